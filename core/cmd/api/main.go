@@ -12,13 +12,18 @@ import (
 	"go.uber.org/zap"
 
 	httpAdapter "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/http"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/notifications"
 	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
 	database "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/messaging"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/application/services"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/debug"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/jwt"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/spool"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/telemetry"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
 
 	_ "github.com/IgorGrieder/Desafio-BTG/tree/main/core/docs"
 )
@@ -28,6 +33,13 @@ import (
 // @description API for managing and querying orders
 // @host localhost:8080
 // @BasePath /api/v1
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT signed by a key of the configured JWKS, sent as "Bearer <token>"
 func main() {
 	ctx := context.Background()
 
@@ -39,7 +51,7 @@ func main() {
 	}
 
 	// Initialize logger
-	if err := logger.Init(cfg.App.Env); err != nil {
+	if err := logger.Init(cfg.App.Env, cfg.App.LogLevel); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
@@ -89,6 +101,7 @@ func main() {
 		cfg.RabbitMQ.URL(),
 		cfg.RabbitMQ.Exchange,
 		cfg.RabbitMQ.Queue,
+		cfg.RabbitMQ.Partitions,
 	)
 	if err != nil {
 		logger.Fatal("Failed to initialize RabbitMQ publisher",
@@ -100,10 +113,61 @@ func main() {
 	logger.Info("RabbitMQ publisher initialized")
 
 	// Initialize services
-	orderService := services.NewOrderService(dbStore, publisher)
+	fxService := services.NewFxService(dbStore)
+	productService := services.NewProductService(dbStore)
+	customerService := services.NewCustomerService(dbStore, cfg.Orders.RequireRegisteredCustomer)
+	var orderService ports.OrderService = services.NewOrderService(dbStore, publisher, fxService, customerService)
+
+	// Background workers run until shutdown
+	backgroundCtx, stopBackground := context.WithCancel(ctx)
+	defer stopBackground()
+
+	// Cache order reads, invalidated by changes the consumer notifies through Postgres
+
+	if cfg.Cache.Enabled {
+		cachedOrderService := services.NewCachedOrderService(orderService, cfg.Cache.Size, cfg.Cache.TTL)
+		orderService = cachedOrderService
+
+		listener := notifications.NewOrderChangeListener(dbConn.Pool, cachedOrderService)
+		go listener.Start(backgroundCtx)
+
+		logger.Info("Order read cache enabled",
+			zap.Int("size", cfg.Cache.Size),
+			zap.Duration("ttl", cfg.Cache.TTL),
+		)
+	}
+
+	// Bearer tokens are only accepted when a JWKS file is configured
+	var verifier *jwt.Verifier
+	if cfg.Auth.JWKSFile != "" {
+		keys, err := jwt.LoadKeySetFile(cfg.Auth.JWKSFile)
+		if err != nil {
+			logger.Fatal("Failed to load JWKS", zap.String("path", cfg.Auth.JWKSFile), zap.Error(err))
+		}
+		verifier = jwt.NewVerifier(keys, cfg.Auth.JWTIssuer, cfg.Auth.JWTAudience, cfg.Auth.JWTLeeway)
+	}
+	authService := services.NewAuthService(dbStore, verifier)
+	if !cfg.Auth.Enabled {
+		logger.Warn("Authentication disabled, every route is anonymous")
+	}
+
+	// Readiness covers the database, the broker and the outbox backlog
+	healthService := services.NewHealthService(dbStore, publisher, cfg.Health.CheckTimeout, cfg.Health.OutboxBacklogThreshold)
+
+	// Writes and sensitive reads are staged by requests and chained in the background
+	var auditSpool *spool.File
+	if cfg.Audit.SpoolFile != "" {
+		auditSpool = spool.NewFile(cfg.Audit.SpoolFile)
+	}
+	auditService := services.NewAuditService(dbStore, auditSpool)
+	if cfg.Audit.Enabled {
+		go services.NewAuditChainer(auditService, cfg.Audit.ChainInterval).Start(backgroundCtx)
+	} else {
+		logger.Warn("Audit log disabled, requests are not recorded")
+	}
 
 	// Initialize HTTP router with middleware chain
-	router := httpAdapter.NewRouter(cfg, orderService)
+	router := httpAdapter.NewRouter(cfg, orderService, fxService, productService, customerService, authService, healthService, auditService)
 
 	// Create HTTP server
 	server := &http.Server{
@@ -114,6 +178,19 @@ func main() {
 		IdleTimeout:  60 * time.Second,
 	}
 
+	// Opt-in profiling and diagnostics on a separate, token-protected listener
+	var debugServer *debug.Server
+	if cfg.Debug.Enabled {
+		states := map[string]debug.StateFunc{
+			"pgx": debug.PoolStats(dbConn.Pool),
+		}
+		if reporter, ok := publisher.(debug.StateReporter); ok {
+			states["amqp"] = func() any { return reporter.DebugState() }
+		}
+		debugServer = debug.NewServer(cfg.Debug.Addr, cfg.Debug.Token, states)
+		debugServer.Start()
+	}
+
 	// Graceful shutdown
 	go func() {
 		sigChan := make(chan os.Signal, 1)
@@ -121,6 +198,7 @@ func main() {
 		<-sigChan
 
 		logger.Info("Shutting down server...")
+		stopBackground()
 
 		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -135,6 +213,12 @@ func main() {
 		if err := server.Shutdown(shutdownCtx); err != nil {
 			logger.Error("Server shutdown error", zap.Error(err))
 		}
+
+		if debugServer != nil {
+			if err := debugServer.Shutdown(shutdownCtx); err != nil {
+				logger.Error("Debug listener shutdown error", zap.Error(err))
+			}
+		}
 	}()
 
 	// Start server
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"go.uber.org/zap"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
+	database "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/application/services"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+)
+
+// create-api-key issues an API key and prints it once. Only its hash is stored,
+// so a lost key cannot be recovered and must be replaced.
+func main() {
+	ctx := context.Background()
+
+	name := flag.String("name", "", "Who or what the key is issued to")
+	scopes := flag.String("scopes", domain.ScopeOrdersRead, "Comma separated scopes: "+strings.Join(domain.KnownScopes, ", "))
+	customers := flag.String("customers", "", "Comma separated customer codes the key is restricted to (default: unrestricted)")
+	flag.Parse()
+
+	if *name == "" {
+		fmt.Fprintln(os.Stderr, "Usage: create-api-key -name <name> [-scopes orders:read,orders:write] [-customers 1,2]")
+		os.Exit(2)
+	}
+
+	var customerCodes []int
+	for code := range strings.SplitSeq(*customers, ",") {
+		if code = strings.TrimSpace(code); code == "" {
+			continue
+		}
+		customerCode, err := strconv.Atoi(code)
+		if err != nil || customerCode < 1 {
+			fmt.Fprintf(os.Stderr, "Invalid customer code %q\n", code)
+			os.Exit(2)
+		}
+		customerCodes = append(customerCodes, customerCode)
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	if err := logger.Init(cfg.App.Env, cfg.App.LogLevel); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	// Initialize database connection
+	dbConn, err := db.NewDB(ctx, cfg.Database.DSN())
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer dbConn.Close()
+
+	dbStore := db.NewStore(dbConn, database.New(dbConn.Pool))
+	authService := services.NewAuthService(dbStore, nil)
+
+	var granted []string
+	for scope := range strings.SplitSeq(*scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			granted = append(granted, scope)
+		}
+	}
+
+	key, err := authService.CreateAPIKey(ctx, *name, granted, customerCodes)
+	if err != nil {
+		logger.Fatal("Failed to create API key", zap.Error(err))
+	}
+
+	logger.Info("API key created",
+		zap.String("name", *name),
+		zap.Strings("scopes", granted),
+		zap.Ints("customer_codes", customerCodes),
+	)
+	fmt.Println(key)
+}
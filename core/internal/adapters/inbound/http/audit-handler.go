@@ -0,0 +1,254 @@
+package http
+
+import (
+	"encoding/csv"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+// Page sizes of the audit log query; the CSV export reads every matching entry
+// in pages of maxAuditPageSize
+const (
+	defaultAuditPageSize = 100
+	maxAuditPageSize     = 1000
+)
+
+// auditCSVHeader names the columns of the audit log export
+var auditCSVHeader = []string{
+	"id", "occurred_at", "principal", "auth_method", "action", "resource",
+	"correlation_id", "source_ip", "outcome", "status_code", "prev_hash", "hash",
+}
+
+type AuditHandler struct {
+	auditService ports.AuditService
+}
+
+func NewAuditHandler(service ports.AuditService) *AuditHandler {
+	return &AuditHandler{auditService: service}
+}
+
+// ListAuditEntries godoc
+// @Summary Query or export the audit log
+// @Description Entries recorded for every write and sensitive read, oldest first: who (principal, auth_method, source_ip), what (action, resource), when, how it ended (outcome, status_code) and the correlation ID of the request.
+// @Description Each entry's hash covers its fields and prev_hash, the hash of the entry before. Pages are walked by passing next_after back as after. With format=csv every matching entry after the cursor is exported at once.
+// @Tags admin
+// @Produce json
+// @Produce text/csv
+// @Param principal query string false "Principal, such as an API key name or token subject"
+// @Param action query string false "Action, such as orders.create"
+// @Param resource query string false "Resource path prefix, such as /api/v1/orders/42"
+// @Param outcome query string false "success, denied or failure"
+// @Param from query string false "Recorded at or after, RFC 3339"
+// @Param to query string false "Recorded before, RFC 3339"
+// @Param after query int false "Only entries with a greater id"
+// @Param limit query int false "Page size, 1 to 1000" default(100)
+// @Param format query string false "json or csv" default(json)
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 401 {object} httputils.ProblemDetails
+// @Failure 403 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/audit-log [get]
+func (h *AuditHandler) ListAuditEntries(w http.ResponseWriter, r *http.Request) {
+	filter, apiErr, ok := parseAuditFilter(r)
+	if !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	switch strings.ToLower(r.URL.Query().Get("format")) {
+	case "", "json":
+	case "csv":
+		h.exportAuditEntries(w, r, filter)
+		return
+	default:
+		httputils.WriteProblem(w, r, constants.ErrInvalidAuditFilter.WithDetail(constants.DetailAuditFormat))
+		return
+	}
+
+	entries, err := h.auditService.ListEntries(r.Context(), filter)
+	if err != nil {
+		logger.Error("failed to list audit entries", zap.Error(err))
+		httputils.WriteProblem(w, r, constants.ErrFailedToListAuditEntries)
+		return
+	}
+
+	items := make([]map[string]any, 0, len(entries))
+	for _, entry := range entries {
+		items = append(items, auditEntryFields(entry))
+	}
+
+	data := map[string]any{"entries": items}
+	if len(entries) == filter.Limit {
+		data["next_after"] = entries[len(entries)-1].ID
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessAuditEntriesListed, data)
+}
+
+// VerifyAuditLog godoc
+// @Summary Verify the audit log
+// @Description Recompute the hash of every entry and check each one chains to the entry before. When the chain is broken, broken_at is the first entry that was altered or follows removed entries.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} httputils.APIResponse
+// @Failure 401 {object} httputils.ProblemDetails
+// @Failure 403 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/audit-log/verify [get]
+func (h *AuditHandler) VerifyAuditLog(w http.ResponseWriter, r *http.Request) {
+	result, err := h.auditService.VerifyChain(r.Context())
+	if err != nil {
+		logger.Error("failed to verify audit log", zap.Error(err))
+		httputils.WriteProblem(w, r, constants.ErrFailedToVerifyAuditLog)
+		return
+	}
+
+	data := map[string]any{
+		"valid":     result.Valid,
+		"checked":   result.Checked,
+		"last_hash": result.LastHash,
+	}
+	if !result.Valid {
+		logger.Error("audit log chain is broken",
+			zap.Int64("broken_at", result.BrokenAt),
+			zap.String("reason", result.Reason),
+		)
+		data["broken_at"] = result.BrokenAt
+		data["reason"] = result.Reason
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessAuditLogVerified, data)
+}
+
+// exportAuditEntries streams every entry matching the filter as CSV. Once the first
+// page is written a failure can only cut the export short, so it is logged.
+func (h *AuditHandler) exportAuditEntries(w http.ResponseWriter, r *http.Request, filter domain.AuditFilter) {
+	filter.Limit = maxAuditPageSize
+
+	entries, err := h.auditService.ListEntries(r.Context(), filter)
+	if err != nil {
+		logger.Error("failed to export audit entries", zap.Error(err))
+		httputils.WriteProblem(w, r, constants.ErrFailedToListAuditEntries)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="audit-log.csv"`)
+
+	writer := csv.NewWriter(w)
+	writer.Write(auditCSVHeader)
+
+	for {
+		for _, entry := range entries {
+			writer.Write(auditEntryRecord(entry))
+		}
+		writer.Flush()
+
+		if len(entries) < filter.Limit {
+			return
+		}
+
+		filter.AfterID = entries[len(entries)-1].ID
+		entries, err = h.auditService.ListEntries(r.Context(), filter)
+		if err != nil {
+			logger.Error("audit export cut short", zap.Int64("after_id", filter.AfterID), zap.Error(err))
+			return
+		}
+	}
+}
+
+// parseAuditFilter reads the filter of the audit log query from the URL
+func parseAuditFilter(r *http.Request) (domain.AuditFilter, constants.APIError, bool) {
+	query := r.URL.Query()
+
+	filter := domain.AuditFilter{
+		Principal: strings.TrimSpace(query.Get("principal")),
+		Action:    strings.TrimSpace(query.Get("action")),
+		Resource:  strings.TrimSpace(query.Get("resource")),
+		Outcome:   domain.AuditOutcome(strings.ToLower(strings.TrimSpace(query.Get("outcome")))),
+		Limit:     defaultAuditPageSize,
+	}
+
+	if filter.Outcome != "" && !filter.Outcome.IsValid() {
+		return filter, constants.ErrInvalidAuditFilter.WithDetail(constants.DetailAuditOutcome), false
+	}
+
+	for name, bound := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		value := query.Get(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return filter, constants.ErrInvalidAuditFilter.WithDetail(constants.DetailAuditTime, "name", name), false
+		}
+		*bound = parsed
+	}
+
+	if value := query.Get("after"); value != "" {
+		after, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || after < 0 {
+			return filter, constants.ErrInvalidAuditFilter.WithDetail(constants.DetailAuditCursor), false
+		}
+		filter.AfterID = after
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxAuditPageSize {
+			return filter, constants.ErrInvalidAuditFilter.WithDetail(constants.DetailAuditLimit, "max", strconv.Itoa(maxAuditPageSize)), false
+		}
+		filter.Limit = limit
+	}
+
+	return filter, constants.APIError{}, true
+}
+
+// auditEntryFields renders an audit entry in responses
+func auditEntryFields(entry *domain.AuditEntry) map[string]any {
+	return map[string]any{
+		"id":             entry.ID,
+		"occurred_at":    entry.OccurredAt.UTC().Format(time.RFC3339Nano),
+		"principal":      entry.Principal,
+		"auth_method":    entry.AuthMethod,
+		"action":         entry.Action,
+		"resource":       entry.Resource,
+		"correlation_id": entry.CorrelationID,
+		"source_ip":      entry.SourceIP,
+		"outcome":        entry.Outcome,
+		"status_code":    entry.StatusCode,
+		"prev_hash":      entry.PrevHash,
+		"hash":           entry.Hash,
+	}
+}
+
+// auditEntryRecord renders an audit entry as a CSV row in the order of auditCSVHeader
+func auditEntryRecord(entry *domain.AuditEntry) []string {
+	return []string{
+		strconv.FormatInt(entry.ID, 10),
+		entry.OccurredAt.UTC().Format(time.RFC3339Nano),
+		entry.Principal,
+		entry.AuthMethod,
+		entry.Action,
+		entry.Resource,
+		entry.CorrelationID,
+		entry.SourceIP,
+		string(entry.Outcome),
+		strconv.Itoa(entry.StatusCode),
+		entry.PrevHash,
+		entry.Hash,
+	}
+}
@@ -0,0 +1,34 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+)
+
+// authorizeCustomer reports whether the caller may see a customer and its orders.
+// Handlers answer a foreign customer exactly like a missing one.
+func authorizeCustomer(r *http.Request, customerCode int) bool {
+	return domain.CanAccessCustomer(r.Context(), customerCode)
+}
+
+// authorizeOrder checks that the caller may see an order before it is read or changed.
+// Orders of other customers are reported as ErrOrderNotFound so their existence does
+// not leak. Unrestricted callers skip the lookup.
+func authorizeOrder(r *http.Request, orderService ports.OrderService, orderCode int32) error {
+	principal, ok := domain.PrincipalFromContext(r.Context())
+	if !ok || !principal.IsCustomerBound() {
+		return nil
+	}
+
+	order, err := orderService.GetOrderByCode(r.Context(), orderCode)
+	if err != nil {
+		return err
+	}
+
+	if !principal.CanAccessCustomer(order.CustomerCode) {
+		return domain.ErrOrderNotFound
+	}
+	return nil
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/http/middleware"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+type CustomerHandler struct {
+	customerService ports.CustomerService
+}
+
+func NewCustomerHandler(service ports.CustomerService) *CustomerHandler {
+	return &CustomerHandler{customerService: service}
+}
+
+type CreateCustomerRequest struct {
+	Code     int    `json:"codigoCliente" validate:"required,gt=0,lte=2147483647" example:"1"`
+	Name     string `json:"nome" validate:"required,max=255" example:"Maria Silva"`
+	Email    string `json:"email" validate:"required,email,max=255" example:"maria@example.com"`
+	Document string `json:"documento" validate:"required,cpf_cnpj" example:"529.982.247-25"`
+	Blocked  bool   `json:"bloqueado" example:"false"`
+}
+
+func (r *CreateCustomerRequest) ToDomain() *domain.Customer {
+	return newCustomer(r.Code, r.Name, r.Email, r.Document, r.Blocked)
+}
+
+type UpdateCustomerRequest struct {
+	Name     string `json:"nome" validate:"required,max=255" example:"Maria Silva"`
+	Email    string `json:"email" validate:"required,email,max=255" example:"maria@example.com"`
+	Document string `json:"documento" validate:"required,cpf_cnpj" example:"529.982.247-25"`
+	Blocked  bool   `json:"bloqueado" example:"false"`
+}
+
+func (r *UpdateCustomerRequest) ToDomain(code int) *domain.Customer {
+	return newCustomer(code, r.Name, r.Email, r.Document, r.Blocked)
+}
+
+// newCustomer normalizes the contact data and document of a customer.
+// The document was validated already, so only its digits and type are kept.
+func newCustomer(code int, name, email, document string, blocked bool) *domain.Customer {
+	digits, documentType, _ := domain.ParseDocument(document)
+
+	status := domain.CustomerStatusActive
+	if blocked {
+		status = domain.CustomerStatusBlocked
+	}
+
+	return &domain.Customer{
+		Code:         code,
+		Name:         strings.TrimSpace(name),
+		Email:        strings.ToLower(strings.TrimSpace(email)),
+		Document:     digits,
+		DocumentType: documentType,
+		Status:       status,
+	}
+}
+
+// CreateCustomer godoc
+// @Summary Create a customer
+// @Description Register a customer under its code. documento is a CPF or CNPJ, formatted or not, with valid check digits; only its digits are stored.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param customer body CreateCustomerRequest true "Customer data"
+// @Success 201 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 409 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/customers [post]
+func (h *CustomerHandler) CreateCustomer(w http.ResponseWriter, r *http.Request) {
+	var req CreateCustomerRequest
+
+	if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	middleware.SetAuditResource(r.Context(), "/api/v1/customers/"+strconv.Itoa(req.Code))
+
+	created, err := h.customerService.CreateCustomer(r.Context(), req.ToDomain())
+	if errors.Is(err, domain.ErrCustomerExists) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerExists)
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToCreateCustomer)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessCustomerCreated, customerFields(created))
+}
+
+// ListCustomers godoc
+// @Summary List customers
+// @Description Get every registered customer ordered by code, including blocked ones.
+// @Description Callers bound to customer codes only see their own customers.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Success 200 {object} httputils.APIResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/customers [get]
+func (h *CustomerHandler) ListCustomers(w http.ResponseWriter, r *http.Request) {
+	customers, err := h.customerService.ListCustomers(r.Context())
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToListCustomers)
+		return
+	}
+
+	items := make([]map[string]any, 0, len(customers))
+	for _, customer := range customers {
+		if authorizeCustomer(r, customer.Code) {
+			items = append(items, customerFields(customer))
+		}
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessCustomersListed, map[string]any{
+		"customers": items,
+	})
+}
+
+// GetCustomer godoc
+// @Summary Get a customer
+// @Description Get a registered customer by its code
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param code path int true "Customer code"
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/customers/{code} [get]
+func (h *CustomerHandler) GetCustomer(w http.ResponseWriter, r *http.Request) {
+	code, err := strconv.ParseInt(r.PathValue("code"), 10, 32)
+	if err != nil || code < 1 {
+		httputils.WriteProblem(w, r, constants.ErrInvalidCustomerCode)
+		return
+	}
+
+	if !authorizeCustomer(r, int(code)) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerNotFound)
+		return
+	}
+
+	customer, err := h.customerService.GetCustomer(r.Context(), int32(code))
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerNotFound)
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToGetCustomer)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessCustomerFound, customerFields(customer))
+}
+
+// UpdateCustomer godoc
+// @Summary Update a customer
+// @Description Replace the name, email, document and status of a customer. Blocked customers cannot place new orders.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param code path int true "Customer code"
+// @Param customer body UpdateCustomerRequest true "Customer data"
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Failure 409 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/customers/{code} [put]
+func (h *CustomerHandler) UpdateCustomer(w http.ResponseWriter, r *http.Request) {
+	code, err := strconv.ParseInt(r.PathValue("code"), 10, 32)
+	if err != nil || code < 1 {
+		httputils.WriteProblem(w, r, constants.ErrInvalidCustomerCode)
+		return
+	}
+
+	if !authorizeCustomer(r, int(code)) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerNotFound)
+		return
+	}
+
+	var req UpdateCustomerRequest
+
+	if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	customer, err := h.customerService.UpdateCustomer(r.Context(), req.ToDomain(int(code)))
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerNotFound)
+		return
+	}
+	if errors.Is(err, domain.ErrCustomerExists) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerExists)
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToUpdateCustomer)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessCustomerUpdated, customerFields(customer))
+}
+
+// customerFields renders a registered customer
+func customerFields(customer *domain.Customer) map[string]any {
+	return map[string]any{
+		"customer_code": customer.Code,
+		"name":          customer.Name,
+		"email":         customer.Email,
+		"document":      customer.Document,
+		"document_type": customer.DocumentType,
+		"status":        customer.Status,
+		"created_at":    customer.CreatedAt.UTC().Format(time.RFC3339),
+		"updated_at":    customer.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
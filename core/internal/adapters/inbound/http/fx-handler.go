@@ -0,0 +1,193 @@
+package http
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+type FxHandler struct {
+	fxService ports.FxService
+}
+
+func NewFxHandler(service ports.FxService) *FxHandler {
+	return &FxHandler{fxService: service}
+}
+
+type ImportFxRatesRequest struct {
+	Rates []FxRateRequest `json:"taxas" validate:"required,min=1,dive"`
+}
+
+type FxRateRequest struct {
+	Base          string `json:"base" validate:"required,iso4217" example:"USD"`
+	Quote         string `json:"cotacao" validate:"required,iso4217" example:"BRL"`
+	Rate          string `json:"taxa" validate:"required" example:"5.1234"`
+	EffectiveDate string `json:"dataVigencia" validate:"required,datetime=2006-01-02" example:"2026-01-02"`
+}
+
+func (r *FxRateRequest) ToDomain() (domain.ExchangeRate, error) {
+	rate, err := domain.ParseRate(r.Rate)
+	if err != nil {
+		return domain.ExchangeRate{}, &domain.ProblemError{Err: domain.ErrInvalidFxRate, Problems: []domain.Problem{{
+			Key:     domain.ProblemInvalidRate,
+			Params:  map[string]string{"rate": r.Rate},
+			Message: fmt.Sprintf("invalid rate %q", r.Rate),
+		}}}
+	}
+
+	effectiveDate, err := time.Parse(time.DateOnly, r.EffectiveDate)
+	if err != nil {
+		return domain.ExchangeRate{}, &domain.ProblemError{Err: domain.ErrInvalidFxRate, Problems: []domain.Problem{{
+			Key:     domain.ProblemInvalidEffectiveDate,
+			Params:  map[string]string{"date": r.EffectiveDate},
+			Message: fmt.Sprintf("invalid effective date %q", r.EffectiveDate),
+		}}}
+	}
+
+	return domain.ExchangeRate{
+		Base:          strings.ToUpper(r.Base),
+		Quote:         strings.ToUpper(r.Quote),
+		Rate:          rate,
+		EffectiveDate: effectiveDate,
+	}, nil
+}
+
+// ImportRates godoc
+// @Summary Import exchange rates
+// @Description Store daily exchange rates used to convert order totals. A rate says one unit of base buys taxa units of cotacao from dataVigencia on; importing the same pair and date again replaces it.
+// @Description The body is either JSON or text/csv with the header base,quote,rate,effective_date. Rates are all stored or none is.
+// @Tags admin
+// @Accept json
+// @Accept text/csv
+// @Produce json
+// @Param rates body ImportFxRatesRequest true "Exchange rates"
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 500 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/fx-rates [post]
+func (h *FxHandler) ImportRates(w http.ResponseWriter, r *http.Request) {
+	var req ImportFxRatesRequest
+
+	switch mediaType := httputils.MediaType(r); {
+	case mediaType == "text/csv":
+		httputils.LimitBody(w, r)
+		rates, apiErr, ok := decodeFxRatesCSV(r.Body)
+		if !ok {
+			httputils.WriteProblem(w, r, apiErr)
+			return
+		}
+		req.Rates = rates
+	case httputils.IsJSONMediaType(mediaType):
+		if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+			httputils.WriteProblem(w, r, apiErr)
+			return
+		}
+	default:
+		httputils.WriteProblem(w, r, constants.ErrUnsupportedMedia.WithDetail(constants.DetailContentTypeJSONOrCSV))
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	rates := make([]domain.ExchangeRate, 0, len(req.Rates))
+	for _, item := range req.Rates {
+		rate, err := item.ToDomain()
+		if err != nil {
+			httputils.WriteProblem(w, r, constants.ErrInvalidFxRate.WithDetails(problemDetails(err)...))
+			return
+		}
+		rates = append(rates, rate)
+	}
+
+	imported, err := h.fxService.ImportRates(r.Context(), rates)
+	if errors.Is(err, domain.ErrInvalidFxRate) {
+		httputils.WriteProblem(w, r, constants.ErrInvalidFxRate.WithDetails(problemDetails(err)...))
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToImportFxRates)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessFxRatesImported, map[string]any{
+		"imported": imported,
+	})
+}
+
+// fxRatesCSVHeader is the header expected on the first line of a CSV import
+var fxRatesCSVHeader = []string{"base", "quote", "rate", "effective_date"}
+
+// decodeFxRatesCSV reads rates from CSV rows in the order of fxRatesCSVHeader. When the
+// body is rejected, the returned APIError is the 400 or 413 to write.
+func decodeFxRatesCSV(body io.Reader) ([]FxRateRequest, constants.APIError, bool) {
+	reader := csv.NewReader(body)
+	reader.FieldsPerRecord = len(fxRatesCSVHeader)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, csvError(err), false
+	}
+	for i, column := range fxRatesCSVHeader {
+		if !strings.EqualFold(strings.TrimSpace(header[i]), column) {
+			return nil, csvHeaderError(), false
+		}
+	}
+
+	var rates []FxRateRequest
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, csvError(err), false
+		}
+
+		rates = append(rates, FxRateRequest{
+			Base:          strings.TrimSpace(record[0]),
+			Quote:         strings.TrimSpace(record[1]),
+			Rate:          strings.TrimSpace(record[2]),
+			EffectiveDate: strings.TrimSpace(record[3]),
+		})
+	}
+
+	return rates, constants.APIError{}, true
+}
+
+// csvHeaderError is the 400 written when the first line is not fxRatesCSVHeader
+func csvHeaderError() constants.APIError {
+	return constants.ErrInvalidFxRate.WithDetail(constants.DetailCSVHeader, "header", strings.Join(fxRatesCSVHeader, ","))
+}
+
+// csvError explains why a CSV body could not be read
+func csvError(err error) constants.APIError {
+	var parseErr *csv.ParseError
+
+	switch {
+	case httputils.BodyTooLarge(err):
+		return httputils.PayloadTooLargeError()
+	case errors.Is(err, io.EOF):
+		return csvHeaderError()
+	case errors.As(err, &parseErr):
+		return constants.ErrInvalidFxRate.WithDetail(constants.DetailMalformedCSV, "line", strconv.Itoa(parseErr.Line))
+	}
+	return constants.ErrInvalidFxRate.WithDetail(constants.DetailUndecodableBody)
+}
@@ -6,38 +6,89 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
 )
 
 // HealthResponse represents the health check response
 type HealthResponse struct {
-	Status    string `json:"status" example:"ok"`
+	Status    string `json:"status" example:"up"`
+	Timestamp string `json:"timestamp" example:"2024-01-15T10:30:00Z"`
+}
+
+// ReadinessResponse reports the instance's readiness and each dependency checked
+type ReadinessResponse struct {
+	domain.HealthReport
 	Timestamp string `json:"timestamp" example:"2024-01-15T10:30:00Z"`
 }
 
 // HealthHandler handles health and metrics endpoints
-type HealthHandler struct{}
+type HealthHandler struct {
+	healthService ports.HealthService
+}
 
 // NewHealthHandler creates a new health handler
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(healthService ports.HealthService) *HealthHandler {
+	return &HealthHandler{healthService: healthService}
 }
 
-// Health returns the health status of the service
-// @Summary      Health check
-// @Description  Returns the health status of the API server
+// Live reports that the process is up and serving HTTP, without checking dependencies,
+// so a broken database or broker never gets the instance restarted
+// @Summary      Liveness probe
+// @Description  Returns 200 while the API process is running. Also served at /health.
 // @Tags         Health
 // @Produce      json
 // @Success      200  {object}  HealthResponse
-// @Router       /health [get]
-func (h *HealthHandler) Health(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	json.NewEncoder(w).Encode(HealthResponse{
-		Status:    "ok",
+// @Router       /health/live [get]
+func (h *HealthHandler) Live(w http.ResponseWriter, r *http.Request) {
+	writeHealth(w, http.StatusOK, HealthResponse{
+		Status:    domain.HealthUp,
 		Timestamp: time.Now().Format(time.RFC3339),
 	})
 }
 
+// Ready reports whether the instance should receive traffic
+// @Summary      Readiness probe
+// @Description  Checks the database, the RabbitMQ connection and channel, and the outbox backlog, reporting each component's status and latency. Returns 503 when any is down; why a check failed is logged, not returned.
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  ReadinessResponse
+// @Failure      503  {object}  ReadinessResponse
+// @Router       /health/ready [get]
+func (h *HealthHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	report := h.healthService.Readiness(r.Context())
+
+	status := http.StatusOK
+	if !report.Ready() {
+		status = http.StatusServiceUnavailable
+	}
+	for _, component := range report.Components {
+		if component.Status != domain.HealthUp {
+			logger.Warn("readiness check failed",
+				zap.String("component", component.Name),
+				zap.String("error", component.Error),
+			)
+		}
+	}
+
+	writeHealth(w, status, ReadinessResponse{
+		HealthReport: report,
+		Timestamp:    time.Now().Format(time.RFC3339),
+	})
+}
+
 // Metrics returns Prometheus metrics
 func (h *HealthHandler) Metrics() http.Handler {
 	return promhttp.Handler()
 }
+
+// writeHealth writes probe responses bare, without the API envelope, and uncached
+func writeHealth(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,69 @@
+package http_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	http_internal "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/http"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+)
+
+type fakeHealthService struct {
+	report domain.HealthReport
+}
+
+func (f fakeHealthService) Readiness(ctx context.Context) domain.HealthReport {
+	return f.report
+}
+
+func TestHealthHandler_Ready(t *testing.T) {
+	tests := []struct {
+		name       string
+		report     domain.HealthReport
+		wantStatus int
+	}{
+		{
+			name: "all components up",
+			report: domain.HealthReport{Status: domain.HealthUp, Components: []domain.ComponentHealth{
+				{Name: "database", Status: domain.HealthUp},
+				{Name: "rabbitmq", Status: domain.HealthUp},
+			}},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "broker down",
+			report: domain.HealthReport{Status: domain.HealthDown, Components: []domain.ComponentHealth{
+				{Name: "database", Status: domain.HealthUp},
+				{Name: "rabbitmq", Status: domain.HealthDown, Error: "connection closed"},
+			}},
+			wantStatus: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := http_internal.NewHealthHandler(fakeHealthService{report: tt.report})
+			w := httptest.NewRecorder()
+			h.Ready(w, httptest.NewRequest(http.MethodGet, "/health/ready", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+
+			var body http_internal.ReadinessResponse
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Status != tt.report.Status || len(body.Components) != len(tt.report.Components) {
+				t.Errorf("unexpected body %+v", body)
+			}
+			if strings.Contains(w.Body.String(), "connection closed") {
+				t.Errorf("dependency error leaked into the response: %s", w.Body.String())
+			}
+		})
+	}
+}
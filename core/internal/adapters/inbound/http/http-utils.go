@@ -10,9 +10,3 @@ import (
 func RespondJSON(w http.ResponseWriter, statusCode int, data any) {
 	httputils.RespondJSON(w, statusCode, data)
 }
-
-// RespondError writes a JSON error response (legacy wrapper).
-// Consider using httputils.WriteAPIError for new code.
-func RespondError(w http.ResponseWriter, statusCode int, message string, details map[string]string) {
-	httputils.RespondError(w, statusCode, message, details)
-}
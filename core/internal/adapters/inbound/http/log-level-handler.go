@@ -0,0 +1,157 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+// LogLevelHandler reads and changes log levels at runtime
+type LogLevelHandler struct{}
+
+// NewLogLevelHandler creates a new LogLevelHandler
+func NewLogLevelHandler() *LogLevelHandler {
+	return &LogLevelHandler{}
+}
+
+type SetLogLevelRequest struct {
+	Logger      string `json:"logger" validate:"omitempty,max=100" example:"http"`
+	Level       string `json:"nivel" validate:"required" example:"debug"`
+	RevertAfter string `json:"reverterApos" example:"15m"`
+}
+
+// ListLogLevels godoc
+// @Summary List log levels
+// @Description The global log level, with an empty logger, followed by the named loggers given a level of their own, each with the time it reverts when changed temporarily.
+// @Tags admin
+// @Produce json
+// @Success 200 {object} httputils.APIResponse
+// @Failure 401 {object} httputils.ProblemDetails
+// @Failure 403 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/log-levels [get]
+func (h *LogLevelHandler) ListLogLevels(w http.ResponseWriter, r *http.Request) {
+	settings := logger.Levels()
+
+	levels := make([]map[string]any, 0, len(settings))
+	for _, setting := range settings {
+		levels = append(levels, logLevelFields(setting))
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessLogLevelsListed, levels)
+}
+
+// SetLogLevel godoc
+// @Summary Change a log level
+// @Description Change the global level, when logger is empty, or the level of a named logger such as http, rabbitmq or notifications, which also applies to its children. With reverterApos the change is undone once that duration elapses.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param level body SetLogLevelRequest true "Level"
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 401 {object} httputils.ProblemDetails
+// @Failure 403 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/log-levels [put]
+func (h *LogLevelHandler) SetLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req SetLogLevelRequest
+
+	if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil || level < zapcore.DebugLevel || level > zapcore.ErrorLevel {
+		httputils.WriteProblem(w, r, constants.ErrInvalidLogLevel.WithDetail(constants.DetailUnknownLogLevel, "level", req.Level))
+		return
+	}
+
+	var revertAfter time.Duration
+	if req.RevertAfter != "" {
+		revertAfter, err = time.ParseDuration(req.RevertAfter)
+		if err != nil || revertAfter <= 0 {
+			httputils.WriteProblem(w, r, constants.ErrInvalidLogLevel.WithDetail(constants.DetailInvalidRevertDelay, "delay", req.RevertAfter))
+			return
+		}
+	}
+
+	name := strings.TrimSpace(req.Logger)
+	setting, err := logger.SetLevel(name, level, revertAfter)
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrInvalidLogLevel.WithDetail(constants.DetailInvalidRevertDelay, "delay", req.RevertAfter))
+		return
+	}
+
+	// Logged at warn, which every level but error lets through
+	fields := []zap.Field{
+		zap.String("logger", name),
+		zap.String("level", level.String()),
+		zap.Duration("revert_after", revertAfter),
+	}
+	if principal, ok := domain.PrincipalFromContext(r.Context()); ok {
+		fields = append(fields, zap.String("principal", principal.Subject))
+	}
+	logger.Warn("Log level changed", fields...)
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessLogLevelUpdated, logLevelFields(setting))
+}
+
+// ResetLogLevel godoc
+// @Summary Reset a named logger's level
+// @Description Drop the level given to a named logger, which then follows the global level again.
+// @Tags admin
+// @Produce json
+// @Param logger path string true "Logger name"
+// @Success 200 {object} httputils.APIResponse
+// @Failure 401 {object} httputils.ProblemDetails
+// @Failure 403 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/admin/log-levels/{logger} [delete]
+func (h *LogLevelHandler) ResetLogLevel(w http.ResponseWriter, r *http.Request) {
+	name := r.PathValue("logger")
+
+	if !logger.ResetLevel(name) {
+		httputils.WriteProblem(w, r, constants.ErrLogLevelNotFound)
+		return
+	}
+
+	logger.Warn("Log level reset", zap.String("logger", name))
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessLogLevelReset, map[string]any{
+		"logger": name,
+		"level":  logger.Level.Level().String(),
+	})
+}
+
+// logLevelFields renders the level in force for a logger
+func logLevelFields(setting logger.LevelSetting) map[string]any {
+	fields := map[string]any{
+		"logger": setting.Name,
+		"level":  setting.Level.String(),
+	}
+	if !setting.RevertAt.IsZero() {
+		fields["revert_at"] = setting.RevertAt.UTC().Format(time.RFC3339)
+	}
+	return fields
+}
@@ -0,0 +1,134 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+// auditRecordTimeout bounds how long storing an entry may hold the request back
+const auditRecordTimeout = 5 * time.Second
+
+// AuditMiddleware records an audit entry for every request to a route that changes
+// data, any method but GET, HEAD and OPTIONS, and for GET requests to the routes in
+// sensitiveReads. Entries name the action after the route in actions, falling back to
+// the mux pattern, and are recorded after the response, whatever its outcome, so
+// requests rejected by the auth middleware it wraps are recorded as denied.
+// Recording only stages the entry, so requests never wait on the hash chain. An entry
+// the database refuses is spooled to local disk and replayed by the audit chainer;
+// only when the spool fails too is it lost, and then it is logged in full. Neither
+// case changes the response, which was already written.
+func AuditMiddleware(audit ports.AuditService, mux *http.ServeMux, actions map[string]string, sensitiveReads map[string]bool, trustProxy bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, route := mux.Handler(r)
+			if route == "" || !audited(r.Method, route, sensitiveReads) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// The auth middleware and handlers report the principal and resource through the context
+			r, principal := withRequestPrincipal(r)
+			record := &auditRecord{resource: r.URL.Path}
+			wrapped := &auditResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+			next.ServeHTTP(wrapped, r.WithContext(context.WithValue(r.Context(), auditRecordKey{}, record)))
+
+			action, ok := actions[route]
+			if !ok {
+				action = route
+			}
+
+			entry := &domain.AuditEntry{
+				OccurredAt:    time.Now(),
+				Action:        action,
+				Resource:      record.resource,
+				CorrelationID: r.Header.Get(httputils.CorrelationIDHeader),
+				SourceIP:      clientIP(r, trustProxy),
+				Outcome:       auditOutcome(wrapped.statusCode),
+				StatusCode:    wrapped.statusCode,
+			}
+			if principal.principal != nil {
+				entry.Principal = principal.principal.Subject
+				entry.AuthMethod = principal.principal.Method
+			}
+
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(r.Context()), auditRecordTimeout)
+			defer cancel()
+			if err := audit.Record(ctx, entry); errors.Is(err, domain.ErrAuditEntrySpooled) {
+				logger.Warn("audit entry spooled for replay",
+					zap.String("action", entry.Action),
+					zap.String("correlation_id", entry.CorrelationID),
+					zap.Error(err),
+				)
+			} else if err != nil {
+				// Logged in full so the entry can be reconstructed from the logs
+				logger.Error("failed to record audit entry",
+					zap.String("action", entry.Action),
+					zap.String("resource", entry.Resource),
+					zap.String("principal", entry.Principal),
+					zap.String("source_ip", entry.SourceIP),
+					zap.String("outcome", string(entry.Outcome)),
+					zap.Int("status_code", entry.StatusCode),
+					zap.String("correlation_id", entry.CorrelationID),
+					zap.Error(err),
+				)
+			}
+		})
+	}
+}
+
+// SetAuditResource names the resource a request acted on when its path does not,
+// such as the order a POST to the collection created
+func SetAuditResource(ctx context.Context, resource string) {
+	if record, ok := ctx.Value(auditRecordKey{}).(*auditRecord); ok {
+		record.resource = resource
+	}
+}
+
+// audited reports whether requests of a method to a route are recorded
+func audited(method, route string, sensitiveReads map[string]bool) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return sensitiveReads[route]
+	default:
+		return true
+	}
+}
+
+// auditOutcome classifies a response: 401 and 403 were denied, other errors failed
+func auditOutcome(status int) domain.AuditOutcome {
+	switch {
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return domain.AuditOutcomeDenied
+	case status >= http.StatusBadRequest:
+		return domain.AuditOutcomeFailure
+	default:
+		return domain.AuditOutcomeSuccess
+	}
+}
+
+// auditRecord collects details known only once the handler ran
+type auditRecord struct {
+	resource string
+}
+
+type auditRecordKey struct{}
+
+// auditResponseWriter wraps http.ResponseWriter to capture the status code
+type auditResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (rw *auditResponseWriter) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+)
+
+// stubAuditService keeps the entries it is asked to record
+type stubAuditService struct {
+	entries []*domain.AuditEntry
+}
+
+func (s *stubAuditService) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	s.entries = append(s.entries, entry)
+	return nil
+}
+
+func (s *stubAuditService) ChainStaged(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (s *stubAuditService) ReplaySpooled(ctx context.Context) (int, error) {
+	return 0, nil
+}
+
+func (s *stubAuditService) ListEntries(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	return s.entries, nil
+}
+
+func (s *stubAuditService) VerifyChain(ctx context.Context) (domain.AuditVerification, error) {
+	return domain.AuditVerification{Valid: true}, nil
+}
+
+func TestAuditMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/orders", func(w http.ResponseWriter, r *http.Request) {
+		SetAuditResource(r.Context(), "/api/v1/orders/42")
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("GET /api/v1/orders/{code}/total", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /api/v1/products", func(w http.ResponseWriter, r *http.Request) {})
+
+	auth := &stubAuthService{keys: map[string]*domain.Principal{
+		"writer": {Subject: "writer", Method: domain.AuthMethodAPIKey, Scopes: []string{domain.ScopeOrdersWrite}},
+	}}
+	routes := AuthMiddleware(auth, mux, map[string]string{
+		"POST /api/v1/orders":             domain.ScopeOrdersWrite,
+		"GET /api/v1/orders/{code}/total": domain.ScopeOrdersRead,
+		"GET /api/v1/products":            "",
+	})(mux)
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		key           string
+		forwardedFor  string
+		trustProxy    bool
+		wantAudited   bool
+		wantAction    string
+		wantResource  string
+		wantPrincipal string
+		wantOutcome   domain.AuditOutcome
+		wantSourceIP  string
+	}{
+		{
+			name: "write names the created resource", method: http.MethodPost, path: "/api/v1/orders", key: "writer",
+			wantAudited: true, wantAction: "orders.create", wantResource: "/api/v1/orders/42",
+			wantPrincipal: "writer", wantOutcome: domain.AuditOutcomeSuccess, wantSourceIP: "192.0.2.1",
+		},
+		{
+			name: "sensitive read outside the caller's scope is denied", method: http.MethodGet, path: "/api/v1/orders/7/total", key: "writer",
+			wantAudited: true, wantAction: "GET /api/v1/orders/{code}/total", wantResource: "/api/v1/orders/7/total",
+			wantPrincipal: "writer", wantOutcome: domain.AuditOutcomeDenied, wantSourceIP: "192.0.2.1",
+		},
+		{
+			name: "write without credentials is denied", method: http.MethodPost, path: "/api/v1/orders",
+			forwardedFor: "203.0.113.9", trustProxy: true,
+			wantAudited: true, wantAction: "orders.create", wantResource: "/api/v1/orders",
+			wantOutcome: domain.AuditOutcomeDenied, wantSourceIP: "203.0.113.9",
+		},
+		{
+			name: "forwarded address is ignored without a trusted proxy", method: http.MethodPost, path: "/api/v1/orders", key: "writer",
+			forwardedFor: "203.0.113.9",
+			wantAudited:  true, wantAction: "orders.create", wantResource: "/api/v1/orders/42",
+			wantPrincipal: "writer", wantOutcome: domain.AuditOutcomeSuccess, wantSourceIP: "192.0.2.1",
+		},
+		{
+			name: "other reads are not audited", method: http.MethodGet, path: "/api/v1/products",
+		},
+		{
+			name: "unmatched routes are not audited", method: http.MethodPost, path: "/unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			audit := &stubAuditService{}
+			handler := AuditMiddleware(audit, mux,
+				map[string]string{"POST /api/v1/orders": "orders.create"},
+				map[string]bool{"GET /api/v1/orders/{code}/total": true},
+				tt.trustProxy,
+			)(routes)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.RemoteAddr = "192.0.2.1:4321"
+			req.Header.Set("X-Correlation-Id", "corr-1")
+			if tt.key != "" {
+				req.Header.Set(APIKeyHeader, tt.key)
+			}
+			if tt.forwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", tt.forwardedFor)
+			}
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			if !tt.wantAudited {
+				if len(audit.entries) != 0 {
+					t.Fatalf("expected no audit entry, got %+v", audit.entries[0])
+				}
+				return
+			}
+			if len(audit.entries) != 1 {
+				t.Fatalf("expected one audit entry, got %d", len(audit.entries))
+			}
+
+			entry := audit.entries[0]
+			if entry.Action != tt.wantAction {
+				t.Errorf("action = %q, want %q", entry.Action, tt.wantAction)
+			}
+			if entry.Resource != tt.wantResource {
+				t.Errorf("resource = %q, want %q", entry.Resource, tt.wantResource)
+			}
+			if entry.Principal != tt.wantPrincipal {
+				t.Errorf("principal = %q, want %q", entry.Principal, tt.wantPrincipal)
+			}
+			if entry.Outcome != tt.wantOutcome {
+				t.Errorf("outcome = %q, want %q", entry.Outcome, tt.wantOutcome)
+			}
+			if entry.SourceIP != tt.wantSourceIP {
+				t.Errorf("source IP = %q, want %q", entry.SourceIP, tt.wantSourceIP)
+			}
+			if entry.CorrelationID != "corr-1" {
+				t.Errorf("correlation ID = %q, want corr-1", entry.CorrelationID)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+// AuthMiddleware authenticates requests by API key (X-API-Key) or bearer token
+// (Authorization) and checks the scope routeScopes assigns to the matched mux pattern.
+// Routes mapped to an empty scope are public, and so are requests the mux will
+// answer with 404 or 405. Routes missing from routeScopes are denied, so a new
+// route cannot be exposed by forgetting to list it.
+func AuthMiddleware(auth ports.AuthService, mux *http.ServeMux, routeScopes map[string]string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, route := mux.Handler(r)
+			if route == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			scope, ok := routeScopes[route]
+			if ok && scope == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			principal, err := authenticate(r, auth)
+			if err != nil {
+				if !errors.Is(err, domain.ErrInvalidCredentials) {
+					logger.Error("failed to authenticate request", zap.Error(err))
+					httputils.WriteProblem(w, r, constants.ErrInternalError)
+					return
+				}
+				w.Header().Set("WWW-Authenticate", `Bearer realm="btg-core-api"`)
+				httputils.WriteProblem(w, r, constants.ErrUnauthorized)
+				return
+			}
+
+			setRequestPrincipal(r.Context(), principal)
+
+			if !ok || (!principal.HasScope(scope) && !principal.HasScope(domain.ScopeAdmin)) {
+				httputils.WriteProblem(w, r, constants.ErrForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(domain.ContextWithPrincipal(r.Context(), principal)))
+		})
+	}
+}
+
+// requestPrincipal carries the principal the auth middleware resolves out to the
+// middlewares wrapping it, which only see the request they passed on
+type requestPrincipal struct {
+	principal *domain.Principal
+}
+
+type requestPrincipalKey struct{}
+
+// withRequestPrincipal returns the request with a holder the auth middleware fills in,
+// so the principal can be read once inner middlewares ran. Middlewares share the
+// holder an outer one added.
+func withRequestPrincipal(r *http.Request) (*http.Request, *requestPrincipal) {
+	if holder, ok := r.Context().Value(requestPrincipalKey{}).(*requestPrincipal); ok {
+		return r, holder
+	}
+	holder := &requestPrincipal{}
+	return r.WithContext(context.WithValue(r.Context(), requestPrincipalKey{}, holder)), holder
+}
+
+// setRequestPrincipal records the authenticated principal for the middlewares wrapping auth
+func setRequestPrincipal(ctx context.Context, principal *domain.Principal) {
+	if holder, ok := ctx.Value(requestPrincipalKey{}).(*requestPrincipal); ok {
+		holder.principal = principal
+	}
+}
+
+// authenticate resolves the credentials of a request. A request carrying neither
+// an API key nor a bearer token is reported as ErrInvalidCredentials.
+func authenticate(r *http.Request, auth ports.AuthService) (*domain.Principal, error) {
+	if key := r.Header.Get(APIKeyHeader); key != "" {
+		return auth.AuthenticateAPIKey(r.Context(), key)
+	}
+
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if found && strings.EqualFold(scheme, "Bearer") && token != "" {
+		return auth.AuthenticateToken(r.Context(), strings.TrimSpace(token))
+	}
+
+	return nil, domain.ErrInvalidCredentials
+}
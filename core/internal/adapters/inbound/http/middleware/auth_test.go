@@ -0,0 +1,89 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+)
+
+// stubAuthService accepts the API keys it holds and rejects every token
+type stubAuthService struct {
+	keys map[string]*domain.Principal
+}
+
+func (s *stubAuthService) AuthenticateAPIKey(ctx context.Context, key string) (*domain.Principal, error) {
+	if principal, ok := s.keys[key]; ok {
+		return principal, nil
+	}
+	return nil, domain.ErrInvalidCredentials
+}
+
+func (s *stubAuthService) AuthenticateToken(ctx context.Context, token string) (*domain.Principal, error) {
+	return nil, domain.ErrInvalidCredentials
+}
+
+func (s *stubAuthService) CreateAPIKey(ctx context.Context, name string, scopes []string, customerCodes []int) (string, error) {
+	return "", nil
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/orders", func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := domain.PrincipalFromContext(r.Context()); !ok {
+			t.Error("expected the principal in the request context")
+		}
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {})
+	mux.HandleFunc("GET /unlisted", func(w http.ResponseWriter, r *http.Request) {})
+
+	auth := &stubAuthService{keys: map[string]*domain.Principal{
+		"reader": {Subject: "reader", Method: domain.AuthMethodAPIKey, Scopes: []string{domain.ScopeOrdersRead}},
+		"writer": {Subject: "writer", Method: domain.AuthMethodAPIKey, Scopes: []string{domain.ScopeOrdersWrite}},
+		"admin":  {Subject: "admin", Method: domain.AuthMethodAPIKey, Scopes: []string{domain.ScopeAdmin}},
+	}}
+	handler := AuthMiddleware(auth, mux, map[string]string{
+		"POST /api/v1/orders": domain.ScopeOrdersWrite,
+		"GET /health":         "",
+	})(mux)
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		header string
+		value  string
+		want   int
+	}{
+		{"public route", http.MethodGet, "/health", "", "", http.StatusOK},
+		{"no credentials", http.MethodPost, "/api/v1/orders", "", "", http.StatusUnauthorized},
+		{"unknown key", http.MethodPost, "/api/v1/orders", APIKeyHeader, "nope", http.StatusUnauthorized},
+		{"rejected token", http.MethodPost, "/api/v1/orders", "Authorization", "Bearer abc", http.StatusUnauthorized},
+		{"missing scope", http.MethodPost, "/api/v1/orders", APIKeyHeader, "reader", http.StatusForbidden},
+		{"granted scope", http.MethodPost, "/api/v1/orders", APIKeyHeader, "writer", http.StatusCreated},
+		{"admin scope", http.MethodPost, "/api/v1/orders", APIKeyHeader, "admin", http.StatusCreated},
+		{"unlisted route", http.MethodGet, "/unlisted", APIKeyHeader, "writer", http.StatusForbidden},
+		{"unknown route", http.MethodGet, "/nowhere", "", "", http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				r.Header.Set(tt.header, tt.value)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.want {
+				t.Fatalf("expected %d, got %d", tt.want, w.Code)
+			}
+			if tt.want == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("expected a WWW-Authenticate challenge")
+			}
+		})
+	}
+}
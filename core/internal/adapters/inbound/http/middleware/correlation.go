@@ -0,0 +1,20 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+// CorrelationIDMiddleware gives every request a correlation ID, keeping the one the
+// client sent, so responses, problem details and logs written for the request all
+// carry the same ID
+func CorrelationIDMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		correlationID := httputils.GetCorrelationID(r)
+		r.Header.Set(httputils.CorrelationIDHeader, correlationID)
+		w.Header().Set(httputils.CorrelationIDHeader, correlationID)
+
+		next.ServeHTTP(w, r)
+	})
+}
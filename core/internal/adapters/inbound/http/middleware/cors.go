@@ -1,20 +1,93 @@
 package middleware
 
-import "net/http"
-
-// CORSMiddleware adds CORS headers to responses
-func CORSMiddleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Correlation-Id")
-
-		// Handle preflight requests
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+// CORSMiddleware applies the configured CORS policy. Requests from allowed origins
+// get their origin echoed back with Vary: Origin; other origins get no CORS headers,
+// so the browser blocks them, and their preflights are refused with 403.
+// Credential origins also get Access-Control-Allow-Credentials.
+func CORSMiddleware(cfg config.CORSConfig) func(http.Handler) http.Handler {
+	allowMethods := strings.Join(cfg.AllowedMethods, ", ")
+	allowHeaders := strings.Join(cfg.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(cfg.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cfg.MaxAge.Seconds()))
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// Responses differ per origin, so shared caches must key on it
+			w.Header().Add("Vary", "Origin")
+
+			credentials := matchOrigin(cfg.CredentialOrigins, origin)
+			if !credentials && !matchOrigin(cfg.AllowedOrigins, origin) {
+				if preflight {
+					httputils.WriteProblem(w, r, constants.ErrForbidden.WithDetail(constants.DetailOriginNotAllowed))
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			if credentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
 
-		next.ServeHTTP(w, r)
-	})
+			if preflight {
+				w.Header().Add("Vary", "Access-Control-Request-Method")
+				w.Header().Add("Vary", "Access-Control-Request-Headers")
+				w.Header().Set("Access-Control-Allow-Methods", allowMethods)
+				w.Header().Set("Access-Control-Allow-Headers", allowHeaders)
+				w.Header().Set("Access-Control-Max-Age", maxAge)
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if exposeHeaders != "" {
+				w.Header().Set("Access-Control-Expose-Headers", exposeHeaders)
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// matchOrigin reports whether an origin matches one of the configured patterns.
+// "https://*.example.com" matches any subdomain of example.com over https, but not
+// example.com itself.
+func matchOrigin(patterns []string, origin string) bool {
+	origin = strings.ToLower(origin)
+	if slices.Contains(patterns, "*") || slices.Contains(patterns, origin) {
+		return true
+	}
+
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		patternScheme, patternHost, _ := strings.Cut(pattern, "://")
+		suffix, wildcard := strings.CutPrefix(patternHost, "*")
+		if wildcard && patternScheme == scheme && strings.HasSuffix(host, suffix) && len(host) > len(suffix) {
+			return true
+		}
+	}
+	return false
 }
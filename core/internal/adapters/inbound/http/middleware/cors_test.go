@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/config"
+)
+
+func TestCORSMiddleware(t *testing.T) {
+	handler := CORSMiddleware(config.CORSConfig{
+		AllowedOrigins:    []string{"https://partner.example.org", "https://*.example.com"},
+		CredentialOrigins: []string{"https://app.example.com"},
+		AllowedMethods:    []string{"GET", "POST"},
+		AllowedHeaders:    []string{"Content-Type", "Authorization"},
+		ExposedHeaders:    []string{"X-Correlation-Id"},
+		MaxAge:            10 * time.Minute,
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	tests := []struct {
+		name        string
+		origin      string
+		preflight   bool
+		wantStatus  int
+		wantOrigin  string
+		credentials bool
+	}{
+		{"no origin", "", false, http.StatusOK, "", false},
+		{"exact origin", "https://partner.example.org", false, http.StatusOK, "https://partner.example.org", false},
+		{"wildcard subdomain", "https://shop.example.com", false, http.StatusOK, "https://shop.example.com", false},
+		{"wildcard skips apex", "https://example.com", false, http.StatusOK, "", false},
+		{"wildcard checks scheme", "http://shop.example.com", false, http.StatusOK, "", false},
+		{"suffix is not a subdomain", "https://evilexample.com", false, http.StatusOK, "", false},
+		{"credential origin", "https://app.example.com", false, http.StatusOK, "https://app.example.com", true},
+		{"allowed preflight", "https://app.example.com", true, http.StatusNoContent, "https://app.example.com", true},
+		{"foreign preflight", "https://evil.test", true, http.StatusForbidden, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := http.MethodGet
+			if tt.preflight {
+				method = http.MethodOptions
+			}
+			r := httptest.NewRequest(method, "/api/v1/orders", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.preflight {
+				r.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			}
+			w := httptest.NewRecorder()
+			handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected status %d, got %d", tt.wantStatus, w.Code)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Origin"); got != tt.wantOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin %q, got %q", tt.wantOrigin, got)
+			}
+			if got := w.Header().Get("Access-Control-Allow-Credentials") == "true"; got != tt.credentials {
+				t.Errorf("expected credentials %v, got %v", tt.credentials, got)
+			}
+			if tt.origin != "" && w.Header().Get("Vary") != "Origin" {
+				t.Errorf("expected Vary: Origin, got %v", w.Header().Values("Vary"))
+			}
+			if tt.preflight && tt.wantOrigin != "" && w.Header().Get("Access-Control-Max-Age") != "600" {
+				t.Errorf("expected Access-Control-Max-Age 600, got %q", w.Header().Get("Access-Control-Max-Age"))
+			}
+			if !tt.preflight && tt.wantOrigin != "" && w.Header().Get("Access-Control-Expose-Headers") != "X-Correlation-Id" {
+				t.Errorf("expected X-Correlation-Id to be exposed, got %q", w.Header().Get("Access-Control-Expose-Headers"))
+			}
+		})
+	}
+}
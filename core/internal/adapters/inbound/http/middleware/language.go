@@ -0,0 +1,23 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/i18n"
+)
+
+// LanguageMiddleware negotiates the response language from Accept-Language, falling
+// back to defaultLanguage, and stores it in the request context for problem titles,
+// validation messages and success messages
+func LanguageMiddleware(defaultLanguage string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			language := i18n.Negotiate(r.Header.Get("Accept-Language"), defaultLanguage)
+
+			w.Header().Set("Content-Language", language)
+			w.Header().Add("Vary", "Accept-Language")
+
+			next.ServeHTTP(w, r.WithContext(i18n.WithLanguage(r.Context(), language)))
+		})
+	}
+}
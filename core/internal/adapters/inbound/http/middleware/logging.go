@@ -5,18 +5,24 @@ import (
 	"time"
 
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
-// LoggingMiddleware logs incoming requests with Zap and includes trace context
+// LoggingMiddleware logs incoming requests with Zap and includes trace context.
+// Requests are logged by the "http" logger, so their level can be set apart.
 func LoggingMiddleware(next http.Handler) http.Handler {
+	log := logger.Named("http")
+
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
 
 		// Wrap response writer to capture status code
 		wrapped := &loggingResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
+		// The auth middleware reports the principal through the request context
+		r, entry := withRequestPrincipal(r)
 		next.ServeHTTP(wrapped, r)
 
 		duration := time.Since(start)
@@ -28,6 +34,14 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 			zap.Int("status", wrapped.statusCode),
 			zap.Duration("duration", duration),
 			zap.String("remote_addr", r.RemoteAddr),
+			zap.String("correlation_id", r.Header.Get(httputils.CorrelationIDHeader)),
+		}
+
+		if entry.principal != nil {
+			fields = append(fields,
+				zap.String("principal", entry.principal.Subject),
+				zap.String("auth_method", entry.principal.Method),
+			)
 		}
 
 		// Add trace context if available
@@ -39,7 +53,7 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 			)
 		}
 
-		logger.Info("request completed", fields...)
+		log.Info("request completed", fields...)
 	})
 }
 
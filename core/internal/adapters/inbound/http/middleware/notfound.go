@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+// UnmatchedRouteMiddleware answers requests no route matches with problem details
+// instead of the plain text 404 and 405 the mux writes. Other unmatched responses,
+// such as redirects to a canonical path, are passed through unchanged.
+func UnmatchedRouteMiddleware(mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler, route := mux.Handler(r)
+			if route != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			captured := &capturedResponse{header: make(http.Header), status: http.StatusOK}
+			handler.ServeHTTP(captured, r)
+
+			switch captured.status {
+			case http.StatusNotFound:
+				httputils.WriteProblem(w, r, constants.ErrNotFound)
+			case http.StatusMethodNotAllowed:
+				w.Header().Set("Allow", captured.header.Get("Allow"))
+				httputils.WriteProblem(w, r, constants.ErrMethodNotAllowed)
+			default:
+				for key, values := range captured.header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(captured.status)
+				w.Write(captured.body.Bytes())
+			}
+		})
+	}
+}
+
+// capturedResponse buffers what the mux's fallback handlers write
+type capturedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (c *capturedResponse) Header() http.Header {
+	return c.header
+}
+
+func (c *capturedResponse) WriteHeader(status int) {
+	c.status = status
+}
+
+func (c *capturedResponse) Write(data []byte) (int, error) {
+	return c.body.Write(data)
+}
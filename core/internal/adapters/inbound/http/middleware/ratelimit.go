@@ -0,0 +1,244 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+// APIKeyHeader identifies a client independently of its address
+const APIKeyHeader = "X-API-Key"
+
+// sweepInterval is how often buckets that refilled completely are dropped
+const sweepInterval = 10 * time.Minute
+
+var httpRequestsThrottled = promauto.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "http_requests_throttled_total",
+		Help: "Total number of HTTP requests rejected by the rate limiter",
+	},
+	[]string{"method", "path", "client_type"},
+)
+
+// RateLimiter keeps one token bucket per client and route. Routes without
+// their own limit share the default one. An API key only gets buckets of its own
+// once it has authenticated; until then its requests count against the caller's IP,
+// so sending a new made-up key with every request does not escape the limit.
+type RateLimiter struct {
+	defaultLimit config.RateLimit
+	routes       map[string]config.RateLimit
+	trustProxy   bool
+	now          func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[bucketKey]*bucket
+	keys      map[string]time.Time
+	lastSweep time.Time
+}
+
+type bucketKey struct {
+	client string
+	route  string
+}
+
+type bucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// NewRateLimiter creates a limiter from the rate limit configuration
+func NewRateLimiter(cfg config.RateLimitConfig) *RateLimiter {
+	return &RateLimiter{
+		defaultLimit: cfg.Default,
+		routes:       cfg.Routes,
+		trustProxy:   cfg.TrustProxy,
+		now:          time.Now,
+		buckets:      make(map[bucketKey]*bucket),
+		keys:         make(map[string]time.Time),
+	}
+}
+
+// RateLimitMiddleware throttles requests per client before they reach the mux.
+// The route is the mux pattern the request matches, so limits are configured with
+// the same "METHOD /path/{param}" strings the router registers.
+func RateLimitMiddleware(limiter *RateLimiter, mux *http.ServeMux) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, route := mux.Handler(r)
+			if route == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			client, clientType := limiter.clientKey(r)
+			limit := limiter.limitFor(route)
+			allowed, remaining, retryAfter := limiter.take(bucketKey{client: client, route: route}, limit)
+
+			w.Header().Set("RateLimit-Limit", strconv.Itoa(limit.Burst))
+			w.Header().Set("RateLimit-Remaining", strconv.Itoa(remaining))
+			w.Header().Set("RateLimit-Reset", strconv.Itoa(ceilSeconds(resetAfter(limit, remaining, retryAfter))))
+
+			if !allowed {
+				httpRequestsThrottled.WithLabelValues(r.Method, route, clientType).Inc()
+				w.Header().Set("Retry-After", strconv.Itoa(ceilSeconds(retryAfter)))
+				httputils.WriteProblem(w, r, constants.ErrRateLimited)
+				return
+			}
+
+			// The auth middleware reports whether the key authenticated, so the
+			// next requests sending it are told apart from the caller's IP
+			keyID := apiKeyID(r.Header.Get(APIKeyHeader))
+			if keyID == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			r, principal := withRequestPrincipal(r)
+			next.ServeHTTP(w, r)
+			limiter.recordKey(keyID, principal.principal != nil && principal.principal.Method == domain.AuthMethodAPIKey)
+		})
+	}
+}
+
+// limitFor returns the configured limit of a route pattern, or the default one
+func (l *RateLimiter) limitFor(route string) config.RateLimit {
+	if limit, ok := l.routes[route]; ok {
+		return limit
+	}
+	return l.defaultLimit
+}
+
+// clientKey identifies the caller by API key when it sends one that has authenticated,
+// otherwise by IP address
+func (l *RateLimiter) clientKey(r *http.Request) (string, string) {
+	if keyID := apiKeyID(r.Header.Get(APIKeyHeader)); keyID != "" {
+		l.mu.Lock()
+		_, verified := l.keys[keyID]
+		l.mu.Unlock()
+		if verified {
+			return "key:" + keyID, "api_key"
+		}
+	}
+
+	return "ip:" + clientIP(r, l.trustProxy), "ip"
+}
+
+// recordKey remembers a key that authenticated, or forgets one that no longer does
+func (l *RateLimiter) recordKey(keyID string, authenticated bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if authenticated {
+		l.keys[keyID] = l.now()
+	} else {
+		delete(l.keys, keyID)
+	}
+}
+
+// apiKeyID is a stable identifier of an API key that does not keep the key itself
+// in memory, or "" when no key is sent
+func apiKeyID(key string) string {
+	if key == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:16])
+}
+
+// clientIP returns the address of the caller, taken from X-Forwarded-For only when
+// trustProxy is set, since clients can forge it
+func clientIP(r *http.Request, trustProxy bool) string {
+	if trustProxy {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			ip, _, _ := strings.Cut(forwarded, ",")
+			return strings.TrimSpace(ip)
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return host
+}
+
+// take refills the bucket for the elapsed time and spends one token if available.
+// It returns whether the request may proceed, the whole tokens left and, when
+// rejected, how long until the next token.
+func (l *RateLimiter) take(key bucketKey, limit config.RateLimit) (bool, int, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Burst), last: now}
+		l.buckets[key] = b
+	}
+
+	elapsed := now.Sub(b.last).Seconds()
+	b.tokens = math.Min(float64(limit.Burst), b.tokens+elapsed*limit.RequestsPerSecond)
+	b.last = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / limit.RequestsPerSecond * float64(time.Second))
+		return false, 0, wait
+	}
+
+	b.tokens--
+	return true, int(b.tokens), 0
+}
+
+// sweep drops buckets that have refilled completely, since a new bucket starts full anyway,
+// and keys unused for a whole interval, which authenticate again on their next request.
+// It runs at most once per sweepInterval; the caller holds the lock.
+func (l *RateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < sweepInterval {
+		return
+	}
+	l.lastSweep = now
+
+	for key, b := range l.buckets {
+		limit := l.limitFor(key.route)
+		if b.tokens+now.Sub(b.last).Seconds()*limit.RequestsPerSecond >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+
+	for keyID, lastSeen := range l.keys {
+		if now.Sub(lastSeen) >= sweepInterval {
+			delete(l.keys, keyID)
+		}
+	}
+}
+
+// resetAfter estimates when the bucket is full again, or when the next token
+// arrives for a rejected request
+func resetAfter(limit config.RateLimit, remaining int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	missing := float64(limit.Burst - remaining)
+	return time.Duration(missing / limit.RequestsPerSecond * float64(time.Second))
+}
+
+// ceilSeconds rounds a duration up to whole seconds, as the rate limit headers expect
+func ceilSeconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+)
+
+func TestRateLimitMiddleware(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/orders", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {})
+
+	limiter := NewRateLimiter(config.RateLimitConfig{
+		Default: config.RateLimit{RequestsPerSecond: 100, Burst: 100},
+		Routes:  map[string]config.RateLimit{"POST /api/v1/orders": {RequestsPerSecond: 1, Burst: 2}},
+	})
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	auth := &stubAuthService{keys: map[string]*domain.Principal{
+		"client-key": {Subject: "client", Method: domain.AuthMethodAPIKey, Scopes: []string{domain.ScopeOrdersWrite}},
+	}}
+	routes := AuthMiddleware(auth, mux, map[string]string{
+		"POST /api/v1/orders": domain.ScopeOrdersWrite,
+		"GET /health":         "",
+	})(mux)
+	handler := RateLimitMiddleware(limiter, mux)(routes)
+
+	send := func(method, path, apiKey string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, path, nil)
+		r.RemoteAddr = "10.0.0.1:5000"
+		if apiKey != "" {
+			r.Header.Set(APIKeyHeader, apiKey)
+		}
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	// A key counts against its IP until it has authenticated
+	if w := send(http.MethodPost, "/api/v1/orders", "client-key"); w.Code != http.StatusCreated {
+		t.Fatalf("expected the first keyed request to pass, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/api/v1/orders", ""); w.Code == http.StatusTooManyRequests {
+		t.Fatalf("expected the IP's second token to pass the limiter, got %d", w.Code)
+	}
+
+	w := send(http.MethodPost, "/api/v1/orders", "")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429 once the burst is spent, got %d", w.Code)
+	}
+	if got := w.Header().Get("Retry-After"); got != "1" {
+		t.Errorf("expected Retry-After 1, got %q", got)
+	}
+	if got := w.Header().Get("RateLimit-Remaining"); got != "0" {
+		t.Errorf("expected RateLimit-Remaining 0, got %q", got)
+	}
+
+	if w := send(http.MethodGet, "/health", ""); w.Code != http.StatusOK {
+		t.Errorf("expected other routes to keep their own bucket, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/api/v1/orders", "client-key"); w.Code != http.StatusCreated {
+		t.Errorf("expected an authenticated API key to get its own bucket, got %d", w.Code)
+	}
+	if w := send(http.MethodPost, "/api/v1/orders", "made-up-key"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected an unknown key to share the IP's bucket, got %d", w.Code)
+	}
+
+	now = now.Add(time.Second)
+	if w := send(http.MethodPost, "/api/v1/orders", ""); w.Code == http.StatusTooManyRequests {
+		t.Errorf("expected a token after one second, got %d", w.Code)
+	}
+}
+
+func TestRateLimitMiddlewareRotatingKeys(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /api/v1/orders", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	})
+
+	limiter := NewRateLimiter(config.RateLimitConfig{
+		Default: config.RateLimit{RequestsPerSecond: 1, Burst: 3},
+	})
+	routes := AuthMiddleware(&stubAuthService{}, mux, map[string]string{
+		"POST /api/v1/orders": domain.ScopeOrdersWrite,
+	})(mux)
+	handler := RateLimitMiddleware(limiter, mux)(routes)
+
+	codes := make(map[int]int)
+	for i := range 20 {
+		r := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+		r.RemoteAddr = "10.0.0.2:5000"
+		r.Header.Set(APIKeyHeader, fmt.Sprintf("random-key-%d", i))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, r)
+		codes[w.Code]++
+	}
+
+	if codes[http.StatusUnauthorized] != 3 || codes[http.StatusTooManyRequests] != 17 {
+		t.Errorf("expected the IP's 3 tokens then 429 for every made-up key, got %v", codes)
+	}
+	if len(limiter.keys) != 0 || len(limiter.buckets) != 1 {
+		t.Errorf("expected made-up keys to leave no state, got %d keys and %d buckets", len(limiter.keys), len(limiter.buckets))
+	}
+}
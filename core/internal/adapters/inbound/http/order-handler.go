@@ -2,10 +2,15 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/http/middleware"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
@@ -22,157 +27,378 @@ func NewOrderHandler(service ports.OrderService) *OrderHandler {
 
 // GetOrderTotal godoc
 // @Summary Get total value of an order
-// @Description Get the total value of an order by its code
+// @Description Get the gross total with its breakdown (subtotal, discounts, shipping, tax), the refunded value and the net total (gross minus refunds) of an order by its code.
+// @Description With currency the amounts are converted using the rate effective at the order date.
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Param code path int true "Order Code" minimum(1)
+// @Param currency query string false "Reporting currency (ISO 4217)" example(USD)
 // @Success 200 {object} httputils.APIResponse
-// @Failure 400 {object} httputils.APIResponse
-// @Failure 404 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Failure 422 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/orders/{code}/total [get]
 func (h *OrderHandler) GetOrderTotal(w http.ResponseWriter, r *http.Request) {
 	codeStr := r.PathValue("code")
 
-	code, err := strconv.Atoi(codeStr)
+	code, err := strconv.ParseInt(codeStr, 10, 32)
 	if err != nil || code < 1 {
-		httputils.WriteAPIError(w, r, constants.ErrInvalidOrderCode)
+		httputils.WriteProblem(w, r, constants.ErrInvalidOrderCode)
 		return
 	}
 
-	// TODO: Call service to get order total
-	// For now, return mock data
-	httputils.WriteAPISuccess(w, r, constants.SuccessOrderFound, map[string]any{
-		"order_code":  code,
-		"total_value": 120.50,
-	})
+	currency, ok := reportingCurrency(r)
+	if !ok {
+		httputils.WriteProblem(w, r, constants.ErrInvalidCurrency)
+		return
+	}
+
+	if err := authorizeOrder(r, h.orderService, int32(code)); err != nil {
+		writeOrderChangeError(w, r, err, constants.ErrFailedToGetOrderTotal)
+		return
+	}
+
+	total, err := h.orderService.GetOrderTotal(r.Context(), int32(code), currency)
+	if errors.Is(err, domain.ErrOrderNotFound) {
+		httputils.WriteProblem(w, r, constants.ErrOrderNotFound)
+		return
+	}
+	if errors.Is(err, domain.ErrFxRateNotFound) {
+		httputils.WriteProblem(w, r, constants.ErrFxRateNotFound.WithDetails(problemDetails(err)...))
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToGetOrderTotal)
+		return
+	}
+
+	data := totalsFields(total)
+	data["order_code"] = code
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessOrderFound, data)
 }
 
 // CountCustomerOrders godoc
 // @Summary Count orders by customer
-// @Description Get the total number of orders for a specific customer
+// @Description Get the total number of non-cancelled orders for a specific customer, read from the customer_stats projection
 // @Tags customers
 // @Accept json
 // @Produce json
 // @Param code path int true "Customer Code" minimum(1)
 // @Success 200 {object} httputils.APIResponse
-// @Failure 400 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/customers/{code}/orders/count [get]
 func (h *OrderHandler) CountCustomerOrders(w http.ResponseWriter, r *http.Request) {
 	codeStr := r.PathValue("code")
 
-	code, err := strconv.Atoi(codeStr)
+	code, err := strconv.ParseInt(codeStr, 10, 32)
 	if err != nil || code < 1 {
-		httputils.WriteAPIError(w, r, constants.ErrInvalidCustomerCode)
+		httputils.WriteProblem(w, r, constants.ErrInvalidCustomerCode)
+		return
+	}
+
+	if !authorizeCustomer(r, int(code)) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerNotFound)
+		return
+	}
+
+	count, err := h.orderService.CountOrdersByCustomer(r.Context(), int32(code))
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToCountOrders)
 		return
 	}
 
-	// TODO: Call service to count orders
-	// For now, return mock data
 	httputils.WriteAPISuccess(w, r, constants.SuccessOrderCounted, map[string]any{
 		"customer_code": code,
-		"order_count":   5,
+		"order_count":   count,
+	})
+}
+
+// GetCustomerSummary godoc
+// @Summary Get customer order summary
+// @Description Get the order count, last order date, top product and lifetime value of a customer.
+// @Description Read from a projection updated with each processed order; cancelled orders are left out and lifetime values are net of refunds, one per currency.
+// @Tags customers
+// @Accept json
+// @Produce json
+// @Param code path int true "Customer Code" minimum(1)
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/customers/{code}/summary [get]
+func (h *OrderHandler) GetCustomerSummary(w http.ResponseWriter, r *http.Request) {
+	codeStr := r.PathValue("code")
+
+	code, err := strconv.ParseInt(codeStr, 10, 32)
+	if err != nil || code < 1 {
+		httputils.WriteProblem(w, r, constants.ErrInvalidCustomerCode)
+		return
+	}
+
+	if !authorizeCustomer(r, int(code)) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerNotFound)
+		return
+	}
+
+	stats, err := h.orderService.GetCustomerStats(r.Context(), int32(code))
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToGetSummary)
+		return
+	}
+
+	lifetimeValues := make([]map[string]any, 0, len(stats.LifetimeValues))
+	for _, value := range stats.LifetimeValues {
+		lifetimeValues = append(lifetimeValues, map[string]any{
+			"currency": value.Currency,
+			"value":    json.Number(value.Value.StringFixed(2)),
+		})
+	}
+
+	var lastOrderAt any
+	if stats.LastOrderAt != nil {
+		lastOrderAt = stats.LastOrderAt.UTC().Format(time.RFC3339)
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessCustomerSummaryFound, map[string]any{
+		"customer_code":   code,
+		"order_count":     stats.OrderCount,
+		"last_order_at":   lastOrderAt,
+		"top_product":     stats.TopProduct,
+		"lifetime_values": lifetimeValues,
 	})
 }
 
 // ListCustomerOrders godoc
 // @Summary List customer orders
-// @Description Get list of all orders for a specific customer
+// @Description Get list of all orders for a specific customer.
+// @Description With currency the amounts are converted using the rate effective at each order date.
 // @Tags customers
 // @Accept json
 // @Produce json
 // @Param code path int true "Customer Code" minimum(1)
+// @Param currency query string false "Reporting currency (ISO 4217)" example(USD)
 // @Success 200 {object} httputils.APIResponse
-// @Failure 400 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Failure 422 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/customers/{code}/orders [get]
 func (h *OrderHandler) ListCustomerOrders(w http.ResponseWriter, r *http.Request) {
 	codeStr := r.PathValue("code")
 
-	code, err := strconv.Atoi(codeStr)
+	code, err := strconv.ParseInt(codeStr, 10, 32)
 	if err != nil || code < 1 {
-		httputils.WriteAPIError(w, r, constants.ErrInvalidCustomerCode)
+		httputils.WriteProblem(w, r, constants.ErrInvalidCustomerCode)
+		return
+	}
+
+	if !authorizeCustomer(r, int(code)) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerNotFound)
+		return
+	}
+
+	currency, ok := reportingCurrency(r)
+	if !ok {
+		httputils.WriteProblem(w, r, constants.ErrInvalidCurrency)
 		return
 	}
 
-	// TODO: Call service to get customer orders
-	// For now, return mock data
+	orders, err := h.orderService.GetOrdersByCustomer(r.Context(), int32(code))
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToListOrders)
+		return
+	}
+
+	summaries := make([]map[string]any, 0, len(orders))
+	for _, order := range orders {
+		totals, err := h.orderService.ConvertTotals(r.Context(), order, currency)
+		if errors.Is(err, domain.ErrFxRateNotFound) {
+			httputils.WriteProblem(w, r, constants.ErrFxRateNotFound.WithDetails(problemDetails(err)...))
+			return
+		}
+		if err != nil {
+			httputils.WriteProblem(w, r, constants.ErrFailedToListOrders)
+			return
+		}
+
+		summary := totalsFields(totals)
+		summary["code"] = order.OrderCode
+		summary["item_count"] = order.ItemCount
+		summary["status"] = order.Status
+		summary["version"] = order.Version
+		summary["created_at"] = order.CreatedAt.UTC().Format(time.RFC3339)
+
+		summaries = append(summaries, summary)
+	}
+
 	httputils.WriteAPISuccess(w, r, constants.SuccessOrdersListed, map[string]any{
 		"customer_code": code,
-		"orders": []map[string]any{
-			{
-				"code":        1001,
-				"total_value": 120.50,
-				"created_at":  "2024-12-08T15:30:00Z",
-			},
-			{
-				"code":        1002,
-				"total_value": 50.00,
-				"created_at":  "2024-12-07T10:15:00Z",
-			},
-		},
+		"orders":        summaries,
 	})
 }
 
+// reportingCurrency reads the optional currency query parameter.
+// It returns false when the value is not an ISO 4217 code.
+func reportingCurrency(r *http.Request) (string, bool) {
+	currency := strings.ToUpper(strings.TrimSpace(r.URL.Query().Get("currency")))
+	if currency == "" {
+		return "", true
+	}
+	return currency, domain.IsCurrency(currency)
+}
+
+// totalsFields renders order totals and their breakdown, with the exchange rate used when they were converted
+func totalsFields(totals *domain.OrderTotals) map[string]any {
+	fields := map[string]any{
+		"currency": totals.Currency,
+		"breakdown": map[string]any{
+			"subtotal":    json.Number(totals.Subtotal),
+			"discounts":   json.Number(totals.Discounts),
+			"shipping":    json.Number(totals.Shipping),
+			"tax":         json.Number(totals.Tax),
+			"grand_total": json.Number(totals.Gross),
+		},
+		"total_value":    json.Number(totals.Gross),
+		"refunded_value": json.Number(totals.Refunded),
+		"net_value":      json.Number(totals.Net),
+	}
+
+	if totals.Rate != nil {
+		fields["exchange_rate"] = map[string]any{
+			"base":           totals.Rate.Base,
+			"quote":          totals.Rate.Quote,
+			"rate":           json.Number(totals.Rate.Rate.String()),
+			"effective_date": totals.Rate.EffectiveDate.Format(time.DateOnly),
+		}
+	}
+
+	return fields
+}
+
 type CreateOrderRequest struct {
-	Code         int64                    `json:"codigoPedido" validate:"required,gt=0" example:"1001"`
-	CustomerCode int                      `json:"codigoCliente" validate:"required,gt=0" example:"1"`
+	Code         int64                    `json:"codigoPedido" validate:"required,gt=0,lte=2147483647" example:"1001"`
+	CustomerCode int                      `json:"codigoCliente" validate:"required,gt=0,lte=2147483647" example:"1"`
+	Currency     string                   `json:"moeda" validate:"omitempty,iso4217" example:"BRL"`
 	Items        []CreateOrderItemRequest `json:"itens" validate:"required,min=1,dive"`
+	Coupon       *CouponRequest           `json:"cupom" validate:"omitempty"`
+	Shipping     domain.Money             `json:"frete" validate:"money_nonnegative,money_decimals=2,money_max=999999999999.99" swaggertype:"string" example:"12.90"`
+	Tax          domain.Money             `json:"impostos" validate:"money_nonnegative,money_decimals=2,money_max=999999999999.99" swaggertype:"string" example:"3.15"`
 }
 
 type CreateOrderItemRequest struct {
-	Product  string  `json:"produto" validate:"required,min=1" example:"lápis"`
-	Quantity int     `json:"quantidade" validate:"required,gt=0" example:"100"`
-	Price    float64 `json:"preco" validate:"required,gt=0" example:"1.10"`
+	SKU      string       `json:"sku" validate:"omitempty,max=64" example:"LAP-001"`
+	Product  string       `json:"produto" validate:"required_without=SKU" example:"lápis"`
+	Quantity int          `json:"quantidade" validate:"required,gt=0" example:"100"`
+	Price    domain.Money `json:"preco" validate:"required,money_positive,money_decimals=2,money_max=99999999.99" swaggertype:"string" example:"1.10"`
+	Discount domain.Money `json:"desconto" validate:"money_nonnegative,money_decimals=2,money_max=99999999.99" swaggertype:"string" example:"0.50"`
+}
+
+type CouponRequest struct {
+	Code     string       `json:"codigo" validate:"required,max=50" example:"BEMVINDO"`
+	Discount domain.Money `json:"desconto" validate:"required,money_positive,money_decimals=2,money_max=999999999999.99" swaggertype:"string" example:"5.00"`
 }
 
 func (r *CreateOrderRequest) ToDomain() *domain.Order {
-	orderItems := make([]domain.OrderItem, len(r.Items))
+	orderItems := make([]domain.OrderItem, 0, len(r.Items))
 
 	for _, item := range r.Items {
 		newItem := domain.OrderItem{
+			SKU:      domain.NormalizeSKU(item.SKU),
 			Product:  item.Product,
 			Quantity: item.Quantity,
 			Price:    item.Price,
+			Discount: item.Discount,
 		}
 
 		orderItems = append(orderItems, newItem)
 	}
 
+	currency := r.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+
+	var coupon *domain.Coupon
+	if r.Coupon != nil {
+		coupon = &domain.Coupon{Code: r.Coupon.Code, Discount: r.Coupon.Discount}
+	}
+
 	return &domain.Order{
 		CustomerCode: r.CustomerCode,
 		OrderCode:    r.Code,
 		Items:        orderItems,
+		Currency:     currency,
+		Coupon:       coupon,
+		Shipping:     r.Shipping,
+		Tax:          r.Tax,
 		CreatedAt:    time.Now().UTC(),
 	}
 }
 
 // CreateOrder godoc
 // @Summary Create a new order
-// @Description Create a new order with items
+// @Description Create a new order with items. Items may carry a discount on the whole line; the order may carry a coupon, shipping and tax.
+// @Description Blocked customers are rejected, and so are unregistered ones when CUSTOMER_REQUIRED_FOR_ORDERS is set.
 // @Tags orders
 // @Accept json
 // @Produce json
 // @Param order body CreateOrderRequest true "Order data"
 // @Success 201 {object} httputils.APIResponse
-// @Failure 400 {object} httputils.APIResponse
-// @Failure 500 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Failure 422 {object} httputils.ProblemDetails
+// @Failure 500 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
 // @Router /api/v1/orders [post]
 func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 	var req CreateOrderRequest
 
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		httputils.WriteAPIError(w, r, constants.ErrInvalidRequestBody)
+	if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
 		return
 	}
 
 	if err := ValidateStruct(req); err != nil {
-		RespondValidationError(w, err)
+		RespondValidationError(w, r, err)
 		return
 	}
 
-	err := h.orderService.CreateOrder(r.Context(), req.ToDomain())
+	if !authorizeCustomer(r, req.CustomerCode) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerNotFound)
+		return
+	}
+
+	middleware.SetAuditResource(r.Context(), "/api/v1/orders/"+strconv.FormatInt(req.Code, 10))
+
+	order := req.ToDomain()
+	if err := order.ValidatePricing(); err != nil {
+		httputils.WriteProblem(w, r, constants.ErrInvalidPricing.WithDetails(problemDetails(err)...))
+		return
+	}
+
+	err := h.orderService.CreateOrder(r.Context(), order)
+	if errors.Is(err, domain.ErrCustomerBlocked) {
+		httputils.WriteProblem(w, r, constants.ErrCustomerBlocked)
+		return
+	}
+	if errors.Is(err, domain.ErrUnknownCustomer) {
+		httputils.WriteProblem(w, r, constants.ErrUnknownCustomer)
+		return
+	}
 	if err != nil {
-		httputils.WriteAPIError(w, r, constants.ErrFailedToCreateOrder)
+		httputils.WriteProblem(w, r, constants.ErrFailedToCreateOrder)
 		return
 	}
 
@@ -181,3 +407,269 @@ func (h *OrderHandler) CreateOrder(w http.ResponseWriter, r *http.Request) {
 		"customer_code": req.CustomerCode,
 	})
 }
+
+type CancelOrderRequest struct {
+	Reason          string `json:"motivo" validate:"max=255" example:"cliente desistiu"`
+	ExpectedVersion int    `json:"versaoEsperada" validate:"gte=0" example:"1"`
+}
+
+// CancelOrder godoc
+// @Summary Cancel an order
+// @Description Request the cancellation of an order. The body is optional; when versaoEsperada is set the request is refused if the order changed since that version.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param code path int true "Order Code" minimum(1)
+// @Param cancellation body CancelOrderRequest false "Cancellation data"
+// @Success 202 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Failure 409 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/orders/{code}/cancel [post]
+func (h *OrderHandler) CancelOrder(w http.ResponseWriter, r *http.Request) {
+	codeStr := r.PathValue("code")
+
+	code, err := strconv.ParseInt(codeStr, 10, 32)
+	if err != nil || code < 1 {
+		httputils.WriteProblem(w, r, constants.ErrInvalidOrderCode)
+		return
+	}
+
+	var req CancelOrderRequest
+
+	if apiErr, ok := httputils.DecodeOptionalJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	cancellation := &domain.OrderCancellation{
+		OrderCode:       int64(code),
+		Reason:          req.Reason,
+		ExpectedVersion: req.ExpectedVersion,
+		RequestedAt:     time.Now().UTC(),
+	}
+
+	if err := authorizeOrder(r, h.orderService, int32(code)); err != nil {
+		writeOrderChangeError(w, r, err, constants.ErrFailedToCancelOrder)
+		return
+	}
+
+	err = h.orderService.CancelOrder(r.Context(), cancellation)
+	if err != nil {
+		writeOrderChangeError(w, r, err, constants.ErrFailedToCancelOrder)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessOrderCancellationAccepted, map[string]any{
+		"order_code":    code,
+		"customer_code": cancellation.CustomerCode,
+	})
+}
+
+type AmendOrderRequest struct {
+	ExpectedVersion int                      `json:"versaoEsperada" validate:"gte=0" example:"1"`
+	AddItems        []CreateOrderItemRequest `json:"adicionar" validate:"omitempty,dive"`
+	RemoveProducts  []string                 `json:"remover" validate:"omitempty,dive,required" example:"caderno"`
+	ChangeItems     []CreateOrderItemRequest `json:"alterar" validate:"omitempty,dive"`
+}
+
+func (r *AmendOrderRequest) ToDomain(orderCode int64) *domain.OrderAmendment {
+	toItems := func(requests []CreateOrderItemRequest) []domain.OrderItem {
+		items := make([]domain.OrderItem, 0, len(requests))
+		for _, item := range requests {
+			items = append(items, domain.OrderItem{
+				SKU:      domain.NormalizeSKU(item.SKU),
+				Product:  item.Product,
+				Quantity: item.Quantity,
+				Price:    item.Price,
+				Discount: item.Discount,
+			})
+		}
+		return items
+	}
+
+	return &domain.OrderAmendment{
+		OrderCode:       orderCode,
+		ExpectedVersion: r.ExpectedVersion,
+		AddItems:        toItems(r.AddItems),
+		RemoveProducts:  r.RemoveProducts,
+		ChangeItems:     toItems(r.ChangeItems),
+		RequestedAt:     time.Now().UTC(),
+	}
+}
+
+// AmendOrder godoc
+// @Summary Amend an order
+// @Description Add, remove or change items of an order. Items are matched by product name and the new total is computed when the amendment is applied.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param code path int true "Order Code" minimum(1)
+// @Param amendment body AmendOrderRequest true "Amendment data"
+// @Success 202 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Failure 409 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/orders/{code} [patch]
+func (h *OrderHandler) AmendOrder(w http.ResponseWriter, r *http.Request) {
+	codeStr := r.PathValue("code")
+
+	code, err := strconv.ParseInt(codeStr, 10, 32)
+	if err != nil || code < 1 {
+		httputils.WriteProblem(w, r, constants.ErrInvalidOrderCode)
+		return
+	}
+
+	var req AmendOrderRequest
+
+	if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	if len(req.AddItems) == 0 && len(req.RemoveProducts) == 0 && len(req.ChangeItems) == 0 {
+		httputils.WriteProblem(w, r, constants.ErrEmptyAmendment)
+		return
+	}
+
+	amendment := req.ToDomain(int64(code))
+
+	if err := authorizeOrder(r, h.orderService, int32(code)); err != nil {
+		writeOrderChangeError(w, r, err, constants.ErrFailedToAmendOrder)
+		return
+	}
+
+	err = h.orderService.AmendOrder(r.Context(), amendment)
+	if err != nil {
+		writeOrderChangeError(w, r, err, constants.ErrFailedToAmendOrder)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessOrderAmendmentAccepted, map[string]any{
+		"order_code":    code,
+		"customer_code": amendment.CustomerCode,
+	})
+}
+
+// writeOrderChangeError maps the errors of an order lookup, cancellation or amendment request to API errors
+func writeOrderChangeError(w http.ResponseWriter, r *http.Request, err error, fallback constants.APIError) {
+	switch {
+	case errors.Is(err, domain.ErrOrderNotFound):
+		httputils.WriteProblem(w, r, constants.ErrOrderNotFound)
+	case errors.Is(err, domain.ErrOrderCancelled):
+		httputils.WriteProblem(w, r, constants.ErrOrderCancelled)
+	case errors.Is(err, domain.ErrVersionConflict):
+		httputils.WriteProblem(w, r, constants.ErrVersionConflict)
+	default:
+		httputils.WriteProblem(w, r, fallback)
+	}
+}
+
+type ReturnOrderRequest struct {
+	Reason string                   `json:"motivo" validate:"max=255" example:"produto com defeito"`
+	Items  []ReturnOrderItemRequest `json:"itens" validate:"required,min=1,dive"`
+}
+
+type ReturnOrderItemRequest struct {
+	Product  string `json:"produto" validate:"required,min=1" example:"lápis"`
+	Quantity int    `json:"quantidade" validate:"required,gt=0" example:"10"`
+}
+
+func (r *ReturnOrderRequest) ToDomain(orderCode int64) *domain.OrderReturn {
+	items := make([]domain.ReturnItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, domain.ReturnItem{
+			Product:  item.Product,
+			Quantity: item.Quantity,
+		})
+	}
+
+	return &domain.OrderReturn{
+		ReturnID:    uuid.NewString(),
+		OrderCode:   orderCode,
+		Reason:      r.Reason,
+		Items:       items,
+		RequestedAt: time.Now().UTC(),
+	}
+}
+
+// ReturnOrder godoc
+// @Summary Return order items
+// @Description Return part of an order. Each line may return at most what was bought minus earlier returns; the refund is priced at the purchase price.
+// @Tags orders
+// @Accept json
+// @Produce json
+// @Param code path int true "Order Code" minimum(1)
+// @Param return body ReturnOrderRequest true "Returned items"
+// @Success 202 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Failure 409 {object} httputils.ProblemDetails
+// @Failure 422 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/orders/{code}/returns [post]
+func (h *OrderHandler) ReturnOrder(w http.ResponseWriter, r *http.Request) {
+	codeStr := r.PathValue("code")
+
+	code, err := strconv.ParseInt(codeStr, 10, 32)
+	if err != nil || code < 1 {
+		httputils.WriteProblem(w, r, constants.ErrInvalidOrderCode)
+		return
+	}
+
+	var req ReturnOrderRequest
+
+	if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	orderReturn := req.ToDomain(int64(code))
+
+	if err := authorizeOrder(r, h.orderService, int32(code)); err != nil {
+		writeOrderChangeError(w, r, err, constants.ErrFailedToReturnOrder)
+		return
+	}
+
+	err = h.orderService.ReturnOrder(r.Context(), orderReturn)
+	if errors.Is(err, domain.ErrInvalidReturn) {
+		httputils.WriteProblem(w, r, constants.ErrInvalidReturn.WithDetails(problemDetails(err)...))
+		return
+	}
+	if err != nil {
+		writeOrderChangeError(w, r, err, constants.ErrFailedToReturnOrder)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessOrderReturnAccepted, map[string]any{
+		"return_id":     orderReturn.ReturnID,
+		"order_code":    code,
+		"customer_code": orderReturn.CustomerCode,
+	})
+}
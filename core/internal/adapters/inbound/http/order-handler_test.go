@@ -4,6 +4,7 @@ import (
 	http_internal "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/http"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 )
 
@@ -25,3 +26,37 @@ func TestOrderHandler_CreateOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestHandlersRejectCodesBeyondInt32(t *testing.T) {
+	orders := http_internal.NewOrderHandler(nil)
+	customers := http_internal.NewCustomerHandler(nil)
+
+	handlers := map[string]http.HandlerFunc{
+		"GetOrderTotal":       orders.GetOrderTotal,
+		"CountCustomerOrders": orders.CountCustomerOrders,
+		"GetCustomerSummary":  orders.GetCustomerSummary,
+		"ListCustomerOrders":  orders.ListCustomerOrders,
+		"CancelOrder":         orders.CancelOrder,
+		"AmendOrder":          orders.AmendOrder,
+		"ReturnOrder":         orders.ReturnOrder,
+		"GetCustomer":         customers.GetCustomer,
+		"UpdateCustomer":      customers.UpdateCustomer,
+	}
+
+	// 4294967297 wraps to 1 when narrowed to 32 bits
+	for _, code := range []string{"2147483648", "4294967297"} {
+		for name, handler := range handlers {
+			t.Run(name+"/"+code, func(t *testing.T) {
+				r := httptest.NewRequest(http.MethodGet, "/", nil)
+				r.SetPathValue("code", code)
+				w := httptest.NewRecorder()
+
+				handler(w, r)
+
+				if w.Code != http.StatusBadRequest {
+					t.Errorf("status = %d, want %d", w.Code, http.StatusBadRequest)
+				}
+			})
+		}
+	}
+}
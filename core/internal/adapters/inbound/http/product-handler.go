@@ -0,0 +1,267 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/http/middleware"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
+)
+
+type ProductHandler struct {
+	productService ports.ProductService
+}
+
+func NewProductHandler(service ports.ProductService) *ProductHandler {
+	return &ProductHandler{productService: service}
+}
+
+type CreateProductRequest struct {
+	SKU       string       `json:"sku" validate:"required,max=64" example:"LAP-001"`
+	Name      string       `json:"nome" validate:"required,max=255" example:"lápis"`
+	Unit      string       `json:"unidade" validate:"omitempty,max=20" example:"un"`
+	ListPrice domain.Money `json:"precoLista" validate:"required,money_positive,money_decimals=2,money_max=99999999.99" swaggertype:"string" example:"1.10"`
+	Active    *bool        `json:"ativo" example:"true"`
+}
+
+func (r *CreateProductRequest) ToDomain() *domain.Product {
+	return newProduct(r.SKU, r.Name, r.Unit, r.ListPrice, r.Active)
+}
+
+type UpdateProductRequest struct {
+	Name      string       `json:"nome" validate:"required,max=255" example:"lápis"`
+	Unit      string       `json:"unidade" validate:"omitempty,max=20" example:"un"`
+	ListPrice domain.Money `json:"precoLista" validate:"required,money_positive,money_decimals=2,money_max=99999999.99" swaggertype:"string" example:"1.10"`
+	Active    *bool        `json:"ativo" example:"true"`
+}
+
+func (r *UpdateProductRequest) ToDomain(sku string) *domain.Product {
+	return newProduct(sku, r.Name, r.Unit, r.ListPrice, r.Active)
+}
+
+// newProduct normalizes the SKU and name and applies the defaults of omitted fields
+func newProduct(sku, name, unit string, listPrice domain.Money, active *bool) *domain.Product {
+	if unit = strings.TrimSpace(unit); unit == "" {
+		unit = domain.DefaultUnit
+	}
+
+	return &domain.Product{
+		SKU:       domain.NormalizeSKU(sku),
+		Name:      strings.TrimSpace(name),
+		Unit:      unit,
+		ListPrice: listPrice,
+		Active:    active == nil || *active,
+	}
+}
+
+// CreateProduct godoc
+// @Summary Create a product
+// @Description Add a product to the catalog. The SKU is trimmed and upper-cased; products are active unless ativo is false.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param product body CreateProductRequest true "Product data"
+// @Success 201 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 409 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/products [post]
+func (h *ProductHandler) CreateProduct(w http.ResponseWriter, r *http.Request) {
+	var req CreateProductRequest
+
+	if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	product := req.ToDomain()
+	if product.SKU == "" {
+		httputils.WriteProblem(w, r, constants.ErrInvalidSKU)
+		return
+	}
+
+	middleware.SetAuditResource(r.Context(), "/api/v1/products/"+product.SKU)
+
+	created, err := h.productService.CreateProduct(r.Context(), product)
+	if errors.Is(err, domain.ErrProductExists) {
+		httputils.WriteProblem(w, r, constants.ErrProductExists)
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToCreateProduct)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessProductCreated, productFields(created))
+}
+
+// ListProducts godoc
+// @Summary List products
+// @Description Get every product in the catalog ordered by SKU, including inactive ones
+// @Tags products
+// @Accept json
+// @Produce json
+// @Success 200 {object} httputils.APIResponse
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/products [get]
+func (h *ProductHandler) ListProducts(w http.ResponseWriter, r *http.Request) {
+	products, err := h.productService.ListProducts(r.Context())
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToListProducts)
+		return
+	}
+
+	items := make([]map[string]any, 0, len(products))
+	for _, product := range products {
+		items = append(items, productFields(product))
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessProductsListed, map[string]any{
+		"products": items,
+	})
+}
+
+// GetProduct godoc
+// @Summary Get a product
+// @Description Get a catalog product by its SKU
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param sku path string true "SKU"
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/products/{sku} [get]
+func (h *ProductHandler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	sku := domain.NormalizeSKU(r.PathValue("sku"))
+	if sku == "" {
+		httputils.WriteProblem(w, r, constants.ErrInvalidSKU)
+		return
+	}
+
+	product, err := h.productService.GetProduct(r.Context(), sku)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		httputils.WriteProblem(w, r, constants.ErrProductNotFound)
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToGetProduct)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessProductFound, productFields(product))
+}
+
+// UpdateProduct godoc
+// @Summary Update a product
+// @Description Replace the name, unit, list price and active flag of a product. Stored orders keep the prices they were placed with.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param sku path string true "SKU"
+// @Param product body UpdateProductRequest true "Product data"
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 413 {object} httputils.ProblemDetails
+// @Failure 415 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/products/{sku} [put]
+func (h *ProductHandler) UpdateProduct(w http.ResponseWriter, r *http.Request) {
+	sku := domain.NormalizeSKU(r.PathValue("sku"))
+	if sku == "" {
+		httputils.WriteProblem(w, r, constants.ErrInvalidSKU)
+		return
+	}
+
+	var req UpdateProductRequest
+
+	if apiErr, ok := httputils.DecodeJSONBody(w, r, &req); !ok {
+		httputils.WriteProblem(w, r, apiErr)
+		return
+	}
+
+	if err := ValidateStruct(req); err != nil {
+		RespondValidationError(w, r, err)
+		return
+	}
+
+	product, err := h.productService.UpdateProduct(r.Context(), req.ToDomain(sku))
+	if errors.Is(err, domain.ErrProductNotFound) {
+		httputils.WriteProblem(w, r, constants.ErrProductNotFound)
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToUpdateProduct)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessProductUpdated, productFields(product))
+}
+
+// DeactivateProduct godoc
+// @Summary Deactivate a product
+// @Description Stop a product from being ordered. It stays in the catalog because stored orders reference its SKU.
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param sku path string true "SKU"
+// @Success 200 {object} httputils.APIResponse
+// @Failure 400 {object} httputils.ProblemDetails
+// @Failure 404 {object} httputils.ProblemDetails
+// @Security ApiKeyAuth
+// @Security BearerAuth
+// @Router /api/v1/products/{sku} [delete]
+func (h *ProductHandler) DeactivateProduct(w http.ResponseWriter, r *http.Request) {
+	sku := domain.NormalizeSKU(r.PathValue("sku"))
+	if sku == "" {
+		httputils.WriteProblem(w, r, constants.ErrInvalidSKU)
+		return
+	}
+
+	err := h.productService.DeactivateProduct(r.Context(), sku)
+	if errors.Is(err, domain.ErrProductNotFound) {
+		httputils.WriteProblem(w, r, constants.ErrProductNotFound)
+		return
+	}
+	if err != nil {
+		httputils.WriteProblem(w, r, constants.ErrFailedToDeactivateProduct)
+		return
+	}
+
+	httputils.WriteAPISuccess(w, r, constants.SuccessProductDeactivated, map[string]any{
+		"sku":    sku,
+		"active": false,
+	})
+}
+
+// productFields renders a catalog product
+func productFields(product *domain.Product) map[string]any {
+	return map[string]any{
+		"sku":        product.SKU,
+		"name":       product.Name,
+		"unit":       product.Unit,
+		"list_price": json.Number(product.ListPrice.StringFixed(2)),
+		"active":     product.Active,
+		"created_at": product.CreatedAt.UTC().Format(time.RFC3339),
+		"updated_at": product.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
@@ -2,13 +2,12 @@ package http
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/http/middleware"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/telemetry"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
-	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
 	httpSwagger "github.com/swaggo/http-swagger"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 )
@@ -16,28 +15,97 @@ import (
 // spanNames maps route patterns to custom span names
 var spanNames = map[string]string{
 	"GET /health":                               "health",
+	"GET /health/live":                          "health.live",
+	"GET /health/ready":                         "health.ready",
 	"GET /metrics":                              "metrics",
 	"POST /api/v1/orders":                       "orders.create",
 	"GET /api/v1/orders/{code}/total":           "orders.getTotal",
+	"POST /api/v1/orders/{code}/cancel":         "orders.cancel",
+	"PATCH /api/v1/orders/{code}":               "orders.amend",
+	"POST /api/v1/orders/{code}/returns":        "orders.return",
+	"POST /api/v1/customers":                    "customers.create",
+	"GET /api/v1/customers":                     "customers.list",
+	"GET /api/v1/customers/{code}":              "customers.get",
+	"PUT /api/v1/customers/{code}":              "customers.update",
 	"GET /api/v1/customers/{code}/orders":       "customers.listOrders",
 	"GET /api/v1/customers/{code}/orders/count": "customers.countOrders",
+	"GET /api/v1/customers/{code}/summary":      "customers.summary",
+	"POST /api/v1/admin/fx-rates":               "fx.importRates",
+	"GET /api/v1/admin/log-levels":              "logLevels.list",
+	"PUT /api/v1/admin/log-levels":              "logLevels.set",
+	"DELETE /api/v1/admin/log-levels/{logger}":  "logLevels.reset",
+	"GET /api/v1/admin/audit-log":               "audit.list",
+	"GET /api/v1/admin/audit-log/verify":        "audit.verify",
+	"POST /api/v1/products":                     "products.create",
+	"GET /api/v1/products":                      "products.list",
+	"GET /api/v1/products/{sku}":                "products.get",
+	"PUT /api/v1/products/{sku}":                "products.update",
+	"DELETE /api/v1/products/{sku}":             "products.deactivate",
+}
+
+// routeScopes maps route patterns to the scope a caller needs. Routes mapped to an
+// empty scope are public; admin-scoped callers may use every route.
+var routeScopes = map[string]string{
+	"GET /health":                               "",
+	"GET /health/live":                          "",
+	"GET /health/ready":                         "",
+	"GET /metrics":                              "",
+	"GET /swagger/":                             "",
+	"POST /api/v1/orders":                       domain.ScopeOrdersWrite,
+	"GET /api/v1/orders/{code}/total":           domain.ScopeOrdersRead,
+	"POST /api/v1/orders/{code}/cancel":         domain.ScopeOrdersWrite,
+	"PATCH /api/v1/orders/{code}":               domain.ScopeOrdersWrite,
+	"POST /api/v1/orders/{code}/returns":        domain.ScopeOrdersWrite,
+	"POST /api/v1/customers":                    domain.ScopeAdmin,
+	"GET /api/v1/customers":                     domain.ScopeOrdersRead,
+	"GET /api/v1/customers/{code}":              domain.ScopeOrdersRead,
+	"PUT /api/v1/customers/{code}":              domain.ScopeAdmin,
+	"GET /api/v1/customers/{code}/orders":       domain.ScopeReportsRead,
+	"GET /api/v1/customers/{code}/orders/count": domain.ScopeReportsRead,
+	"GET /api/v1/customers/{code}/summary":      domain.ScopeReportsRead,
+	"POST /api/v1/admin/fx-rates":               domain.ScopeAdmin,
+	"GET /api/v1/admin/log-levels":              domain.ScopeAdmin,
+	"PUT /api/v1/admin/log-levels":              domain.ScopeAdmin,
+	"DELETE /api/v1/admin/log-levels/{logger}":  domain.ScopeAdmin,
+	"GET /api/v1/admin/audit-log":               domain.ScopeAdmin,
+	"GET /api/v1/admin/audit-log/verify":        domain.ScopeAdmin,
+	"POST /api/v1/products":                     domain.ScopeAdmin,
+	"GET /api/v1/products":                      domain.ScopeOrdersRead,
+	"GET /api/v1/products/{sku}":                domain.ScopeOrdersRead,
+	"PUT /api/v1/products/{sku}":                domain.ScopeAdmin,
+	"DELETE /api/v1/products/{sku}":             domain.ScopeAdmin,
+}
+
+// auditedReads lists the GET routes recorded in the audit log besides every write:
+// those exposing customers, their orders and the audit log itself
+var auditedReads = map[string]bool{
+	"GET /api/v1/orders/{code}/total":           true,
+	"GET /api/v1/customers":                     true,
+	"GET /api/v1/customers/{code}":              true,
+	"GET /api/v1/customers/{code}/orders":       true,
+	"GET /api/v1/customers/{code}/orders/count": true,
+	"GET /api/v1/customers/{code}/summary":      true,
+	"GET /api/v1/admin/audit-log":               true,
+	"GET /api/v1/admin/audit-log/verify":        true,
 }
 
 // NewRouter creates and configures the HTTP router with all routes and middleware
-func NewRouter(cfg *config.Config, orderService ports.OrderService) http.Handler {
+func NewRouter(cfg *config.Config, orderService ports.OrderService, fxService ports.FxService, productService ports.ProductService, customerService ports.CustomerService, authService ports.AuthService, healthService ports.HealthService, auditService ports.AuditService) http.Handler {
 	mux := http.NewServeMux()
 
 	// Initialize handlers
 	orderHandler := NewOrderHandler(orderService)
-	healthHandler := NewHealthHandler()
+	fxHandler := NewFxHandler(fxService)
+	productHandler := NewProductHandler(productService)
+	customerHandler := NewCustomerHandler(customerService)
+	healthHandler := NewHealthHandler(healthService)
+	logLevelHandler := NewLogLevelHandler()
+	auditHandler := NewAuditHandler(auditService)
 
-	// Health check
-	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
-		httputils.RespondJSON(w, http.StatusOK, map[string]string{
-			"status": "ok",
-			"time":   time.Now().Format(time.RFC3339),
-		})
-	})
+	// Health probes; /health is kept as an alias of the liveness probe
+	mux.HandleFunc("GET /health", healthHandler.Live)
+	mux.HandleFunc("GET /health/live", healthHandler.Live)
+	mux.HandleFunc("GET /health/ready", healthHandler.Ready)
 
 	// Metrics endpoint
 	mux.Handle("GET /metrics", healthHandler.Metrics())
@@ -48,15 +116,63 @@ func NewRouter(cfg *config.Config, orderService ports.OrderService) http.Handler
 	// API v1 routes - Orders
 	mux.HandleFunc("POST /api/v1/orders", orderHandler.CreateOrder)
 	mux.HandleFunc("GET /api/v1/orders/{code}/total", orderHandler.GetOrderTotal)
+	mux.HandleFunc("POST /api/v1/orders/{code}/cancel", orderHandler.CancelOrder)
+	mux.HandleFunc("PATCH /api/v1/orders/{code}", orderHandler.AmendOrder)
+	mux.HandleFunc("POST /api/v1/orders/{code}/returns", orderHandler.ReturnOrder)
 
 	// API v1 routes - Customers
+	mux.HandleFunc("POST /api/v1/customers", customerHandler.CreateCustomer)
+	mux.HandleFunc("GET /api/v1/customers", customerHandler.ListCustomers)
+	mux.HandleFunc("GET /api/v1/customers/{code}", customerHandler.GetCustomer)
+	mux.HandleFunc("PUT /api/v1/customers/{code}", customerHandler.UpdateCustomer)
 	mux.HandleFunc("GET /api/v1/customers/{code}/orders", orderHandler.ListCustomerOrders)
 	mux.HandleFunc("GET /api/v1/customers/{code}/orders/count", orderHandler.CountCustomerOrders)
+	mux.HandleFunc("GET /api/v1/customers/{code}/summary", orderHandler.GetCustomerSummary)
+
+	// API v1 routes - Products
+	mux.HandleFunc("POST /api/v1/products", productHandler.CreateProduct)
+	mux.HandleFunc("GET /api/v1/products", productHandler.ListProducts)
+	mux.HandleFunc("GET /api/v1/products/{sku}", productHandler.GetProduct)
+	mux.HandleFunc("PUT /api/v1/products/{sku}", productHandler.UpdateProduct)
+	mux.HandleFunc("DELETE /api/v1/products/{sku}", productHandler.DeactivateProduct)
+
+	// API v1 routes - Admin
+	mux.HandleFunc("POST /api/v1/admin/fx-rates", fxHandler.ImportRates)
+	mux.HandleFunc("GET /api/v1/admin/log-levels", logLevelHandler.ListLogLevels)
+	mux.HandleFunc("PUT /api/v1/admin/log-levels", logLevelHandler.SetLogLevel)
+	mux.HandleFunc("DELETE /api/v1/admin/log-levels/{logger}", logLevelHandler.ResetLogLevel)
+	mux.HandleFunc("GET /api/v1/admin/audit-log", auditHandler.ListAuditEntries)
+	mux.HandleFunc("GET /api/v1/admin/audit-log/verify", auditHandler.VerifyAuditLog)
+
+	// Answer unknown paths and methods with problem details like every other error
+	routes := middleware.UnmatchedRouteMiddleware(mux)(mux)
+
+	// Authenticate callers and check the scope of the route they call
+	if cfg.Auth.Enabled {
+		routes = middleware.AuthMiddleware(authService, mux, routeScopes)(routes)
+	}
+
+	// Record writes and sensitive reads once the response is known, including
+	// those the auth middleware denied
+	if cfg.Audit.Enabled {
+		routes = middleware.AuditMiddleware(auditService, mux, spanNames, auditedReads, cfg.Audit.TrustProxy)(routes)
+	}
+
+	// Throttle clients per route before requests reach the handlers,
+	// including callers with bad credentials
+	if cfg.RateLimit.Enabled {
+		limiter := middleware.NewRateLimiter(cfg.RateLimit)
+		routes = middleware.RateLimitMiddleware(limiter, mux)(routes)
+	}
 
-	// Wrap with global middlewares: metrics -> logging -> CORS -> routes
-	innerHandler := middleware.MetricsMiddleware(
-		middleware.LoggingMiddleware(
-			middleware.CORSMiddleware(mux),
+	// Wrap with global middlewares: correlation ID -> metrics -> logging -> language -> CORS -> rate limit -> audit -> auth -> routes
+	innerHandler := middleware.CorrelationIDMiddleware(
+		middleware.MetricsMiddleware(
+			middleware.LoggingMiddleware(
+				middleware.LanguageMiddleware(cfg.App.DefaultLanguage)(
+					middleware.CORSMiddleware(cfg.CORS)(routes),
+				),
+			),
 		),
 	)
 
@@ -2,7 +2,11 @@ package http
 
 import (
 	"net/http"
+	"sort"
 
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/i18n"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/validation"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/pkg/httputils"
 )
@@ -13,13 +17,28 @@ func ValidateStruct(s any) error {
 	return validation.Validate(s)
 }
 
-// RespondValidationError writes a validation error response with field details.
-// Uses the new httputils package for consistent response format.
-func RespondValidationError(w http.ResponseWriter, err error) {
-	details := validation.ValidationErrors(err)
-	if details != nil {
-		httputils.RespondError(w, http.StatusBadRequest, "Validation failed", details)
-		return
+// RespondValidationError writes a validation problem listing each rejected field
+// by its JSON path, sorted so responses are stable, in the negotiated language.
+func RespondValidationError(w http.ResponseWriter, r *http.Request, err error) {
+	details := validation.ValidationErrors(err, i18n.FromContext(r.Context()))
+
+	fieldErrors := make([]httputils.FieldError, 0, len(details))
+	for field, message := range details {
+		fieldErrors = append(fieldErrors, httputils.FieldError{Field: field, Message: message})
+	}
+	sort.Slice(fieldErrors, func(i, j int) bool {
+		return fieldErrors[i].Field < fieldErrors[j].Field
+	})
+
+	httputils.WriteProblem(w, r, constants.ErrValidationFailed, fieldErrors...)
+}
+
+// problemDetails explains the business rules err reports as broken, one detail per problem
+func problemDetails(err error) []constants.Detail {
+	problems := domain.Problems(err)
+	details := make([]constants.Detail, len(problems))
+	for i, problem := range problems {
+		details[i] = constants.Detail{Key: problem.Key, Params: problem.Params, Message: problem.Message}
 	}
-	httputils.RespondError(w, http.StatusBadRequest, "Validation failed", nil)
+	return details
 }
@@ -0,0 +1,102 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+)
+
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
+// OrderChangeListener holds a dedicated Postgres connection listening on
+// domain.OrdersChannel and forwards each notified change to the cache invalidator
+type OrderChangeListener struct {
+	pool        *pgxpool.Pool
+	invalidator ports.OrderCacheInvalidator
+	log         *zap.Logger
+}
+
+// NewOrderChangeListener creates a new OrderChangeListener with dependency injection
+func NewOrderChangeListener(pool *pgxpool.Pool, invalidator ports.OrderCacheInvalidator) *OrderChangeListener {
+	return &OrderChangeListener{
+		pool:        pool,
+		invalidator: invalidator,
+		log:         logger.Named("notifications"),
+	}
+}
+
+// Start listens until the context is cancelled, reconnecting with backoff when the
+// connection drops. Every (re)connection purges the cache, since changes committed
+// while disconnected were never notified.
+func (l *OrderChangeListener) Start(ctx context.Context) {
+	delay := minReconnectDelay
+
+	for {
+		err := l.listen(ctx)
+		if ctx.Err() != nil {
+			l.log.Info("Order change listener stopped", zap.String("reason", "shutdown"))
+			return
+		}
+
+		l.log.Error("Order change listener disconnected",
+			zap.Error(err),
+			zap.Duration("retry_in", delay),
+		)
+
+		select {
+		case <-ctx.Done():
+			l.log.Info("Order change listener stopped", zap.String("reason", "shutdown"))
+			return
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxReconnectDelay)
+	}
+}
+
+// listen subscribes to the channel and handles notifications until the connection fails
+func (l *OrderChangeListener) listen(ctx context.Context) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return fmt.Errorf("error acquiring connection: %w", err)
+	}
+	// The connection carries the LISTEN state, so it is taken out of the pool and closed when done
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pgx.Identifier{domain.OrdersChannel}.Sanitize()); err != nil {
+		return fmt.Errorf("error listening on %s: %w", domain.OrdersChannel, err)
+	}
+
+	l.invalidator.InvalidateOrderChange(domain.OrderChange{All: true})
+	l.log.Info("Order change listener started", zap.String("channel", domain.OrdersChannel))
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var change domain.OrderChange
+		if err := json.Unmarshal([]byte(notification.Payload), &change); err != nil {
+			l.log.Warn("Malformed order change notification, purging cache",
+				zap.String("payload", notification.Payload),
+				zap.Error(err),
+			)
+			change = domain.OrderChange{All: true}
+		}
+
+		l.invalidator.InvalidateOrderChange(change)
+	}
+}
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS refunded_total NUMERIC(14, 2) NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS returns (
+    id BIGSERIAL PRIMARY KEY,
+    return_id UUID NOT NULL UNIQUE,
+    order_id BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL DEFAULT '',
+    refund_total NUMERIC(14, 2) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS return_items (
+    id BIGSERIAL PRIMARY KEY,
+    return_id BIGINT NOT NULL REFERENCES returns(id) ON DELETE CASCADE,
+    product VARCHAR(255) NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    price NUMERIC(10, 2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_returns_order_id ON returns(order_id);
+CREATE INDEX IF NOT EXISTS idx_return_items_return_id ON return_items(return_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_return_items_return_id;
+DROP INDEX IF EXISTS idx_returns_order_id;
+DROP TABLE IF EXISTS return_items;
+DROP TABLE IF EXISTS returns;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS refunded_total;
+-- +goose StatementEnd
@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Audit entries are chained: hash covers the entry and the hash of the one before it,
+-- so editing or removing a row breaks the chain from that row on
+CREATE TABLE IF NOT EXISTS audit_log (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP NOT NULL,
+    principal VARCHAR(255) NOT NULL DEFAULT '',
+    auth_method VARCHAR(20) NOT NULL DEFAULT '',
+    action VARCHAR(100) NOT NULL,
+    resource VARCHAR(500) NOT NULL,
+    correlation_id VARCHAR(100) NOT NULL DEFAULT '',
+    source_ip VARCHAR(64) NOT NULL DEFAULT '',
+    outcome VARCHAR(20) NOT NULL,
+    status_code INTEGER NOT NULL,
+    prev_hash CHAR(64) NOT NULL,
+    hash CHAR(64) NOT NULL UNIQUE
+);
+
+CREATE INDEX IF NOT EXISTS idx_audit_log_principal ON audit_log(principal, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log(occurred_at);
+
+-- The table is append-only: rows can be neither changed nor removed
+CREATE OR REPLACE FUNCTION reject_audit_log_change() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_log_change();
+
+CREATE TRIGGER audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_log_change();
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log;
+DROP FUNCTION IF EXISTS reject_audit_log_change();
+-- +goose StatementEnd
@@ -0,0 +1,41 @@
+package database
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NumericFromMoney converts an amount to a NUMERIC parameter without losing digits
+func NumericFromMoney(m domain.Money) pgtype.Numeric {
+	return pgtype.Numeric{Int: m.Coefficient(), Exp: m.Exponent(), Valid: true}
+}
+
+// MoneyFromNumeric converts a NUMERIC column to an amount. NULL maps to zero.
+func MoneyFromNumeric(n pgtype.Numeric) (domain.Money, error) {
+	if !n.Valid {
+		return domain.Money{}, nil
+	}
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return domain.Money{}, fmt.Errorf("numeric value is not a finite number")
+	}
+	if n.Int == nil {
+		return domain.NewMoney(new(big.Int), n.Exp), nil
+	}
+	return domain.NewMoney(n.Int, n.Exp), nil
+}
+
+// NumericFromRate converts a rate to a NUMERIC parameter without losing digits
+func NumericFromRate(r domain.Rate) pgtype.Numeric {
+	return pgtype.Numeric{Int: r.Coefficient(), Exp: r.Exponent(), Valid: true}
+}
+
+// RateFromNumeric converts a NUMERIC column to a rate
+func RateFromNumeric(n pgtype.Numeric) (domain.Rate, error) {
+	if !n.Valid || n.NaN || n.InfinityModifier != pgtype.Finite || n.Int == nil {
+		return domain.Rate{}, fmt.Errorf("numeric value is not a finite number")
+	}
+	return domain.NewRate(n.Int, n.Exp), nil
+}
@@ -0,0 +1,8 @@
+-- name: CreateAPIKey :one
+INSERT INTO api_keys (name, key_prefix, key_hash, scopes, customer_codes)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetActiveAPIKeyByHash :one
+SELECT * FROM api_keys
+WHERE key_hash = $1 AND active;
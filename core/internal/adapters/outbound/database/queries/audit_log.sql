@@ -0,0 +1,43 @@
+-- name: StageAuditEntry :exec
+INSERT INTO audit_log_staging (
+    occurred_at, principal, auth_method, action, resource,
+    correlation_id, source_ip, outcome, status_code
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+
+-- name: GetStagedAuditEntries :many
+SELECT * FROM audit_log_staging
+ORDER BY id
+LIMIT $1;
+
+-- name: DeleteStagedAuditEntries :exec
+DELETE FROM audit_log_staging
+WHERE id = ANY(sqlc.arg(ids)::BIGINT[]);
+
+-- name: TryLockAuditLog :one
+SELECT pg_try_advisory_xact_lock(hashtext('audit_log')) AS locked;
+
+-- name: GetLastAuditHash :one
+SELECT hash FROM audit_log
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateAuditEntry :one
+INSERT INTO audit_log (
+    occurred_at, principal, auth_method, action, resource,
+    correlation_id, source_ip, outcome, status_code, prev_hash, hash
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+RETURNING id;
+
+-- name: ListAuditEntries :many
+SELECT * FROM audit_log
+WHERE id > sqlc.arg(after_id)::BIGINT
+  AND (sqlc.narg(principal)::TEXT IS NULL OR principal = sqlc.narg(principal))
+  AND (sqlc.narg(action)::TEXT IS NULL OR action = sqlc.narg(action))
+  AND (sqlc.narg(resource)::TEXT IS NULL OR starts_with(resource, sqlc.narg(resource)))
+  AND (sqlc.narg(outcome)::TEXT IS NULL OR outcome = sqlc.narg(outcome))
+  AND (sqlc.narg(occurred_from)::TIMESTAMP IS NULL OR occurred_at >= sqlc.narg(occurred_from))
+  AND (sqlc.narg(occurred_to)::TIMESTAMP IS NULL OR occurred_at < sqlc.narg(occurred_to))
+ORDER BY id
+LIMIT sqlc.arg(row_limit)::INTEGER;
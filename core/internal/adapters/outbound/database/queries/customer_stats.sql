@@ -0,0 +1,8 @@
+-- name: GetCustomerStats :one
+SELECT * FROM customer_stats
+WHERE customer_code = $1;
+
+-- name: GetCustomerLifetimeValues :many
+SELECT * FROM customer_lifetime_values
+WHERE customer_code = $1
+ORDER BY currency;
@@ -0,0 +1,23 @@
+-- name: CreateCustomer :one
+INSERT INTO customers (code, name, email, document, document_type, status)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: GetCustomerByCode :one
+SELECT * FROM customers
+WHERE code = $1;
+
+-- name: ListCustomers :many
+SELECT * FROM customers
+ORDER BY code;
+
+-- name: UpdateCustomer :one
+UPDATE customers
+SET name = $2,
+    email = $3,
+    document = $4,
+    document_type = $5,
+    status = $6,
+    updated_at = NOW()
+WHERE code = $1
+RETURNING *;
@@ -0,0 +1,13 @@
+-- name: UpsertFxRate :exec
+INSERT INTO fx_rates (base_currency, quote_currency, rate, effective_date, created_at)
+VALUES ($1, $2, $3, $4, NOW())
+ON CONFLICT (base_currency, quote_currency, effective_date)
+DO UPDATE SET rate = EXCLUDED.rate;
+
+-- name: GetEffectiveFxRate :one
+SELECT * FROM fx_rates
+WHERE base_currency = $1
+  AND quote_currency = $2
+  AND effective_date <= $3
+ORDER BY effective_date DESC
+LIMIT 1;
@@ -24,7 +24,3 @@ ORDER BY created_at DESC;
 -- name: GetOrderItems :many
 SELECT * FROM order_items
 WHERE order_id = $1;
-
--- name: CountOrdersByCustomer :one
-SELECT COUNT(*) FROM orders
-WHERE customer_code = $1;
@@ -0,0 +1,3 @@
+-- name: CountPendingOutboxEvents :one
+SELECT COUNT(*) FROM outbox_events
+WHERE published_at IS NULL;
@@ -0,0 +1,28 @@
+-- name: CreateProduct :one
+INSERT INTO products (sku, name, unit, list_price, active)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetProductBySKU :one
+SELECT * FROM products
+WHERE sku = $1;
+
+-- name: ListProducts :many
+SELECT * FROM products
+ORDER BY sku;
+
+-- name: UpdateProduct :one
+UPDATE products
+SET name = $2,
+    unit = $3,
+    list_price = $4,
+    active = $5,
+    updated_at = NOW()
+WHERE sku = $1
+RETURNING *;
+
+-- name: DeactivateProduct :execrows
+UPDATE products
+SET active = FALSE,
+    updated_at = NOW()
+WHERE sku = $1;
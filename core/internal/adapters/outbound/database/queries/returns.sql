@@ -0,0 +1,7 @@
+-- name: GetReturnedQuantities :many
+SELECT ri.product, SUM(ri.quantity)::BIGINT AS quantity
+FROM return_items ri
+JOIN returns r ON r.id = ri.return_id
+WHERE r.order_id = $1
+GROUP BY ri.product
+ORDER BY ri.product;
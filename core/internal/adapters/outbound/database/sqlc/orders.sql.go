@@ -11,22 +11,10 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const countOrdersByCustomer = `-- name: CountOrdersByCustomer :one
-SELECT COUNT(*) FROM orders
-WHERE customer_code = $1
-`
-
-func (q *Queries) CountOrdersByCustomer(ctx context.Context, customerCode int32) (int64, error) {
-	row := q.db.QueryRow(ctx, countOrdersByCustomer, customerCode)
-	var count int64
-	err := row.Scan(&count)
-	return count, err
-}
-
 const createOrder = `-- name: CreateOrder :one
 INSERT INTO orders (code, customer_code, created_at)
 VALUES ($1, $2, NOW())
-RETURNING id, code, customer_code, created_at
+RETURNING id, code, customer_code, created_at, total, item_count, status, version, updated_at, refunded_total, currency, subtotal, discount_total, coupon_code, coupon_discount, shipping, tax
 `
 
 type CreateOrderParams struct {
@@ -42,6 +30,19 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 		&i.Code,
 		&i.CustomerCode,
 		&i.CreatedAt,
+		&i.Total,
+		&i.ItemCount,
+		&i.Status,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.RefundedTotal,
+		&i.Currency,
+		&i.Subtotal,
+		&i.DiscountTotal,
+		&i.CouponCode,
+		&i.CouponDiscount,
+		&i.Shipping,
+		&i.Tax,
 	)
 	return i, err
 }
@@ -49,7 +50,7 @@ func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order
 const createOrderItem = `-- name: CreateOrderItem :one
 INSERT INTO order_items (order_id, product, quantity, price, created_at)
 VALUES ($1, $2, $3, $4, NOW())
-RETURNING id, order_id, product, quantity, price, created_at
+RETURNING id, order_id, product, quantity, price, created_at, discount, sku, price_flagged
 `
 
 type CreateOrderItemParams struct {
@@ -74,12 +75,15 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		&i.Quantity,
 		&i.Price,
 		&i.CreatedAt,
+		&i.Discount,
+		&i.Sku,
+		&i.PriceFlagged,
 	)
 	return i, err
 }
 
 const getOrderByCode = `-- name: GetOrderByCode :one
-SELECT id, code, customer_code, created_at FROM orders
+SELECT id, code, customer_code, created_at, total, item_count, status, version, updated_at, refunded_total, currency, subtotal, discount_total, coupon_code, coupon_discount, shipping, tax FROM orders
 WHERE code = $1
 `
 
@@ -91,12 +95,25 @@ func (q *Queries) GetOrderByCode(ctx context.Context, code int32) (Order, error)
 		&i.Code,
 		&i.CustomerCode,
 		&i.CreatedAt,
+		&i.Total,
+		&i.ItemCount,
+		&i.Status,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.RefundedTotal,
+		&i.Currency,
+		&i.Subtotal,
+		&i.DiscountTotal,
+		&i.CouponCode,
+		&i.CouponDiscount,
+		&i.Shipping,
+		&i.Tax,
 	)
 	return i, err
 }
 
 const getOrderByID = `-- name: GetOrderByID :one
-SELECT id, code, customer_code, created_at FROM orders
+SELECT id, code, customer_code, created_at, total, item_count, status, version, updated_at, refunded_total, currency, subtotal, discount_total, coupon_code, coupon_discount, shipping, tax FROM orders
 WHERE id = $1
 `
 
@@ -108,12 +125,25 @@ func (q *Queries) GetOrderByID(ctx context.Context, id int64) (Order, error) {
 		&i.Code,
 		&i.CustomerCode,
 		&i.CreatedAt,
+		&i.Total,
+		&i.ItemCount,
+		&i.Status,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.RefundedTotal,
+		&i.Currency,
+		&i.Subtotal,
+		&i.DiscountTotal,
+		&i.CouponCode,
+		&i.CouponDiscount,
+		&i.Shipping,
+		&i.Tax,
 	)
 	return i, err
 }
 
 const getOrderItems = `-- name: GetOrderItems :many
-SELECT id, order_id, product, quantity, price, created_at FROM order_items
+SELECT id, order_id, product, quantity, price, created_at, discount, sku, price_flagged FROM order_items
 WHERE order_id = $1
 `
 
@@ -133,6 +163,9 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem
 			&i.Quantity,
 			&i.Price,
 			&i.CreatedAt,
+			&i.Discount,
+			&i.Sku,
+			&i.PriceFlagged,
 		); err != nil {
 			return nil, err
 		}
@@ -145,7 +178,7 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem
 }
 
 const getOrdersByCustomerCode = `-- name: GetOrdersByCustomerCode :many
-SELECT id, code, customer_code, created_at FROM orders
+SELECT id, code, customer_code, created_at, total, item_count, status, version, updated_at, refunded_total, currency, subtotal, discount_total, coupon_code, coupon_discount, shipping, tax FROM orders
 WHERE customer_code = $1
 ORDER BY created_at DESC
 `
@@ -164,6 +197,19 @@ func (q *Queries) GetOrdersByCustomerCode(ctx context.Context, customerCode int3
 			&i.Code,
 			&i.CustomerCode,
 			&i.CreatedAt,
+			&i.Total,
+			&i.ItemCount,
+			&i.Status,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.RefundedTotal,
+			&i.Currency,
+			&i.Subtotal,
+			&i.DiscountTotal,
+			&i.CouponCode,
+			&i.CouponDiscount,
+			&i.Shipping,
+			&i.Tax,
 		); err != nil {
 			return nil, err
 		}
@@ -9,13 +9,37 @@ import (
 )
 
 type Querier interface {
-	CountOrdersByCustomer(ctx context.Context, customerCode int32) (int64, error)
+	CountPendingOutboxEvents(ctx context.Context) (int64, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
+	CreateAuditEntry(ctx context.Context, arg CreateAuditEntryParams) (int64, error)
+	CreateCustomer(ctx context.Context, arg CreateCustomerParams) (Customer, error)
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
+	CreateProduct(ctx context.Context, arg CreateProductParams) (Product, error)
+	DeactivateProduct(ctx context.Context, sku string) (int64, error)
+	DeleteStagedAuditEntries(ctx context.Context, ids []int64) error
+	GetActiveAPIKeyByHash(ctx context.Context, keyHash string) (ApiKey, error)
+	GetCustomerByCode(ctx context.Context, code int32) (Customer, error)
+	GetCustomerLifetimeValues(ctx context.Context, customerCode int32) ([]CustomerLifetimeValue, error)
+	GetCustomerStats(ctx context.Context, customerCode int32) (CustomerStat, error)
+	GetEffectiveFxRate(ctx context.Context, arg GetEffectiveFxRateParams) (FxRate, error)
+	GetLastAuditHash(ctx context.Context) (string, error)
 	GetOrderByCode(ctx context.Context, code int32) (Order, error)
 	GetOrderByID(ctx context.Context, id int64) (Order, error)
 	GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem, error)
 	GetOrdersByCustomerCode(ctx context.Context, customerCode int32) ([]Order, error)
+	GetProductBySKU(ctx context.Context, sku string) (Product, error)
+	GetReturnedQuantities(ctx context.Context, orderID int64) ([]GetReturnedQuantitiesRow, error)
+	GetStagedAuditEntries(ctx context.Context, limit int32) ([]AuditLogStaging, error)
+	ListAuditEntries(ctx context.Context, arg ListAuditEntriesParams) ([]AuditLog, error)
+	ListCustomers(ctx context.Context) ([]Customer, error)
+	ListProducts(ctx context.Context) ([]Product, error)
+	NotifyOrderChange(ctx context.Context, arg NotifyOrderChangeParams) error
+	StageAuditEntry(ctx context.Context, arg StageAuditEntryParams) error
+	TryLockAuditLog(ctx context.Context) (bool, error)
+	UpdateCustomer(ctx context.Context, arg UpdateCustomerParams) (Customer, error)
+	UpdateProduct(ctx context.Context, arg UpdateProductParams) (Product, error)
+	UpsertFxRate(ctx context.Context, arg UpsertFxRateParams) error
 }
 
 var _ Querier = (*Queries)(nil)
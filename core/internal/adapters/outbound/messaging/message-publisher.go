@@ -3,7 +3,9 @@ package messaging
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
@@ -14,23 +16,78 @@ import (
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
 )
 
+// Message types the ms consumer dispatches on
+const (
+	orderCreatedType   = "order.created"
+	orderCancelledType = "order.cancelled"
+	orderAmendedType   = "order.amended"
+	orderReturnedType  = "order.returned"
+)
+
+// Delays between attempts to reopen a lost broker connection
+const (
+	minReconnectDelay = time.Second
+	maxReconnectDelay = 30 * time.Second
+)
+
 type RabbitMQPublisher struct {
-	conn     *amqp.Connection
-	channel  *amqp.Channel
-	exchange string
-	queue    string
+	url        string
+	exchange   string
+	queue      string
+	partitions int
+	log        *zap.Logger
+
+	// mu guards the connection and channel, which are replaced when the broker drops them
+	mu      sync.RWMutex
+	conn    *amqp.Connection
+	channel *amqp.Channel
+	closed  bool
+	done    chan struct{}
 }
 
-func NewRabbitMQPublisher(url, exchange, queue string) (ports.MessagePublisher, error) {
+// NewRabbitMQPublisher connects to RabbitMQ and declares the order queues.
+// With partitions > 1 orders are spread over queues "<queue>.0" .. "<queue>.N-1"
+// by customer code, so each customer's orders always land on the same queue.
+// When the connection or channel closes it is reopened with backoff until Close.
+func NewRabbitMQPublisher(url, exchange, queue string, partitions int) (ports.MessagePublisher, error) {
+	conn, channel, err := connect(url, exchange, queue, partitions)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.Named("rabbitmq")
+	log.Info("RabbitMQ publisher initialized",
+		zap.String("exchange", exchange),
+		zap.String("queue", queue),
+		zap.Int("partitions", partitions),
+	)
+
+	p := &RabbitMQPublisher{
+		url:        url,
+		exchange:   exchange,
+		queue:      queue,
+		partitions: partitions,
+		log:        log,
+		conn:       conn,
+		channel:    channel,
+		done:       make(chan struct{}),
+	}
+	go p.watch(conn, channel)
+
+	return p, nil
+}
+
+// connect dials the broker, opens a channel and declares the exchange and queues
+func connect(url, exchange, queue string, partitions int) (*amqp.Connection, *amqp.Channel, error) {
 	conn, err := amqp.Dial(url)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+		return nil, nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
 	channel, err := conn.Channel()
 	if err != nil {
 		conn.Close()
-		return nil, fmt.Errorf("failed to open channel: %w", err)
+		return nil, nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
 	// Declare exchange (idempotent operation)
@@ -46,69 +103,156 @@ func NewRabbitMQPublisher(url, exchange, queue string) (ports.MessagePublisher,
 	if err != nil {
 		channel.Close()
 		conn.Close()
-		return nil, fmt.Errorf("failed to declare exchange: %w", err)
+		return nil, nil, fmt.Errorf("failed to declare exchange: %w", err)
 	}
 
-	// Declare queue (idempotent operation)
-	_, err = channel.QueueDeclare(
-		queue, // name
-		true,  // durable
-		false, // delete when unused
-		false, // exclusive
-		false, // no-wait
-		nil,   // arguments
-	)
-	if err != nil {
-		channel.Close()
-		conn.Close()
-		return nil, fmt.Errorf("failed to declare queue: %w", err)
+	for _, q := range QueueNames(queue, partitions) {
+		// Declare queue (idempotent operation)
+		_, err = channel.QueueDeclare(
+			q,                          // name
+			true,                       // durable
+			false,                      // delete when unused
+			false,                      // exclusive
+			false,                      // no-wait
+			QueueArguments(partitions), // arguments
+		)
+		if err != nil {
+			channel.Close()
+			conn.Close()
+			return nil, nil, fmt.Errorf("failed to declare queue %s: %w", q, err)
+		}
+
+		// Bind queue to exchange
+		err = channel.QueueBind(
+			q,        // queue name
+			q,        // routing key (same as queue name)
+			exchange, // exchange
+			false,
+			nil,
+		)
+		if err != nil {
+			channel.Close()
+			conn.Close()
+			return nil, nil, fmt.Errorf("failed to bind queue %s: %w", q, err)
+		}
 	}
 
-	// Bind queue to exchange
-	err = channel.QueueBind(
-		queue,    // queue name
-		queue,    // routing key (same as queue name)
-		exchange, // exchange
-		false,
-		nil,
-	)
-	if err != nil {
-		channel.Close()
+	return conn, channel, nil
+}
+
+// watch waits for the connection or channel to close and reopens both, retrying with
+// backoff, until the publisher is closed. Publishes fail while the broker is away.
+func (p *RabbitMQPublisher) watch(conn *amqp.Connection, channel *amqp.Channel) {
+	for {
+		var reason *amqp.Error
+		select {
+		case <-p.done:
+			return
+		case reason = <-conn.NotifyClose(make(chan *amqp.Error, 1)):
+		case reason = <-channel.NotifyClose(make(chan *amqp.Error, 1)):
+		}
+
+		select {
+		case <-p.done:
+			return
+		default:
+		}
+
+		// reason is nil when the broker closed the connection gracefully
+		lost := errors.New("closed by the broker")
+		if reason != nil {
+			lost = reason
+		}
+		p.log.Error("RabbitMQ connection lost", zap.Error(lost))
+		// A channel can close on its own; drop the connection too and start afresh
 		conn.Close()
-		return nil, fmt.Errorf("failed to bind queue: %w", err)
-	}
 
-	logger.Info("RabbitMQ publisher initialized",
-		zap.String("exchange", exchange),
-		zap.String("queue", queue),
-	)
+		delay := minReconnectDelay
+		for {
+			select {
+			case <-p.done:
+				return
+			case <-time.After(delay):
+			}
+
+			var err error
+			conn, channel, err = connect(p.url, p.exchange, p.queue, p.partitions)
+			if err == nil {
+				break
+			}
+
+			delay = min(delay*2, maxReconnectDelay)
+			p.log.Error("Failed to reconnect to RabbitMQ",
+				zap.Error(err),
+				zap.Duration("retry_in", delay),
+			)
+		}
 
-	return &RabbitMQPublisher{
-		conn:     conn,
-		channel:  channel,
-		exchange: exchange,
-		queue:    queue,
-	}, nil
+		p.mu.Lock()
+		if p.closed {
+			p.mu.Unlock()
+			channel.Close()
+			conn.Close()
+			return
+		}
+		p.conn, p.channel = conn, channel
+		p.mu.Unlock()
+
+		p.log.Info("RabbitMQ publisher reconnected")
+	}
+}
+
+// current returns the connection and channel in use
+func (p *RabbitMQPublisher) current() (*amqp.Connection, *amqp.Channel) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.conn, p.channel
 }
 
 func (p *RabbitMQPublisher) PublishOrder(ctx context.Context, order *domain.Order) error {
-	body, err := json.Marshal(order)
+	return p.publish(ctx, orderCreatedType, order.OrderCode, order.CustomerCode, order)
+}
+
+// PublishCancellation routes the cancellation to the same queue as the customer's orders,
+// so it is applied after the order it refers to.
+func (p *RabbitMQPublisher) PublishCancellation(ctx context.Context, cancellation *domain.OrderCancellation) error {
+	return p.publish(ctx, orderCancelledType, cancellation.OrderCode, cancellation.CustomerCode, cancellation)
+}
+
+// PublishAmendment routes the amendment to the same queue as the customer's orders,
+// so amendments are applied in the order they were accepted.
+func (p *RabbitMQPublisher) PublishAmendment(ctx context.Context, amendment *domain.OrderAmendment) error {
+	return p.publish(ctx, orderAmendedType, amendment.OrderCode, amendment.CustomerCode, amendment)
+}
+
+// PublishReturn routes the return to the same queue as the customer's orders
+func (p *RabbitMQPublisher) PublishReturn(ctx context.Context, orderReturn *domain.OrderReturn) error {
+	return p.publish(ctx, orderReturnedType, orderReturn.OrderCode, orderReturn.CustomerCode, orderReturn)
+}
+
+func (p *RabbitMQPublisher) publish(ctx context.Context, messageType string, orderCode int64, customerCode int, message any) error {
+	body, err := json.Marshal(message)
 	if err != nil {
-		logger.Error("Failed to marshal message",
+		p.log.Error("Failed to marshal message",
 			zap.Error(err),
-			zap.Int64("order_code", order.OrderCode),
+			zap.String("message_type", messageType),
+			zap.Int64("order_code", orderCode),
 		)
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	err = p.channel.PublishWithContext(
+	routingKey := RoutingKey(p.queue, p.partitions, customerCode)
+
+	_, channel := p.current()
+	err = channel.PublishWithContext(
 		ctx,
 		p.exchange, // exchange
-		p.queue,    // routing key
+		routingKey, // routing key
 		false,      // mandatory
 		false,      // immediate
 		amqp.Publishing{
 			ContentType:  "application/json",
+			Type:         messageType,
 			Body:         body,
 			DeliveryMode: amqp.Persistent, // make message persistent
 			Timestamp:    time.Now(),
@@ -116,39 +260,73 @@ func (p *RabbitMQPublisher) PublishOrder(ctx context.Context, order *domain.Orde
 	)
 
 	if err != nil {
-		logger.Error("Failed to publish message",
+		p.log.Error("Failed to publish message",
 			zap.Error(err),
-			zap.Int64("order_code", order.OrderCode),
+			zap.String("message_type", messageType),
+			zap.Int64("order_code", orderCode),
 		)
 		return fmt.Errorf("failed to publish message: %v", err)
 	}
 
-	logger.Info("Message published successfully",
-		zap.Int64("order_code", order.OrderCode),
-		zap.Int("customer_code", order.CustomerCode),
+	p.log.Info("Message published successfully",
+		zap.String("message_type", messageType),
+		zap.Int64("order_code", orderCode),
+		zap.Int("customer_code", customerCode),
 		zap.String("exchange", p.exchange),
-		zap.String("routing_key", p.queue),
+		zap.String("routing_key", routingKey),
 	)
 
 	return nil
 }
 
+// CheckHealth reports a dead broker connection or channel. Both are reopened in the
+// background, so the instance is ready again once the broker is back.
+func (p *RabbitMQPublisher) CheckHealth(ctx context.Context) error {
+	conn, channel := p.current()
+	if conn.IsClosed() {
+		return errors.New("connection closed")
+	}
+	if channel.IsClosed() {
+		return errors.New("channel closed")
+	}
+	return nil
+}
+
+// DebugState describes the broker connection and channel for the debug listener
+func (p *RabbitMQPublisher) DebugState() map[string]any {
+	conn, channel := p.current()
+	return map[string]any{
+		"connection_closed": conn.IsClosed(),
+		"channel_closed":    channel.IsClosed(),
+		"exchange":          p.exchange,
+		"queues":            QueueNames(p.queue, p.partitions),
+	}
+}
+
 func (p *RabbitMQPublisher) Close() error {
-	logger.Info("Closing RabbitMQ publisher")
+	p.log.Info("Closing RabbitMQ publisher")
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.closed {
+		return nil
+	}
+	p.closed = true
+	close(p.done)
 
 	if p.channel != nil {
 		if err := p.channel.Close(); err != nil {
-			logger.Error("Failed to close channel", zap.Error(err))
+			p.log.Error("Failed to close channel", zap.Error(err))
 		}
 	}
 
 	if p.conn != nil {
 		if err := p.conn.Close(); err != nil {
-			logger.Error("Failed to close connection", zap.Error(err))
+			p.log.Error("Failed to close connection", zap.Error(err))
 			return err
 		}
 	}
 
-	logger.Info("RabbitMQ publisher closed successfully")
+	p.log.Info("RabbitMQ publisher closed successfully")
 	return nil
 }
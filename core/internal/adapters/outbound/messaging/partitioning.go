@@ -0,0 +1,49 @@
+package messaging
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// QueueNames returns the queues orders are published to.
+// A single partition keeps the plain queue name so existing deployments are unaffected.
+func QueueNames(queue string, partitions int) []string {
+	if partitions <= 1 {
+		return []string{queue}
+	}
+
+	names := make([]string, partitions)
+	for i := range partitions {
+		names[i] = fmt.Sprintf("%s.%d", queue, i)
+	}
+	return names
+}
+
+// QueueArguments returns the declaration arguments for the order queues.
+// Partition queues use single active consumer so only one worker across all
+// consumer replicas reads a partition at a time, preserving per-customer order.
+func QueueArguments(partitions int) amqp.Table {
+	if partitions <= 1 {
+		return nil
+	}
+	return amqp.Table{"x-single-active-consumer": true}
+}
+
+// RoutingKey picks the queue for a customer by hashing its code.
+// The ms consumer must be configured with the same partition count.
+func RoutingKey(queue string, partitions int, customerCode int) string {
+	if partitions <= 1 {
+		return queue
+	}
+	return fmt.Sprintf("%s.%d", queue, Partition(customerCode, partitions))
+}
+
+// Partition returns the FNV-1a hash of the customer code modulo the partition count
+func Partition(customerCode int, partitions int) int {
+	h := fnv.New32a()
+	h.Write([]byte(strconv.Itoa(customerCode)))
+	return int(h.Sum32() % uint32(partitions))
+}
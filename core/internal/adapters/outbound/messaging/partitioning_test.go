@@ -0,0 +1,68 @@
+package messaging
+
+import (
+	"reflect"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// The ms consumer declares the same queues; these names and arguments must not drift
+func TestQueueNames(t *testing.T) {
+	tests := []struct {
+		partitions int
+		want       []string
+	}{
+		{partitions: 0, want: []string{"orders"}},
+		{partitions: 1, want: []string{"orders"}},
+		{partitions: 4, want: []string{"orders.0", "orders.1", "orders.2", "orders.3"}},
+	}
+
+	for _, tt := range tests {
+		if got := QueueNames("orders", tt.partitions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("QueueNames(orders, %d) = %v, want %v", tt.partitions, got, tt.want)
+		}
+	}
+}
+
+func TestQueueArguments(t *testing.T) {
+	if got := QueueArguments(1); got != nil {
+		t.Errorf("QueueArguments(1) = %v, want nil", got)
+	}
+
+	want := amqp.Table{"x-single-active-consumer": true}
+	if got := QueueArguments(4); !reflect.DeepEqual(got, want) {
+		t.Errorf("QueueArguments(4) = %v, want %v", got, want)
+	}
+}
+
+// Changing the hash moves customers to other queues, breaking their ordering while
+// both layouts have messages in flight
+func TestPartition(t *testing.T) {
+	tests := []struct {
+		customerCode int
+		want         map[int]int
+	}{
+		{customerCode: 1, want: map[int]int{2: 0, 4: 0, 8: 4}},
+		{customerCode: 42, want: map[int]int{2: 1, 4: 3, 8: 3}},
+		{customerCode: 1001, want: map[int]int{2: 1, 4: 3, 8: 7}},
+		{customerCode: 123456, want: map[int]int{2: 0, 4: 2, 8: 2}},
+	}
+
+	for _, tt := range tests {
+		for partitions, want := range tt.want {
+			if got := Partition(tt.customerCode, partitions); got != want {
+				t.Errorf("Partition(%d, %d) = %d, want %d", tt.customerCode, partitions, got, want)
+			}
+		}
+	}
+}
+
+func TestRoutingKey(t *testing.T) {
+	if got := RoutingKey("orders", 1, 42); got != "orders" {
+		t.Errorf("RoutingKey(orders, 1, 42) = %q, want orders", got)
+	}
+	if got := RoutingKey("orders", 8, 1001); got != "orders.7" {
+		t.Errorf("RoutingKey(orders, 8, 1001) = %q, want orders.7", got)
+	}
+}
@@ -0,0 +1,62 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+)
+
+// AuditChainer moves staged audit entries onto the hash chain off the request path.
+// Every instance runs one; the advisory lock taken by ChainStaged lets a single
+// instance chain at a time.
+type AuditChainer struct {
+	audit    ports.AuditService
+	interval time.Duration
+}
+
+// NewAuditChainer creates a new AuditChainer with dependency injection
+func NewAuditChainer(audit ports.AuditService, interval time.Duration) *AuditChainer {
+	return &AuditChainer{audit: audit, interval: interval}
+}
+
+// Start replays this instance's spool and chains staged entries until the context
+// is cancelled. Full batches are chained back to back so a backlog drains quickly.
+func (c *AuditChainer) Start(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	logger.Info("Audit chainer started", zap.Duration("interval", c.interval))
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Audit chainer stopped", zap.String("reason", "shutdown"))
+			return
+		case <-ticker.C:
+			replayed, err := c.audit.ReplaySpooled(ctx)
+			if err != nil {
+				logger.Error("Failed to replay spooled audit entries", zap.Error(err))
+			} else if replayed > 0 {
+				logger.Info("Spooled audit entries replayed", zap.Int("replayed", replayed))
+			}
+
+			for {
+				chained, err := c.audit.ChainStaged(ctx)
+				if err != nil {
+					logger.Error("Failed to chain audit entries", zap.Error(err))
+					break
+				}
+				if chained > 0 {
+					logger.Debug("Audit entries chained", zap.Int("chained", chained))
+				}
+				if chained < auditBatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/spool"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// auditBatchSize is how many entries are read at a time when no limit is given,
+// when the chain is verified and when staged entries are chained
+const auditBatchSize = 1000
+
+// AuditService keeps the audit log in the database. Requests only stage their
+// entries; ChainStaged, run by one instance at a time, hashes them onto the log.
+type AuditService struct {
+	queries *db.Store
+	spool   *spool.File
+}
+
+// NewAuditService creates a new AuditService with dependency injection. Entries
+// that cannot be staged are kept in the fallback spool, when given, until replayed.
+func NewAuditService(queries *db.Store, fallback *spool.File) ports.AuditService {
+	return &AuditService{queries: queries, spool: fallback}
+}
+
+// Record stages the entry with a plain insert, taking no lock. When the database
+// refuses it the entry is spooled to disk and domain.ErrAuditEntrySpooled returned;
+// any other error means the entry is lost.
+func (s *AuditService) Record(ctx context.Context, entry *domain.AuditEntry) error {
+	entry.OccurredAt = entry.OccurredAt.UTC().Truncate(time.Microsecond)
+
+	err := s.queries.StageAuditEntry(ctx, stageAuditEntryParams(entry))
+	if err == nil {
+		return nil
+	}
+	if s.spool == nil {
+		return fmt.Errorf("error staging audit entry: %w", err)
+	}
+
+	if spoolErr := s.spool.Append(entry); spoolErr != nil {
+		return fmt.Errorf("error staging audit entry: %w, and spooling it: %w", err, spoolErr)
+	}
+	return fmt.Errorf("%w: %w", domain.ErrAuditEntrySpooled, err)
+}
+
+// ChainStaged moves up to auditBatchSize staged entries onto the log in id order. It
+// takes a transaction-scoped advisory lock without waiting, so one instance chains at
+// a time and the others skip the batch; requests never wait on the lock.
+func (s *AuditService) ChainStaged(ctx context.Context) (int, error) {
+	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+
+	locked, err := queries.TryLockAuditLog(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error locking audit log: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	staged, err := queries.GetStagedAuditEntries(ctx, auditBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error reading staged audit entries: %w", err)
+	}
+	if len(staged) == 0 {
+		return 0, nil
+	}
+
+	prevHash, err := queries.GetLastAuditHash(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		prevHash = domain.AuditGenesisHash
+	} else if err != nil {
+		return 0, fmt.Errorf("error reading last audit hash: %w", err)
+	}
+
+	ids := make([]int64, 0, len(staged))
+	for _, row := range staged {
+		entry := auditEntryFromStaged(row)
+		entry.PrevHash = prevHash
+		entry.Hash = entry.ComputeHash()
+
+		_, err := queries.CreateAuditEntry(ctx, database.CreateAuditEntryParams{
+			OccurredAt:    pgtype.Timestamp{Time: entry.OccurredAt, Valid: true},
+			Principal:     entry.Principal,
+			AuthMethod:    entry.AuthMethod,
+			Action:        entry.Action,
+			Resource:      entry.Resource,
+			CorrelationID: entry.CorrelationID,
+			SourceIp:      entry.SourceIP,
+			Outcome:       string(entry.Outcome),
+			StatusCode:    int32(entry.StatusCode),
+			PrevHash:      entry.PrevHash,
+			Hash:          entry.Hash,
+		})
+		if err != nil {
+			return 0, fmt.Errorf("error storing audit entry: %w", err)
+		}
+
+		prevHash = entry.Hash
+		ids = append(ids, row.ID)
+	}
+
+	if err := queries.DeleteStagedAuditEntries(ctx, ids); err != nil {
+		return 0, fmt.Errorf("error removing chained audit entries: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return len(staged), nil
+}
+
+// ReplaySpooled stages every spooled entry in one transaction and empties the spool.
+// An entry is staged twice only if the process dies between the commit and the
+// spool being emptied.
+func (s *AuditService) ReplaySpooled(ctx context.Context) (int, error) {
+	if s.spool == nil {
+		return 0, nil
+	}
+
+	return s.spool.Drain(func(records []json.RawMessage) error {
+		tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		queries := s.queries.WithTx(tx)
+
+		for _, record := range records {
+			var entry domain.AuditEntry
+			if err := json.Unmarshal(record, &entry); err != nil {
+				return fmt.Errorf("error reading spooled audit entry: %w", err)
+			}
+			if err := queries.StageAuditEntry(ctx, stageAuditEntryParams(&entry)); err != nil {
+				return fmt.Errorf("error staging spooled audit entry: %w", err)
+			}
+		}
+
+		return tx.Commit(ctx)
+	})
+}
+
+// ListEntries retrieves up to filter.Limit entries recorded after filter.AfterID
+func (s *AuditService) ListEntries(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error) {
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = auditBatchSize
+	}
+
+	rows, err := s.queries.ListAuditEntries(ctx, database.ListAuditEntriesParams{
+		AfterID:      filter.AfterID,
+		Principal:    optionalText(filter.Principal),
+		Action:       optionalText(filter.Action),
+		Resource:     optionalText(filter.Resource),
+		Outcome:      optionalText(string(filter.Outcome)),
+		OccurredFrom: optionalTimestamp(filter.From),
+		OccurredTo:   optionalTimestamp(filter.To),
+		RowLimit:     int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*domain.AuditEntry, 0, len(rows))
+	for _, row := range rows {
+		entries = append(entries, auditEntryFromRow(row))
+	}
+	return entries, nil
+}
+
+// VerifyChain walks the whole log in order. An entry whose hash does not match its
+// fields was altered; one whose previous hash is not the hash before it follows
+// removed or reordered entries.
+func (s *AuditService) VerifyChain(ctx context.Context) (domain.AuditVerification, error) {
+	result := domain.AuditVerification{Valid: true, LastHash: domain.AuditGenesisHash}
+
+	var afterID int64
+	for {
+		entries, err := s.ListEntries(ctx, domain.AuditFilter{AfterID: afterID, Limit: auditBatchSize})
+		if err != nil {
+			return domain.AuditVerification{}, err
+		}
+
+		for _, entry := range entries {
+			switch {
+			case entry.PrevHash != result.LastHash:
+				result.Valid, result.BrokenAt = false, entry.ID
+				result.Reason = "previous hash does not match the entry before"
+			case entry.ComputeHash() != entry.Hash:
+				result.Valid, result.BrokenAt = false, entry.ID
+				result.Reason = "hash does not match the entry's content"
+			}
+			if !result.Valid {
+				return result, nil
+			}
+
+			result.Checked++
+			result.LastHash = entry.Hash
+			afterID = entry.ID
+		}
+
+		if len(entries) < auditBatchSize {
+			return result, nil
+		}
+	}
+}
+
+// stageAuditEntryParams maps an entry to the staging row, which holds no hashes yet
+func stageAuditEntryParams(entry *domain.AuditEntry) database.StageAuditEntryParams {
+	return database.StageAuditEntryParams{
+		OccurredAt:    pgtype.Timestamp{Time: entry.OccurredAt.UTC(), Valid: true},
+		Principal:     entry.Principal,
+		AuthMethod:    entry.AuthMethod,
+		Action:        entry.Action,
+		Resource:      entry.Resource,
+		CorrelationID: entry.CorrelationID,
+		SourceIp:      entry.SourceIP,
+		Outcome:       string(entry.Outcome),
+		StatusCode:    int32(entry.StatusCode),
+	}
+}
+
+// auditEntryFromStaged maps a staged row to the domain entry it will chain
+func auditEntryFromStaged(row database.AuditLogStaging) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		OccurredAt:    row.OccurredAt.Time,
+		Principal:     row.Principal,
+		AuthMethod:    row.AuthMethod,
+		Action:        row.Action,
+		Resource:      row.Resource,
+		CorrelationID: row.CorrelationID,
+		SourceIP:      row.SourceIp,
+		Outcome:       domain.AuditOutcome(row.Outcome),
+		StatusCode:    int(row.StatusCode),
+	}
+}
+
+// auditEntryFromRow maps a stored audit row to the domain entry
+func auditEntryFromRow(row database.AuditLog) *domain.AuditEntry {
+	return &domain.AuditEntry{
+		ID:            row.ID,
+		OccurredAt:    row.OccurredAt.Time,
+		Principal:     row.Principal,
+		AuthMethod:    row.AuthMethod,
+		Action:        row.Action,
+		Resource:      row.Resource,
+		CorrelationID: row.CorrelationID,
+		SourceIP:      row.SourceIp,
+		Outcome:       domain.AuditOutcome(row.Outcome),
+		StatusCode:    int(row.StatusCode),
+		PrevHash:      row.PrevHash,
+		Hash:          row.Hash,
+	}
+}
+
+// optionalText maps an empty filter value to NULL, which matches every row
+func optionalText(value string) pgtype.Text {
+	return pgtype.Text{String: value, Valid: value != ""}
+}
+
+// optionalTimestamp maps an unset bound to NULL, which matches every row
+func optionalTimestamp(value time.Time) pgtype.Timestamp {
+	return pgtype.Timestamp{Time: value.UTC(), Valid: !value.IsZero()}
+}
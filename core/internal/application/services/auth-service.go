@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/jwt"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/jackc/pgx/v5"
+)
+
+// apiKeyPrefix marks issued keys so they are recognisable in logs and secret scanners
+const apiKeyPrefix = "btg_"
+
+// apiKeyDisplayLength is how many leading characters of a key are stored in clear to identify it
+const apiKeyDisplayLength = 12
+
+// customerCodesClaim is the JWT claim binding a token to customer codes, given
+// as a single number or an array of numbers
+const customerCodesClaim = "customer_codes"
+
+// AuthService authenticates callers by API key or JWT bearer token
+type AuthService struct {
+	queries  *db.Store
+	verifier *jwt.Verifier
+}
+
+// NewAuthService creates a new AuthService with dependency injection.
+// A nil verifier disables bearer tokens, leaving only API keys.
+func NewAuthService(queries *db.Store, verifier *jwt.Verifier) ports.AuthService {
+	return &AuthService{
+		queries:  queries,
+		verifier: verifier,
+	}
+}
+
+// AuthenticateAPIKey looks an active key up by its SHA-256 hash
+func (s *AuthService) AuthenticateAPIKey(ctx context.Context, key string) (*domain.Principal, error) {
+	apiKey, err := s.queries.GetActiveAPIKeyByHash(ctx, hashAPIKey(key))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	customerCodes := make([]int, 0, len(apiKey.CustomerCodes))
+	for _, code := range apiKey.CustomerCodes {
+		customerCodes = append(customerCodes, int(code))
+	}
+
+	return &domain.Principal{
+		Subject:       apiKey.Name,
+		Method:        domain.AuthMethodAPIKey,
+		Scopes:        apiKey.Scopes,
+		CustomerCodes: customerCodes,
+	}, nil
+}
+
+// AuthenticateToken verifies the signature and claims of a JWT against the configured JWKS
+func (s *AuthService) AuthenticateToken(ctx context.Context, token string) (*domain.Principal, error) {
+	if s.verifier == nil {
+		return nil, domain.ErrInvalidCredentials
+	}
+
+	claims, err := s.verifier.Verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCredentials, err)
+	}
+
+	customerCodes, err := tokenCustomerCodes(claims.Raw[customerCodesClaim])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidCredentials, err)
+	}
+
+	return &domain.Principal{
+		Subject:       claims.Subject,
+		Method:        domain.AuthMethodJWT,
+		Scopes:        claims.Scopes,
+		CustomerCodes: customerCodes,
+	}, nil
+}
+
+// CreateAPIKey generates a random key and stores its hash with the granted scopes
+// and the customer codes it is bound to
+func (s *AuthService) CreateAPIKey(ctx context.Context, name string, scopes []string, customerCodes []int) (string, error) {
+	for _, scope := range scopes {
+		if !slices.Contains(domain.KnownScopes, scope) {
+			return "", fmt.Errorf("%w: %q", domain.ErrUnknownScope, scope)
+		}
+	}
+
+	codes := make([]int32, 0, len(customerCodes))
+	for _, code := range customerCodes {
+		if code < 1 || code > math.MaxInt32 {
+			return "", fmt.Errorf("invalid customer code %d", code)
+		}
+		codes = append(codes, int32(code))
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", fmt.Errorf("failed to generate API key: %w", err)
+	}
+	key := apiKeyPrefix + base64.RawURLEncoding.EncodeToString(secret)
+
+	_, err := s.queries.CreateAPIKey(ctx, database.CreateAPIKeyParams{
+		Name:          name,
+		KeyPrefix:     key[:apiKeyDisplayLength],
+		KeyHash:       hashAPIKey(key),
+		Scopes:        scopes,
+		CustomerCodes: codes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to store API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// tokenCustomerCodes reads the customer binding claim of a token, which is
+// optional and may hold one code or a list of them. A claim that is present but
+// empty or holds an invalid code is an error: ignoring it would leave the token
+// bound to no customer, which means unrestricted.
+func tokenCustomerCodes(claim json.RawMessage) ([]int, error) {
+	if len(claim) == 0 {
+		return nil, nil
+	}
+
+	var codes []int
+	var single int
+	if err := json.Unmarshal(claim, &single); err == nil {
+		codes = []int{single}
+	} else if err := json.Unmarshal(claim, &codes); err != nil {
+		return nil, fmt.Errorf("invalid %s claim", customerCodesClaim)
+	}
+
+	if len(codes) == 0 {
+		return nil, fmt.Errorf("empty %s claim", customerCodesClaim)
+	}
+	for _, code := range codes {
+		if code < 1 || code > math.MaxInt32 {
+			return nil, fmt.Errorf("invalid customer code %d in %s claim", code, customerCodesClaim)
+		}
+	}
+	return codes, nil
+}
+
+// hashAPIKey returns the hex SHA-256 of a key. Keys carry 256 bits of entropy,
+// so a fast unsalted hash is enough to make a leaked table useless.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
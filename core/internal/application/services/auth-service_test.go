@@ -0,0 +1,118 @@
+package services
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/jwt"
+)
+
+// newTokenSigner returns an AuthService trusting a fresh P-256 key and a function
+// signing the given customer claim with it. A nil claim leaves it out of the token.
+func newTokenSigner(t *testing.T) (*AuthService, func(customerCodes any) string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := jwt.ParseKeySet(fmt.Appendf(nil, `{"keys":[{"kty":"EC","kid":"k1","use":"sig","crv":"P-256","x":%q,"y":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	service := &AuthService{verifier: jwt.NewVerifier(keys, "https://issuer.example", "btg-core-api", time.Minute)}
+
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	sign := func(customerCodes any) string {
+		claims := map[string]any{
+			"sub":   "client-42",
+			"iss":   "https://issuer.example",
+			"aud":   "btg-core-api",
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"scope": "orders:read",
+		}
+		if customerCodes != nil {
+			claims[customerCodesClaim] = customerCodes
+		}
+
+		input := encode(map[string]any{"alg": "ES256", "kid": "k1"}) + "." + encode(claims)
+		digest := sha256.Sum256([]byte(input))
+		r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+		return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+	}
+
+	return service, sign
+}
+
+func TestAuthenticateTokenCustomerBinding(t *testing.T) {
+	service, sign := newTokenSigner(t)
+
+	tests := []struct {
+		name    string
+		claim   any
+		want    []int
+		wantErr bool
+	}{
+		{name: "absent", claim: nil},
+		{name: "single code", claim: 42, want: []int{42}},
+		{name: "list of codes", claim: []int{1, 2}, want: []int{1, 2}},
+		{name: "empty list", claim: []int{}, wantErr: true},
+		{name: "zero", claim: 0, wantErr: true},
+		{name: "negative code in a list", claim: []int{1, -2}, wantErr: true},
+		{name: "beyond 32 bits", claim: int64(4294967297), wantErr: true},
+		{name: "string", claim: "42", wantErr: true},
+		{name: "object", claim: map[string]int{"code": 42}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			principal, err := service.AuthenticateToken(t.Context(), sign(tt.claim))
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrInvalidCredentials) {
+					t.Fatalf("expected ErrInvalidCredentials, got principal %+v, err %v", principal, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(principal.CustomerCodes, tt.want) {
+				t.Errorf("customer codes = %v, want %v", principal.CustomerCodes, tt.want)
+			}
+			if principal.IsCustomerBound() != (len(tt.want) > 0) {
+				t.Errorf("IsCustomerBound() = %v for codes %v", principal.IsCustomerBound(), tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenCustomerCodesRejectsNull(t *testing.T) {
+	if codes, err := tokenCustomerCodes(json.RawMessage("null")); err == nil {
+		t.Fatalf("null claim read as %v", codes)
+	}
+}
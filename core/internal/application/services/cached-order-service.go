@@ -0,0 +1,139 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/cache"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+)
+
+var (
+	_ ports.OrderService          = (*CachedOrderService)(nil)
+	_ ports.OrderCacheInvalidator = (*CachedOrderService)(nil)
+)
+
+// totalsKey identifies cached totals of an order in a reporting currency
+type totalsKey struct {
+	orderCode int32
+	currency  string
+}
+
+// CachedOrderService serves the OrderService read methods from in-process LRU caches.
+// Writes go straight to the wrapped service; entries are dropped when the consumer
+// notifies a committed change, with the TTL bounding staleness if a notification is lost.
+// Cached values are shared between requests and must not be modified by callers.
+type CachedOrderService struct {
+	ports.OrderService
+
+	totals         *cache.LRU[totalsKey, *domain.OrderTotals]
+	orders         *cache.LRU[int32, *domain.Order]
+	customerOrders *cache.LRU[int32, []*domain.Order]
+	customerStats  *cache.LRU[int32, *domain.CustomerStats]
+}
+
+// NewCachedOrderService wraps an OrderService with caches of size entries each kept for ttl
+func NewCachedOrderService(next ports.OrderService, size int, ttl time.Duration) *CachedOrderService {
+	return &CachedOrderService{
+		OrderService:   next,
+		totals:         cache.NewLRU[totalsKey, *domain.OrderTotals]("order_totals", size, ttl),
+		orders:         cache.NewLRU[int32, *domain.Order]("orders", size, ttl),
+		customerOrders: cache.NewLRU[int32, []*domain.Order]("customer_orders", size, ttl),
+		customerStats:  cache.NewLRU[int32, *domain.CustomerStats]("customer_stats", size, ttl),
+	}
+}
+
+// GetOrderTotal retrieves the totals of an order, cached per reporting currency
+func (s *CachedOrderService) GetOrderTotal(ctx context.Context, orderCode int32, currency string) (*domain.OrderTotals, error) {
+	key := totalsKey{orderCode: orderCode, currency: currency}
+	if totals, ok := s.totals.Get(key); ok {
+		return totals, nil
+	}
+
+	totals, err := s.OrderService.GetOrderTotal(ctx, orderCode, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	s.totals.Add(key, totals)
+	return totals, nil
+}
+
+// GetOrderByCode retrieves an order by its code. Missing orders are not cached.
+func (s *CachedOrderService) GetOrderByCode(ctx context.Context, orderCode int32) (*domain.Order, error) {
+	if order, ok := s.orders.Get(orderCode); ok {
+		return order, nil
+	}
+
+	order, err := s.OrderService.GetOrderByCode(ctx, orderCode)
+	if err != nil {
+		return nil, err
+	}
+
+	s.orders.Add(orderCode, order)
+	return order, nil
+}
+
+// GetOrdersByCustomer retrieves all orders for a customer
+func (s *CachedOrderService) GetOrdersByCustomer(ctx context.Context, customerCode int32) ([]*domain.Order, error) {
+	if orders, ok := s.customerOrders.Get(customerCode); ok {
+		return orders, nil
+	}
+
+	orders, err := s.OrderService.GetOrdersByCustomer(ctx, customerCode)
+	if err != nil {
+		return nil, err
+	}
+
+	s.customerOrders.Add(customerCode, orders)
+	return orders, nil
+}
+
+// CountOrdersByCustomer counts the orders of a customer from its cached stats
+func (s *CachedOrderService) CountOrdersByCustomer(ctx context.Context, customerCode int32) (int64, error) {
+	stats, err := s.GetCustomerStats(ctx, customerCode)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(stats.OrderCount), nil
+}
+
+// GetCustomerStats retrieves the order aggregates of a customer
+func (s *CachedOrderService) GetCustomerStats(ctx context.Context, customerCode int32) (*domain.CustomerStats, error) {
+	if stats, ok := s.customerStats.Get(customerCode); ok {
+		return stats, nil
+	}
+
+	stats, err := s.OrderService.GetCustomerStats(ctx, customerCode)
+	if err != nil {
+		return nil, err
+	}
+
+	s.customerStats.Add(customerCode, stats)
+	return stats, nil
+}
+
+// InvalidateOrderChange drops the cached order, its totals in every currency and the
+// cached reads of its customer. A change with All set purges every cache.
+func (s *CachedOrderService) InvalidateOrderChange(change domain.OrderChange) {
+	if change.All {
+		s.totals.Purge()
+		s.orders.Purge()
+		s.customerOrders.Purge()
+		s.customerStats.Purge()
+		return
+	}
+
+	if change.OrderCode != 0 {
+		orderCode := int32(change.OrderCode)
+		s.orders.Remove(orderCode)
+		s.totals.RemoveFunc(func(key totalsKey) bool { return key.orderCode == orderCode })
+	}
+
+	if change.CustomerCode != 0 {
+		s.customerOrders.Remove(int32(change.CustomerCode))
+		s.customerStats.Remove(int32(change.CustomerCode))
+	}
+}
@@ -0,0 +1,138 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CustomerService handles the registry of customers that place orders
+type CustomerService struct {
+	queries         *db.Store
+	requireCustomer bool
+}
+
+// NewCustomerService creates a new CustomerService with dependency injection.
+// When requireCustomer is set, orders for unregistered customers are rejected.
+func NewCustomerService(queries *db.Store, requireCustomer bool) ports.CustomerService {
+	return &CustomerService{
+		queries:         queries,
+		requireCustomer: requireCustomer,
+	}
+}
+
+// CreateCustomer registers a customer. A taken code or document is reported as ErrCustomerExists.
+func (s *CustomerService) CreateCustomer(ctx context.Context, customer *domain.Customer) (*domain.Customer, error) {
+	dbCustomer, err := s.queries.CreateCustomer(ctx, database.CreateCustomerParams{
+		Code:         int32(customer.Code),
+		Name:         customer.Name,
+		Email:        customer.Email,
+		Document:     customer.Document,
+		DocumentType: string(customer.DocumentType),
+		Status:       string(customer.Status),
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return nil, domain.ErrCustomerExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToCustomerDomain(dbCustomer), nil
+}
+
+// GetCustomer retrieves a customer by its code
+func (s *CustomerService) GetCustomer(ctx context.Context, code int32) (*domain.Customer, error) {
+	dbCustomer, err := s.queries.GetCustomerByCode(ctx, code)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrCustomerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToCustomerDomain(dbCustomer), nil
+}
+
+// ListCustomers retrieves every registered customer ordered by code
+func (s *CustomerService) ListCustomers(ctx context.Context) ([]*domain.Customer, error) {
+	dbCustomers, err := s.queries.ListCustomers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	customers := make([]*domain.Customer, 0, len(dbCustomers))
+	for _, dbCustomer := range dbCustomers {
+		customers = append(customers, convertToCustomerDomain(dbCustomer))
+	}
+
+	return customers, nil
+}
+
+// UpdateCustomer replaces the name, email, document and status of a customer.
+// A document already registered to another customer is reported as ErrCustomerExists.
+func (s *CustomerService) UpdateCustomer(ctx context.Context, customer *domain.Customer) (*domain.Customer, error) {
+	dbCustomer, err := s.queries.UpdateCustomer(ctx, database.UpdateCustomerParams{
+		Code:         int32(customer.Code),
+		Name:         customer.Name,
+		Email:        customer.Email,
+		Document:     customer.Document,
+		DocumentType: string(customer.DocumentType),
+		Status:       string(customer.Status),
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return nil, domain.ErrCustomerExists
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrCustomerNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToCustomerDomain(dbCustomer), nil
+}
+
+// CheckCanOrder rejects blocked customers with ErrCustomerBlocked. Unregistered customers
+// are only rejected, with ErrUnknownCustomer, when registration is required.
+func (s *CustomerService) CheckCanOrder(ctx context.Context, code int32) error {
+	customer, err := s.GetCustomer(ctx, code)
+	if errors.Is(err, domain.ErrCustomerNotFound) {
+		if s.requireCustomer {
+			return domain.ErrUnknownCustomer
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if customer.IsBlocked() {
+		return domain.ErrCustomerBlocked
+	}
+	return nil
+}
+
+// convertToCustomerDomain maps a stored customer row to the domain
+func convertToCustomerDomain(dbCustomer database.Customer) *domain.Customer {
+	return &domain.Customer{
+		Code:         int(dbCustomer.Code),
+		Name:         dbCustomer.Name,
+		Email:        dbCustomer.Email,
+		Document:     dbCustomer.Document,
+		DocumentType: domain.DocumentType(dbCustomer.DocumentType),
+		Status:       domain.CustomerStatus(dbCustomer.Status),
+		CreatedAt:    dbCustomer.CreatedAt.Time,
+		UpdatedAt:    dbCustomer.UpdatedAt.Time,
+	}
+}
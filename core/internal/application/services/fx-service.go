@@ -0,0 +1,223 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// fxRateReader looks up the latest stored rate of a pair effective at a date
+type fxRateReader interface {
+	GetEffectiveFxRate(ctx context.Context, arg database.GetEffectiveFxRateParams) (database.FxRate, error)
+}
+
+// FxService handles exchange rates used to report orders in another currency
+type FxService struct {
+	queries *db.Store
+	rates   fxRateReader
+}
+
+// NewFxService creates a new FxService with dependency injection
+func NewFxService(queries *db.Store) ports.FxService {
+	return &FxService{queries: queries, rates: queries}
+}
+
+// ImportRates validates every rate and then stores them in one transaction.
+// A rate for an existing pair and effective date replaces the stored one.
+func (s *FxService) ImportRates(ctx context.Context, rates []domain.ExchangeRate) (int, error) {
+	for i := range rates {
+		if err := rates[i].Validate(); err != nil {
+			return 0, fmt.Errorf("rate %d: %w", i+1, err)
+		}
+	}
+
+	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+
+	for _, rate := range rates {
+		err := queries.UpsertFxRate(ctx, database.UpsertFxRateParams{
+			BaseCurrency:  rate.Base,
+			QuoteCurrency: rate.Quote,
+			Rate:          db.NumericFromRate(rate.Rate),
+			EffectiveDate: pgtype.Date{Time: rate.EffectiveDate, Valid: true},
+		})
+		if err != nil {
+			return 0, fmt.Errorf("error storing %s/%s rate: %w", rate.Base, rate.Quote, err)
+		}
+	}
+
+	// Converted totals cached by any replica may now use a different rate
+	if err := notifyOrderChange(ctx, queries, domain.OrderChange{All: true}); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return len(rates), nil
+}
+
+// GetRate returns the rate converting from into to that is effective on the given date.
+// The candidates are the stored rate for the pair, the inverse of the opposite pair and
+// a cross through PivotCurrency, effective from the older of its two legs; the one
+// effective most recently wins, so a stale rate never hides a fresher one. On a tie
+// the stored pair beats its inverse, which beats the cross.
+func (s *FxService) GetRate(ctx context.Context, from, to string, on time.Time) (*domain.ExchangeRate, error) {
+	if from == to {
+		return &domain.ExchangeRate{Base: from, Quote: to, Rate: domain.OneRate(), EffectiveDate: on}, nil
+	}
+
+	rate, err := s.pairRate(ctx, from, to, on)
+	if err != nil && !errors.Is(err, domain.ErrFxRateNotFound) {
+		return nil, err
+	}
+	if from == domain.PivotCurrency || to == domain.PivotCurrency {
+		return rate, err
+	}
+
+	cross, crossErr := s.crossRate(ctx, from, to, on)
+	if crossErr != nil && !errors.Is(crossErr, domain.ErrFxRateNotFound) {
+		return nil, crossErr
+	}
+
+	switch {
+	case rate == nil && cross == nil:
+		return nil, err
+	case rate == nil:
+		return cross, nil
+	case cross == nil:
+		return rate, nil
+	}
+	return latestRate(rate, cross), nil
+}
+
+// pairRate looks up base/quote and the inverse of quote/base, keeping the one effective
+// most recently
+func (s *FxService) pairRate(ctx context.Context, base, quote string, on time.Time) (*domain.ExchangeRate, error) {
+	direct, err := s.storedRate(ctx, base, quote, on)
+	if err != nil && !errors.Is(err, domain.ErrFxRateNotFound) {
+		return nil, err
+	}
+
+	stored, inverseErr := s.storedRate(ctx, quote, base, on)
+	if inverseErr != nil && !errors.Is(inverseErr, domain.ErrFxRateNotFound) {
+		return nil, inverseErr
+	}
+
+	var inverse *domain.ExchangeRate
+	if stored != nil {
+		inverse = &domain.ExchangeRate{
+			Base:          base,
+			Quote:         quote,
+			Rate:          stored.Rate.Invert(),
+			EffectiveDate: stored.EffectiveDate,
+		}
+	}
+
+	switch {
+	case direct == nil && inverse == nil:
+		return nil, err
+	case direct == nil:
+		return inverse, nil
+	case inverse == nil:
+		return direct, nil
+	}
+	return latestRate(direct, inverse), nil
+}
+
+// crossRate chains from/PivotCurrency and PivotCurrency/to
+func (s *FxService) crossRate(ctx context.Context, from, to string, on time.Time) (*domain.ExchangeRate, error) {
+	toPivot, err := s.pairRate(ctx, from, domain.PivotCurrency, on)
+	if err != nil {
+		return nil, err
+	}
+	fromPivot, err := s.pairRate(ctx, domain.PivotCurrency, to, on)
+	if err != nil {
+		return nil, err
+	}
+
+	effective := toPivot.EffectiveDate
+	if fromPivot.EffectiveDate.Before(effective) {
+		effective = fromPivot.EffectiveDate
+	}
+
+	return &domain.ExchangeRate{
+		Base:          from,
+		Quote:         to,
+		Rate:          toPivot.Rate.Mul(fromPivot.Rate),
+		EffectiveDate: effective,
+	}, nil
+}
+
+// latestRate returns the candidate effective most recently, preferring the first on a tie
+func latestRate(preferred, other *domain.ExchangeRate) *domain.ExchangeRate {
+	if other.EffectiveDate.After(preferred.EffectiveDate) {
+		return other
+	}
+	return preferred
+}
+
+func (s *FxService) storedRate(ctx context.Context, base, quote string, on time.Time) (*domain.ExchangeRate, error) {
+	dbRate, err := s.rates.GetEffectiveFxRate(ctx, database.GetEffectiveFxRateParams{
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		EffectiveDate: pgtype.Date{Time: on, Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		date := on.Format(time.DateOnly)
+		return nil, &domain.ProblemError{Err: domain.ErrFxRateNotFound, Problems: []domain.Problem{{
+			Key:     domain.ProblemFxRateNotFound,
+			Params:  map[string]string{"base": base, "quote": quote, "date": date},
+			Message: fmt.Sprintf("no %s/%s rate effective on %s", base, quote, date),
+		}}}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := db.RateFromNumeric(dbRate.Rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.ExchangeRate{
+		Base:          dbRate.BaseCurrency,
+		Quote:         dbRate.QuoteCurrency,
+		Rate:          rate,
+		EffectiveDate: dbRate.EffectiveDate.Time,
+	}, nil
+}
+
+// notifyOrderChange announces a change on domain.OrdersChannel using the caller's
+// transaction. Postgres delivers the notification only if the transaction commits.
+func notifyOrderChange(ctx context.Context, queries *database.Queries, change domain.OrderChange) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("error marshalling order change: %w", err)
+	}
+
+	err = queries.NotifyOrderChange(ctx, database.NotifyOrderChangeParams{
+		Channel: domain.OrdersChannel,
+		Payload: string(payload),
+	})
+	if err != nil {
+		return fmt.Errorf("error notifying order change: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// storedFxRate is a rate the fake reader holds for a pair
+type storedFxRate struct {
+	base, quote string
+	rate        string
+	effective   string
+}
+
+// fakeFxRates answers lookups like the database: the latest rate of the pair
+// effective on or before the date
+type fakeFxRates []storedFxRate
+
+func (f fakeFxRates) GetEffectiveFxRate(ctx context.Context, arg database.GetEffectiveFxRateParams) (database.FxRate, error) {
+	var found *storedFxRate
+	var foundDate time.Time
+	for i, stored := range f {
+		effective, _ := time.Parse(time.DateOnly, stored.effective)
+		if stored.base != arg.BaseCurrency || stored.quote != arg.QuoteCurrency || effective.After(arg.EffectiveDate.Time) {
+			continue
+		}
+		if found == nil || effective.After(foundDate) {
+			found, foundDate = &f[i], effective
+		}
+	}
+	if found == nil {
+		return database.FxRate{}, pgx.ErrNoRows
+	}
+
+	rate, err := domain.ParseRate(found.rate)
+	if err != nil {
+		return database.FxRate{}, err
+	}
+	return database.FxRate{
+		BaseCurrency:  found.base,
+		QuoteCurrency: found.quote,
+		Rate:          db.NumericFromRate(rate),
+		EffectiveDate: pgtype.Date{Time: foundDate, Valid: true},
+	}, nil
+}
+
+func TestFxServiceGetRate(t *testing.T) {
+	tests := []struct {
+		name          string
+		rates         fakeFxRates
+		from, to      string
+		wantRate      string
+		wantEffective string
+		wantNotFound  bool
+	}{
+		{
+			name:  "inverse of the opposite pair",
+			rates: fakeFxRates{{base: "BRL", quote: "USD", rate: "0.2", effective: "2025-01-10"}},
+			from:  "USD", to: "BRL",
+			wantRate: "5", wantEffective: "2025-01-10",
+		},
+		{
+			name: "fresher inverse beats a stale direct rate",
+			rates: fakeFxRates{
+				{base: "USD", quote: "BRL", rate: "5", effective: "2025-01-01"},
+				{base: "BRL", quote: "USD", rate: "0.25", effective: "2025-01-10"},
+			},
+			from: "USD", to: "BRL",
+			wantRate: "4", wantEffective: "2025-01-10",
+		},
+		{
+			name: "direct rate wins a tie with the inverse",
+			rates: fakeFxRates{
+				{base: "USD", quote: "BRL", rate: "5", effective: "2025-01-10"},
+				{base: "BRL", quote: "USD", rate: "0.25", effective: "2025-01-10"},
+			},
+			from: "USD", to: "BRL",
+			wantRate: "5", wantEffective: "2025-01-10",
+		},
+		{
+			name: "cross through the pivot",
+			rates: fakeFxRates{
+				{base: "USD", quote: "BRL", rate: "5", effective: "2025-01-10"},
+				{base: "EUR", quote: "BRL", rate: "6", effective: "2025-01-12"},
+			},
+			from: "USD", to: "EUR",
+			wantRate: "0.8333333335", wantEffective: "2025-01-10",
+		},
+		{
+			name: "fresher cross beats a stale direct rate",
+			rates: fakeFxRates{
+				{base: "USD", quote: "EUR", rate: "0.9", effective: "2025-01-01"},
+				{base: "USD", quote: "BRL", rate: "5", effective: "2025-01-10"},
+				{base: "BRL", quote: "EUR", rate: "0.2", effective: "2025-01-12"},
+			},
+			from: "USD", to: "EUR",
+			wantRate: "1", wantEffective: "2025-01-10",
+		},
+		{
+			name: "cross is as stale as its older leg",
+			rates: fakeFxRates{
+				{base: "USD", quote: "EUR", rate: "0.9", effective: "2025-01-05"},
+				{base: "USD", quote: "BRL", rate: "5", effective: "2025-01-01"},
+				{base: "BRL", quote: "EUR", rate: "0.2", effective: "2025-01-12"},
+			},
+			from: "USD", to: "EUR",
+			wantRate: "0.9", wantEffective: "2025-01-05",
+		},
+		{
+			name:  "rates effective after the date are ignored",
+			rates: fakeFxRates{{base: "USD", quote: "BRL", rate: "5", effective: "2025-02-01"}},
+			from:  "USD", to: "BRL",
+			wantNotFound: true,
+		},
+	}
+
+	on := time.Date(2025, time.January, 15, 0, 0, 0, 0, time.UTC)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &FxService{rates: tt.rates}
+
+			rate, err := service.GetRate(context.Background(), tt.from, tt.to, on)
+			if tt.wantNotFound {
+				if !errors.Is(err, domain.ErrFxRateNotFound) {
+					t.Fatalf("expected ErrFxRateNotFound, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want, _ := domain.ParseRate(tt.wantRate)
+			if rate.Rate.String() != want.String() {
+				t.Errorf("rate = %s, want %s", rate.Rate, want)
+			}
+			if got := rate.EffectiveDate.Format(time.DateOnly); got != tt.wantEffective {
+				t.Errorf("effective date = %s, want %s", got, tt.wantEffective)
+			}
+			if rate.Base != tt.from || rate.Quote != tt.to {
+				t.Errorf("pair = %s/%s, want %s/%s", rate.Base, rate.Quote, tt.from, tt.to)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+)
+
+// HealthService checks whether the instance can serve traffic
+type HealthService struct {
+	queries                *db.Store
+	publisher              ports.MessagePublisher
+	timeout                time.Duration
+	outboxBacklogThreshold int64
+}
+
+// NewHealthService creates a HealthService giving each check up to timeout.
+// A zero outboxBacklogThreshold skips the outbox check.
+func NewHealthService(queries *db.Store, publisher ports.MessagePublisher, timeout time.Duration, outboxBacklogThreshold int) ports.HealthService {
+	return &HealthService{
+		queries:                queries,
+		publisher:              publisher,
+		timeout:                timeout,
+		outboxBacklogThreshold: int64(outboxBacklogThreshold),
+	}
+}
+
+// healthCheck is one dependency the readiness report covers
+type healthCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// Readiness runs every check concurrently, so a hanging dependency delays the
+// report by at most the timeout. The instance is down when any component is.
+func (s *HealthService) Readiness(ctx context.Context) domain.HealthReport {
+	checks := []healthCheck{
+		{name: "database", check: s.checkDatabase},
+		{name: "rabbitmq", check: s.publisher.CheckHealth},
+	}
+	if s.outboxBacklogThreshold > 0 {
+		checks = append(checks, healthCheck{name: "outbox", check: s.checkOutboxBacklog})
+	}
+
+	components := make([]domain.ComponentHealth, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Go(func() {
+			components[i] = s.run(ctx, c)
+		})
+	}
+	wg.Wait()
+
+	report := domain.HealthReport{Status: domain.HealthUp, Components: components}
+	for _, component := range components {
+		if component.Status != domain.HealthUp {
+			report.Status = domain.HealthDown
+		}
+	}
+	return report
+}
+
+// run times a single check under the configured timeout
+func (s *HealthService) run(ctx context.Context, c healthCheck) domain.ComponentHealth {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.check(ctx)
+
+	component := domain.ComponentHealth{
+		Name:      c.name,
+		Status:    domain.HealthUp,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		component.Status = domain.HealthDown
+		component.Error = err.Error()
+	}
+	return component
+}
+
+// checkDatabase pings the pool, which also proves a connection can be acquired
+func (s *HealthService) checkDatabase(ctx context.Context) error {
+	return s.queries.Pool.Ping(ctx)
+}
+
+// checkOutboxBacklog fails when the consumer's outbox has fallen too far behind
+func (s *HealthService) checkOutboxBacklog(ctx context.Context) error {
+	pending, err := s.queries.CountPendingOutboxEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to count pending outbox events: %w", err)
+	}
+	if pending > s.outboxBacklogThreshold {
+		return fmt.Errorf("%d unpublished outbox events exceed the threshold of %d", pending, s.outboxBacklogThreshold)
+	}
+	return nil
+}
@@ -2,11 +2,13 @@ package services
 
 import (
 	"context"
+	"errors"
 
 	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
 )
@@ -20,82 +22,314 @@ type OrderServiceQuerier struct {
 type OrderService struct {
 	queries          *db.Store
 	messagePublisher ports.MessagePublisher
+	fxService        ports.FxService
+	customerService  ports.CustomerService
 }
 
 // NewOrderService creates a new OrderService with dependency injection
-func NewOrderService(queries *db.Store, messagePublisher ports.MessagePublisher) ports.OrderService {
+func NewOrderService(queries *db.Store, messagePublisher ports.MessagePublisher, fxService ports.FxService, customerService ports.CustomerService) ports.OrderService {
 	return &OrderService{
 		queries:          queries,
 		messagePublisher: messagePublisher,
+		fxService:        fxService,
+		customerService:  customerService,
 	}
 }
 
-// GetOrderTotal retrieves the total value of an order by code
-func (s *OrderService) GetOrderTotal(ctx context.Context, orderCode int32) (string, error) {
-	// TODO: Implement business logic
-	// Example:
-	// total, err := s.queries.GetTotalByOrderCode(ctx, orderCode)
-	// if err != nil {
-	//     return "", err
-	// }
-	// return total, nil
-	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+// GetOrderTotal retrieves the gross, refunded and net value of an order by code.
+// An empty currency keeps the order's own currency.
+func (s *OrderService) GetOrderTotal(ctx context.Context, orderCode int32, currency string) (*domain.OrderTotals, error) {
+	order, err := s.GetOrderByCode(ctx, orderCode)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
-	s.queries.WithTx(tx)
-	return "", nil
+
+	return s.ConvertTotals(ctx, order, currency)
+}
+
+// ConvertTotals computes the totals of an order in the given currency, or its own when empty.
+// Conversion uses the rate effective on the order date and rounds each component half-even to cents;
+// the converted gross is then recomposed from them so the breakdown always adds up.
+func (s *OrderService) ConvertTotals(ctx context.Context, order *domain.Order, currency string) (*domain.OrderTotals, error) {
+	subtotal, discounts, shipping, tax := order.Subtotal, order.Discounts, order.Shipping, order.Tax
+	gross, refunded := order.Total, order.RefundedTotal
+
+	// Cancelled orders no longer count towards anything
+	if order.Status == domain.OrderStatusCancelled {
+		subtotal, discounts, shipping, tax = domain.Money{}, domain.Money{}, domain.Money{}, domain.Money{}
+		gross, refunded = domain.Money{}, domain.Money{}
+	}
+
+	totals := &domain.OrderTotals{Currency: order.Currency}
+
+	if currency != "" && currency != order.Currency {
+		rate, err := s.fxService.GetRate(ctx, order.Currency, currency, order.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		subtotal, discounts = subtotal.Convert(rate.Rate, 2), discounts.Convert(rate.Rate, 2)
+		shipping, tax = shipping.Convert(rate.Rate, 2), tax.Convert(rate.Rate, 2)
+		gross = subtotal.Sub(discounts).Add(shipping).Add(tax)
+		refunded = refunded.Convert(rate.Rate, 2)
+		totals.Currency = currency
+		totals.Rate = rate
+	}
+
+	totals.Subtotal = subtotal.StringFixed(2)
+	totals.Discounts = discounts.StringFixed(2)
+	totals.Shipping = shipping.StringFixed(2)
+	totals.Tax = tax.StringFixed(2)
+	totals.Gross = gross.StringFixed(2)
+	totals.Refunded = refunded.StringFixed(2)
+	totals.Net = gross.Sub(refunded).StringFixed(2)
+
+	return totals, nil
 }
 
 // GetOrderByCode retrieves an order by its code
 func (s *OrderService) GetOrderByCode(ctx context.Context, orderCode int32) (*domain.Order, error) {
-	// TODO: Implement business logic
-	// Example:
-	// dbOrder, err := s.queries.GetOrderByCode(ctx, orderCode)
-	// if err != nil {
-	//     return nil, err
-	// }
-	// return convertToOrderDomain(dbOrder), nil
-	return nil, nil
+	dbOrder, err := s.queries.GetOrderByCode(ctx, orderCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToOrderDomain(dbOrder)
 }
 
 // GetOrdersByCustomer retrieves all orders for a customer
 func (s *OrderService) GetOrdersByCustomer(ctx context.Context, customerCode int32) ([]*domain.Order, error) {
-	// TODO: Implement business logic
-	// Example:
-	// dbOrders, err := s.queries.GetOrdersByCustomerCode(ctx, customerCode)
-	// if err != nil {
-	//     return nil, err
-	// }
-	// return convertToOrdersDomain(dbOrders), nil
-	return nil, nil
+	dbOrders, err := s.queries.GetOrdersByCustomerCode(ctx, customerCode)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*domain.Order, 0, len(dbOrders))
+	for _, dbOrder := range dbOrders {
+		order, err := convertToOrderDomain(dbOrder)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	return orders, nil
 }
 
-// CountOrdersByCustomer counts the number of orders for a customer
+// CountOrdersByCustomer counts the non-cancelled orders of a customer from the customer_stats read model
 func (s *OrderService) CountOrdersByCustomer(ctx context.Context, customerCode int32) (int64, error) {
-	// TODO: Implement business logic
-	// Example:
-	// count, err := s.queries.CountOrdersByCustomer(ctx, customerCode)
-	// if err != nil {
-	//     return 0, err
-	// }
-	// return count, nil
-	return 0, nil
+	stats, err := s.GetCustomerStats(ctx, customerCode)
+	if err != nil {
+		return 0, err
+	}
+
+	return int64(stats.OrderCount), nil
 }
 
-// CreateOrder creates a new order with items
+// GetCustomerStats retrieves the order aggregates of a customer from the customer_stats read model.
+// Customers without processed orders get empty stats.
+func (s *OrderService) GetCustomerStats(ctx context.Context, customerCode int32) (*domain.CustomerStats, error) {
+	stats := &domain.CustomerStats{CustomerCode: int(customerCode)}
+
+	dbStats, err := s.queries.GetCustomerStats(ctx, customerCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return stats, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	stats.OrderCount = int(dbStats.OrderCount)
+	stats.TopProduct = dbStats.TopProduct
+	stats.UpdatedAt = dbStats.UpdatedAt.Time
+	if dbStats.LastOrderAt.Valid {
+		stats.LastOrderAt = &dbStats.LastOrderAt.Time
+	}
+
+	dbValues, err := s.queries.GetCustomerLifetimeValues(ctx, customerCode)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, dbValue := range dbValues {
+		value, err := db.MoneyFromNumeric(dbValue.LifetimeValue)
+		if err != nil {
+			return nil, err
+		}
+		stats.LifetimeValues = append(stats.LifetimeValues, domain.LifetimeValue{
+			Currency: dbValue.Currency,
+			Value:    value,
+		})
+	}
+
+	return stats, nil
+}
+
+// CreateOrder checks the customer may place orders and publishes the new order with its items
 func (s *OrderService) CreateOrder(ctx context.Context, order *domain.Order) error {
+	if err := s.customerService.CheckCanOrder(ctx, int32(order.CustomerCode)); err != nil {
+		return err
+	}
+
 	return s.messagePublisher.PublishOrder(ctx, order)
 }
 
+// CancelOrder checks the order can still be cancelled and publishes the request.
+// The consumer applies it; an already cancelled order is reported as ErrOrderCancelled.
+func (s *OrderService) CancelOrder(ctx context.Context, cancellation *domain.OrderCancellation) error {
+	order, err := s.GetOrderByCode(ctx, int32(cancellation.OrderCode))
+	if err != nil {
+		return err
+	}
+
+	if order.Status == domain.OrderStatusCancelled {
+		return domain.ErrOrderCancelled
+	}
+	if err := order.CheckVersion(cancellation.ExpectedVersion); err != nil {
+		return err
+	}
+
+	cancellation.CustomerCode = order.CustomerCode
+	return s.messagePublisher.PublishCancellation(ctx, cancellation)
+}
+
+// AmendOrder checks the order can still be amended and publishes the request.
+// Item-level rules are checked by the consumer against the version it applies to.
+func (s *OrderService) AmendOrder(ctx context.Context, amendment *domain.OrderAmendment) error {
+	order, err := s.GetOrderByCode(ctx, int32(amendment.OrderCode))
+	if err != nil {
+		return err
+	}
+
+	if order.Status == domain.OrderStatusCancelled {
+		return domain.ErrOrderCancelled
+	}
+	if err := order.CheckVersion(amendment.ExpectedVersion); err != nil {
+		return err
+	}
+
+	amendment.CustomerCode = order.CustomerCode
+	return s.messagePublisher.PublishAmendment(ctx, amendment)
+}
+
+// ReturnOrder checks the returned quantities against what was bought and not yet returned,
+// then publishes the return. The consumer repeats the check before storing it.
+func (s *OrderService) ReturnOrder(ctx context.Context, orderReturn *domain.OrderReturn) error {
+	dbOrder, err := s.queries.GetOrderByCode(ctx, int32(orderReturn.OrderCode))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ErrOrderNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if dbOrder.Status == domain.OrderStatusCancelled {
+		return domain.ErrOrderCancelled
+	}
+
+	items, err := s.GetOrderItems(ctx, dbOrder.ID)
+	if err != nil {
+		return err
+	}
+
+	dbReturned, err := s.queries.GetReturnedQuantities(ctx, dbOrder.ID)
+	if err != nil {
+		return err
+	}
+
+	returned := make(map[string]int, len(dbReturned))
+	for _, row := range dbReturned {
+		returned[row.Product] = int(row.Quantity)
+	}
+
+	bought := make([]domain.OrderItem, 0, len(items))
+	for _, item := range items {
+		bought = append(bought, *item)
+	}
+
+	if err := domain.ValidateReturn(orderReturn, bought, returned); err != nil {
+		return err
+	}
+
+	orderReturn.CustomerCode = int(dbOrder.CustomerCode)
+	return s.messagePublisher.PublishReturn(ctx, orderReturn)
+}
+
 // GetOrderItems retrieves all items for an order
 func (s *OrderService) GetOrderItems(ctx context.Context, orderID int64) ([]*domain.OrderItem, error) {
-	// TODO: Implement business logic
-	// Example:
-	// dbItems, err := s.queries.GetOrderItems(ctx, orderID)
-	// if err != nil {
-	//     return nil, err
-	// }
-	// return convertToOrderItemsDomain(dbItems), nil
-	return nil, nil
+	dbItems, err := s.queries.GetOrderItems(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*domain.OrderItem, 0, len(dbItems))
+	for _, dbItem := range dbItems {
+		price, err := db.MoneyFromNumeric(dbItem.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		discount, err := db.MoneyFromNumeric(dbItem.Discount)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, &domain.OrderItem{
+			SKU:          dbItem.Sku,
+			Product:      dbItem.Product,
+			Quantity:     int(dbItem.Quantity),
+			Price:        price,
+			Discount:     discount,
+			PriceFlagged: dbItem.PriceFlagged,
+		})
+	}
+
+	return items, nil
+}
+
+// convertToOrderDomain maps a stored order row, including its persisted total and breakdown, to the domain
+func convertToOrderDomain(dbOrder database.Order) (*domain.Order, error) {
+	numerics := []pgtype.Numeric{
+		dbOrder.Total,
+		dbOrder.RefundedTotal,
+		dbOrder.Subtotal,
+		dbOrder.DiscountTotal,
+		dbOrder.CouponDiscount,
+		dbOrder.Shipping,
+		dbOrder.Tax,
+	}
+
+	amounts := make([]domain.Money, len(numerics))
+	for i, numeric := range numerics {
+		amount, err := db.MoneyFromNumeric(numeric)
+		if err != nil {
+			return nil, err
+		}
+		amounts[i] = amount
+	}
+
+	var coupon *domain.Coupon
+	if dbOrder.CouponCode != "" {
+		coupon = &domain.Coupon{Code: dbOrder.CouponCode, Discount: amounts[4]}
+	}
+
+	return &domain.Order{
+		CustomerCode:  int(dbOrder.CustomerCode),
+		OrderCode:     int64(dbOrder.Code),
+		CreatedAt:     dbOrder.CreatedAt.Time,
+		Total:         amounts[0],
+		ItemCount:     int(dbOrder.ItemCount),
+		Status:        dbOrder.Status,
+		Version:       int(dbOrder.Version),
+		RefundedTotal: amounts[1],
+		Currency:      dbOrder.Currency,
+		Subtotal:      amounts[2],
+		Discounts:     amounts[3],
+		Coupon:        coupon,
+		Shipping:      amounts[5],
+		Tax:           amounts[6],
+	}, nil
 }
@@ -0,0 +1,130 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// uniqueViolation is the PostgreSQL error code raised by a duplicate key
+const uniqueViolation = "23505"
+
+// ProductService handles the product catalog referenced by order items
+type ProductService struct {
+	queries *db.Store
+}
+
+// NewProductService creates a new ProductService with dependency injection
+func NewProductService(queries *db.Store) ports.ProductService {
+	return &ProductService{queries: queries}
+}
+
+// CreateProduct adds a product to the catalog. A taken SKU is reported as ErrProductExists.
+func (s *ProductService) CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	dbProduct, err := s.queries.CreateProduct(ctx, database.CreateProductParams{
+		Sku:       product.SKU,
+		Name:      product.Name,
+		Unit:      product.Unit,
+		ListPrice: db.NumericFromMoney(product.ListPrice),
+		Active:    product.Active,
+	})
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return nil, domain.ErrProductExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToProductDomain(dbProduct)
+}
+
+// GetProduct retrieves a product by its SKU
+func (s *ProductService) GetProduct(ctx context.Context, sku string) (*domain.Product, error) {
+	dbProduct, err := s.queries.GetProductBySKU(ctx, sku)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToProductDomain(dbProduct)
+}
+
+// ListProducts retrieves every product in the catalog ordered by SKU
+func (s *ProductService) ListProducts(ctx context.Context) ([]*domain.Product, error) {
+	dbProducts, err := s.queries.ListProducts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	products := make([]*domain.Product, 0, len(dbProducts))
+	for _, dbProduct := range dbProducts {
+		product, err := convertToProductDomain(dbProduct)
+		if err != nil {
+			return nil, err
+		}
+		products = append(products, product)
+	}
+
+	return products, nil
+}
+
+// UpdateProduct replaces the name, unit, list price and active flag of a product.
+// Orders already stored keep the product name and price they were placed with.
+func (s *ProductService) UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	dbProduct, err := s.queries.UpdateProduct(ctx, database.UpdateProductParams{
+		Sku:       product.SKU,
+		Name:      product.Name,
+		Unit:      product.Unit,
+		ListPrice: db.NumericFromMoney(product.ListPrice),
+		Active:    product.Active,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToProductDomain(dbProduct)
+}
+
+// DeactivateProduct stops a product from being ordered. Products are never deleted
+// because stored order items keep referencing their SKU.
+func (s *ProductService) DeactivateProduct(ctx context.Context, sku string) error {
+	rows, err := s.queries.DeactivateProduct(ctx, sku)
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return domain.ErrProductNotFound
+	}
+	return nil
+}
+
+// convertToProductDomain maps a stored product row to the domain
+func convertToProductDomain(dbProduct database.Product) (*domain.Product, error) {
+	listPrice, err := db.MoneyFromNumeric(dbProduct.ListPrice)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.Product{
+		SKU:       dbProduct.Sku,
+		Name:      dbProduct.Name,
+		Unit:      dbProduct.Unit,
+		ListPrice: listPrice,
+		Active:    dbProduct.Active,
+		CreatedAt: dbProduct.CreatedAt.Time,
+		UpdatedAt: dbProduct.UpdatedAt.Time,
+	}, nil
+}
@@ -4,16 +4,28 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/i18n"
 )
 
 type Config struct {
-	App      AppConfig
-	Server   ServerConfig
-	Database DatabaseConfig
-	RabbitMQ RabbitMQConfig
-	OTel     OTelConfig
+	App       AppConfig
+	Server    ServerConfig
+	Database  DatabaseConfig
+	RabbitMQ  RabbitMQConfig
+	OTel      OTelConfig
+	Orders    OrdersConfig
+	Cache     CacheConfig
+	RateLimit RateLimitConfig
+	Auth      AuthConfig
+	CORS      CORSConfig
+	Health    HealthConfig
+	Debug     DebugConfig
+	Audit     AuditConfig
 }
 
 type AppConfig struct {
@@ -21,6 +33,8 @@ type AppConfig struct {
 	Version  string
 	Env      string
 	LogLevel string
+	// DefaultLanguage answers clients whose Accept-Language matches no supported language
+	DefaultLanguage string
 }
 
 type ServerConfig struct {
@@ -38,12 +52,85 @@ type DatabaseConfig struct {
 }
 
 type RabbitMQConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Exchange string
-	Queue    string
+	Host       string
+	Port       string
+	User       string
+	Password   string
+	Exchange   string
+	Queue      string
+	Partitions int
+}
+
+type OrdersConfig struct {
+	RequireRegisteredCustomer bool
+}
+
+type CacheConfig struct {
+	Enabled bool
+	Size    int
+	TTL     time.Duration
+}
+
+type RateLimitConfig struct {
+	Enabled    bool
+	Default    RateLimit
+	Routes     map[string]RateLimit
+	TrustProxy bool
+}
+
+// RateLimit is a token bucket refilled at RequestsPerSecond and holding at most Burst requests
+type RateLimit struct {
+	RequestsPerSecond float64
+	Burst             int
+}
+
+// AuthConfig controls API authentication. Bearer tokens are only accepted when
+// JWKSFile is set; empty Issuer or Audience are not checked.
+type AuthConfig struct {
+	Enabled     bool
+	JWKSFile    string
+	JWTIssuer   string
+	JWTAudience string
+	JWTLeeway   time.Duration
+}
+
+// CORSConfig lists the browser origins allowed to call the API. Origins are exact
+// ("https://app.example.com"), wildcard subdomains ("https://*.example.com") or "*".
+// CredentialOrigins are written the same way, except "*", and may also send cookies
+// and Authorization headers.
+type CORSConfig struct {
+	AllowedOrigins    []string
+	CredentialOrigins []string
+	AllowedMethods    []string
+	AllowedHeaders    []string
+	ExposedHeaders    []string
+	MaxAge            time.Duration
+}
+
+// HealthConfig tunes the readiness checks. A backlog of unpublished outbox events
+// above OutboxBacklogThreshold marks the instance unready; 0 disables that check.
+type HealthConfig struct {
+	CheckTimeout           time.Duration
+	OutboxBacklogThreshold int
+}
+
+// DebugConfig enables the pprof and diagnostics listener on its own address.
+// Every request must send Token as a bearer token, so it is required when enabled.
+type DebugConfig struct {
+	Enabled bool
+	Addr    string
+	Token   string
+}
+
+// AuditConfig controls the audit log of writes and sensitive reads. The caller's
+// address is taken from X-Forwarded-For only when TrustProxy is set, as for rate limits.
+// Staged entries are chained every ChainInterval; entries the database refuses are
+// kept in SpoolFile until replayed, or lost when it is empty.
+type AuditConfig struct {
+	Enabled       bool
+	TrustProxy    bool
+	ChainInterval time.Duration
+	SpoolFile     string
 }
 
 type OTelConfig struct {
@@ -56,12 +143,42 @@ func Load() (*Config, error) {
 		fmt.Println("Warning: .env file not found, using environment variables")
 	}
 
+	routeLimits, err := parseRateLimits(getEnv("RATE_LIMIT_ROUTES", "POST /api/v1/orders=10:20"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid RATE_LIMIT_ROUTES: %w", err)
+	}
+
+	allowedOrigins, err := parseOrigins(getEnv("CORS_ALLOWED_ORIGINS", "*"), true)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CORS_ALLOWED_ORIGINS: %w", err)
+	}
+	credentialOrigins, err := parseOrigins(getEnv("CORS_CREDENTIALS_ORIGINS", ""), false)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CORS_CREDENTIALS_ORIGINS: %w", err)
+	}
+
+	defaultLanguage, err := i18n.ParseLanguage(getEnv("DEFAULT_LANGUAGE", i18n.English))
+	if err != nil {
+		return nil, fmt.Errorf("invalid DEFAULT_LANGUAGE: %w", err)
+	}
+
+	debug := DebugConfig{
+		Enabled: getEnvBool("DEBUG_ENABLED", false),
+		Addr:    getEnv("DEBUG_ADDR", "localhost:6060"),
+		Token:   getEnv("DEBUG_TOKEN", ""),
+	}
+	if debug.Enabled && debug.Token == "" {
+		return nil, fmt.Errorf("DEBUG_TOKEN is required when DEBUG_ENABLED is set")
+	}
+
 	config := &Config{
 		App: AppConfig{
 			Name:     getEnv("APP_NAME", "btg-core-api"),
 			Version:  getEnv("APP_VERSION", "1.0.0"),
 			Env:      getEnv("APP_ENV", "development"),
 			LogLevel: getEnv("LOG_LEVEL", "info"),
+
+			DefaultLanguage: defaultLanguage,
 		},
 		Server: ServerConfig{
 			Port: getEnv("PORT", "8080"),
@@ -76,17 +193,61 @@ func Load() (*Config, error) {
 			SSLMode:  getEnv("DB_SSL_MODE", "disable"),
 		},
 		RabbitMQ: RabbitMQConfig{
-			Host:     getEnv("RABBITMQ_HOST", "localhost"),
-			Port:     getEnv("RABBITMQ_PORT", "5672"),
-			User:     getEnv("RABBITMQ_USER", "guest"),
-			Password: getEnv("RABBITMQ_PASSWORD", "guest"),
-			Exchange: getEnv("RABBITMQ_EXCHANGE", "orders_exchange"),
-			Queue:    getEnv("RABBITMQ_QUEUE", "orders"),
+			Host:       getEnv("RABBITMQ_HOST", "localhost"),
+			Port:       getEnv("RABBITMQ_PORT", "5672"),
+			User:       getEnv("RABBITMQ_USER", "guest"),
+			Password:   getEnv("RABBITMQ_PASSWORD", "guest"),
+			Exchange:   getEnv("RABBITMQ_EXCHANGE", "orders_exchange"),
+			Queue:      getEnv("RABBITMQ_QUEUE", "orders"),
+			Partitions: getEnvInt("RABBITMQ_PARTITIONS", 1),
 		},
 		OTel: OTelConfig{
 			Enabled:  getEnvBool("OTEL_ENABLED", true),
 			Endpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
 		},
+		Orders: OrdersConfig{
+			RequireRegisteredCustomer: getEnvBool("CUSTOMER_REQUIRED_FOR_ORDERS", false),
+		},
+		Cache: CacheConfig{
+			Enabled: getEnvBool("CACHE_ENABLED", true),
+			Size:    getEnvInt("CACHE_SIZE", 10000),
+			TTL:     getEnvDuration("CACHE_TTL", time.Minute),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled: getEnvBool("RATE_LIMIT_ENABLED", true),
+			Default: RateLimit{
+				RequestsPerSecond: getEnvFloat("RATE_LIMIT_RPS", 50),
+				Burst:             getEnvInt("RATE_LIMIT_BURST", 100),
+			},
+			Routes:     routeLimits,
+			TrustProxy: getEnvBool("RATE_LIMIT_TRUST_PROXY", false),
+		},
+		Auth: AuthConfig{
+			Enabled:     getEnvBool("AUTH_ENABLED", true),
+			JWKSFile:    getEnv("AUTH_JWKS_FILE", ""),
+			JWTIssuer:   getEnv("AUTH_JWT_ISSUER", ""),
+			JWTAudience: getEnv("AUTH_JWT_AUDIENCE", ""),
+			JWTLeeway:   getEnvDuration("AUTH_JWT_LEEWAY", 30*time.Second),
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:    allowedOrigins,
+			CredentialOrigins: credentialOrigins,
+			AllowedMethods:    getEnvList("CORS_ALLOWED_METHODS", "GET, POST, PUT, PATCH, DELETE"),
+			AllowedHeaders:    getEnvList("CORS_ALLOWED_HEADERS", "Content-Type, Authorization, X-API-Key, X-Correlation-Id"),
+			ExposedHeaders:    getEnvList("CORS_EXPOSED_HEADERS", "X-Correlation-Id, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, Retry-After"),
+			MaxAge:            getEnvDuration("CORS_MAX_AGE", 10*time.Minute),
+		},
+		Health: HealthConfig{
+			CheckTimeout:           getEnvDuration("HEALTH_CHECK_TIMEOUT", 2*time.Second),
+			OutboxBacklogThreshold: getEnvInt("HEALTH_OUTBOX_BACKLOG_THRESHOLD", 10000),
+		},
+		Debug: debug,
+		Audit: AuditConfig{
+			Enabled:       getEnvBool("AUDIT_ENABLED", true),
+			TrustProxy:    getEnvBool("AUDIT_TRUST_PROXY", getEnvBool("RATE_LIMIT_TRUST_PROXY", false)),
+			ChainInterval: getEnvDuration("AUDIT_CHAIN_INTERVAL", time.Second),
+			SpoolFile:     getEnv("AUDIT_SPOOL_FILE", "data/audit-spool.jsonl"),
+		},
 	}
 
 	return config, nil
@@ -117,3 +278,113 @@ func getEnvBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
+
+func getEnvFloat(key string, defaultValue float64) float64 {
+	if value := os.Getenv(key); value != "" {
+		if f, err := strconv.ParseFloat(value, 64); err == nil {
+			return f
+		}
+	}
+	return defaultValue
+}
+
+// getEnvList reads a comma separated list, dropping empty entries
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// parseOrigins reads a comma separated list of CORS origins written as
+// "scheme://host[:port]", where host may start with "*." to match any subdomain.
+// "*" matches every origin and is only accepted when allowAny is set.
+func parseOrigins(value string, allowAny bool) ([]string, error) {
+	var origins []string
+
+	for _, origin := range strings.Split(value, ",") {
+		origin = strings.TrimSpace(origin)
+		if origin == "" {
+			continue
+		}
+
+		if origin == "*" {
+			if !allowAny {
+				return nil, fmt.Errorf("%q is not allowed here", origin)
+			}
+			origins = append(origins, origin)
+			continue
+		}
+
+		scheme, host, ok := strings.Cut(origin, "://")
+		if !ok || scheme == "" || host == "" || strings.Contains(host, "/") {
+			return nil, fmt.Errorf("%q: expected scheme://host[:port]", origin)
+		}
+		if strings.Contains(strings.TrimPrefix(host, "*."), "*") {
+			return nil, fmt.Errorf("%q: a wildcard may only replace the leading subdomain", origin)
+		}
+
+		origins = append(origins, strings.ToLower(origin))
+	}
+
+	return origins, nil
+}
+
+// parseRateLimits reads per-route limits written as "METHOD /pattern=rps:burst",
+// separated by semicolons, e.g. "POST /api/v1/orders=10:20;GET /api/v1/orders/{code}/total=100:200"
+func parseRateLimits(value string) (map[string]RateLimit, error) {
+	limits := make(map[string]RateLimit)
+
+	for _, entry := range strings.Split(value, ";") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		route, limit, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q: expected route=rps:burst", entry)
+		}
+		rps, burst, ok := strings.Cut(limit, ":")
+		if !ok {
+			return nil, fmt.Errorf("%q: expected route=rps:burst", entry)
+		}
+
+		requestsPerSecond, err := strconv.ParseFloat(strings.TrimSpace(rps), 64)
+		if err != nil || requestsPerSecond <= 0 {
+			return nil, fmt.Errorf("%q: requests per second must be a positive number", entry)
+		}
+		burstSize, err := strconv.Atoi(strings.TrimSpace(burst))
+		if err != nil || burstSize < 1 {
+			return nil, fmt.Errorf("%q: burst must be a positive integer", entry)
+		}
+
+		limits[strings.Join(strings.Fields(route), " ")] = RateLimit{
+			RequestsPerSecond: requestsPerSecond,
+			Burst:             burstSize,
+		}
+	}
+
+	return limits, nil
+}
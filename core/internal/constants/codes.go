@@ -1,22 +1,114 @@
 package constants
 
 // Error codes used in API responses.
-// These are the machine-readable codes returned in the "error" field.
+// These are the machine-readable codes returned in the "code" field.
 const (
 	// Common error codes
-	CodeInvalidRequest = "INVALID_REQUEST"
-	CodeInternalError  = "INTERNAL_ERROR"
-	CodeForbidden      = "FORBIDDEN"
-	CodeNotFound       = "NOT_FOUND"
+	CodeInvalidRequest   = "INVALID_REQUEST"
+	CodeValidationFailed = "VALIDATION_FAILED"
+	CodeInternalError    = "INTERNAL_ERROR"
+	CodeUnauthorized     = "UNAUTHORIZED"
+	CodeForbidden        = "FORBIDDEN"
+	CodeNotFound         = "NOT_FOUND"
+	CodeMethodNotAllowed = "METHOD_NOT_ALLOWED"
+	CodeRateLimited      = "RATE_LIMITED"
+	CodePayloadTooLarge  = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMedia = "UNSUPPORTED_MEDIA_TYPE"
 
 	// Order-specific codes
 	CodeOrderNotFound       = "ORDER_NOT_FOUND"
 	CodeInvalidOrderCode    = "INVALID_ORDER_CODE"
 	CodeInvalidCustomerCode = "INVALID_CUSTOMER_CODE"
+	CodeOrderCancelled      = "ORDER_CANCELLED"
+	CodeVersionConflict     = "VERSION_CONFLICT"
+	CodeEmptyAmendment      = "EMPTY_AMENDMENT"
+	CodeInvalidReturn       = "INVALID_RETURN"
+	CodeInvalidPricing      = "INVALID_PRICING"
+
+	// FX-specific codes
+	CodeInvalidCurrency = "INVALID_CURRENCY"
+	CodeFxRateNotFound  = "FX_RATE_NOT_FOUND"
+	CodeInvalidFxRate   = "INVALID_FX_RATE"
+
+	// Product-specific codes
+	CodeProductNotFound = "PRODUCT_NOT_FOUND"
+	CodeProductExists   = "PRODUCT_EXISTS"
+	CodeInvalidSKU      = "INVALID_SKU"
+
+	// Customer-specific codes
+	CodeCustomerNotFound = "CUSTOMER_NOT_FOUND"
+	CodeCustomerExists   = "CUSTOMER_EXISTS"
+	CodeCustomerBlocked  = "CUSTOMER_BLOCKED"
+	CodeUnknownCustomer  = "UNKNOWN_CUSTOMER"
+	CodeInvalidDocument  = "INVALID_DOCUMENT"
+
+	// Log level codes
+	CodeInvalidLogLevel  = "INVALID_LOG_LEVEL"
+	CodeLogLevelNotFound = "LOG_LEVEL_NOT_FOUND"
+
+	// Audit codes
+	CodeInvalidAuditFilter = "INVALID_AUDIT_FILTER"
 
 	// Success codes - Order operations
 	CodeOrderCreated = "ORDER_CREATED"
 	CodeOrderFound   = "ORDER_FOUND"
 	CodeOrdersListed = "ORDERS_LISTED"
 	CodeOrderCounted = "ORDER_COUNTED"
+
+	CodeCustomerSummaryFound = "CUSTOMER_SUMMARY_FOUND"
+
+	CodeOrderCancellationAccepted = "ORDER_CANCELLATION_ACCEPTED"
+	CodeOrderAmendmentAccepted    = "ORDER_AMENDMENT_ACCEPTED"
+	CodeOrderReturnAccepted       = "ORDER_RETURN_ACCEPTED"
+
+	// Success codes - FX operations
+	CodeFxRatesImported = "FX_RATES_IMPORTED"
+
+	// Success codes - Product operations
+	CodeProductCreated     = "PRODUCT_CREATED"
+	CodeProductFound       = "PRODUCT_FOUND"
+	CodeProductsListed     = "PRODUCTS_LISTED"
+	CodeProductUpdated     = "PRODUCT_UPDATED"
+	CodeProductDeactivated = "PRODUCT_DEACTIVATED"
+
+	// Success codes - Customer operations
+	CodeCustomerCreated = "CUSTOMER_CREATED"
+	CodeCustomerFound   = "CUSTOMER_FOUND"
+	CodeCustomersListed = "CUSTOMERS_LISTED"
+	CodeCustomerUpdated = "CUSTOMER_UPDATED"
+
+	// Success codes - Log level operations
+	CodeLogLevelsListed = "LOG_LEVELS_LISTED"
+	CodeLogLevelUpdated = "LOG_LEVEL_UPDATED"
+	CodeLogLevelReset   = "LOG_LEVEL_RESET"
+
+	// Success codes - Audit operations
+	CodeAuditEntriesListed = "AUDIT_ENTRIES_LISTED"
+	CodeAuditLogVerified   = "AUDIT_LOG_VERIFIED"
+)
+
+// Message keys of the internal errors, which all share CodeInternalError.
+// They select the catalog message of each failed operation.
+const (
+	KeyFailedToCreateOrder       = "FAILED_TO_CREATE_ORDER"
+	KeyFailedToGetOrder          = "FAILED_TO_GET_ORDER"
+	KeyFailedToGetOrderTotal     = "FAILED_TO_GET_ORDER_TOTAL"
+	KeyFailedToListOrders        = "FAILED_TO_LIST_ORDERS"
+	KeyFailedToGetSummary        = "FAILED_TO_GET_SUMMARY"
+	KeyFailedToCountOrders       = "FAILED_TO_COUNT_ORDERS"
+	KeyFailedToCancelOrder       = "FAILED_TO_CANCEL_ORDER"
+	KeyFailedToAmendOrder        = "FAILED_TO_AMEND_ORDER"
+	KeyFailedToReturnOrder       = "FAILED_TO_RETURN_ORDER"
+	KeyFailedToImportFxRates     = "FAILED_TO_IMPORT_FX_RATES"
+	KeyFailedToCreateProduct     = "FAILED_TO_CREATE_PRODUCT"
+	KeyFailedToGetProduct        = "FAILED_TO_GET_PRODUCT"
+	KeyFailedToListProducts      = "FAILED_TO_LIST_PRODUCTS"
+	KeyFailedToUpdateProduct     = "FAILED_TO_UPDATE_PRODUCT"
+	KeyFailedToDeactivateProduct = "FAILED_TO_DEACTIVATE_PRODUCT"
+	KeyFailedToCreateCustomer    = "FAILED_TO_CREATE_CUSTOMER"
+	KeyFailedToGetCustomer       = "FAILED_TO_GET_CUSTOMER"
+	KeyFailedToListCustomers     = "FAILED_TO_LIST_CUSTOMERS"
+	KeyFailedToUpdateCustomer    = "FAILED_TO_UPDATE_CUSTOMER"
+	KeyFailedToListAuditEntries  = "FAILED_TO_LIST_AUDIT_ENTRIES"
+	KeyFailedToVerifyAuditLog    = "FAILED_TO_VERIFY_AUDIT_LOG"
 )
@@ -0,0 +1,36 @@
+package constants
+
+// Detail keys, naming the message catalog entries that explain an occurrence of an error.
+// {name} placeholders in the messages are filled by the parameters of the detail.
+const (
+	// Request bodies
+	DetailBodyTooLarge         = "detail.body_too_large"
+	DetailContentTypeJSON      = "detail.content_type_json"
+	DetailContentTypeJSONOrCSV = "detail.content_type_json_or_csv"
+	DetailBodyRequired         = "detail.body_required"
+	DetailSingleDocument       = "detail.single_document"
+	DetailMalformedJSON        = "detail.malformed_json"
+	DetailTruncatedJSON        = "detail.truncated_json"
+	DetailBodyNotObject        = "detail.body_not_object"
+	DetailWrongFieldType       = "detail.wrong_field_type"
+	DetailUnknownField         = "detail.unknown_field"
+	DetailUndecodableBody      = "detail.undecodable_body"
+
+	// Access
+	DetailOriginNotAllowed = "detail.origin_not_allowed"
+
+	// Log level
+	DetailUnknownLogLevel    = "detail.unknown_log_level"
+	DetailInvalidRevertDelay = "detail.invalid_revert_delay"
+
+	// Audit log filters
+	DetailAuditFormat  = "detail.audit_format"
+	DetailAuditOutcome = "detail.audit_outcome"
+	DetailAuditTime    = "detail.audit_time"
+	DetailAuditCursor  = "detail.audit_cursor"
+	DetailAuditLimit   = "detail.audit_limit"
+
+	// Exchange rate imports
+	DetailCSVHeader    = "detail.csv_header"
+	DetailMalformedCSV = "detail.malformed_csv"
+)
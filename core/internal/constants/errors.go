@@ -4,20 +4,53 @@ import "net/http"
 
 // APIError represents a standardized API error with code, message, and HTTP status.
 // Use these predefined errors for consistent API responses across the application.
+// They are rendered as RFC 7807 problem details: Message is the English title shared by
+// every occurrence of the error, translated through the catalog entry of MessageKey,
+// and Details, when set, explain this occurrence.
 type APIError struct {
 	Code    string
+	Key     string
 	Message string
 	Status  int
+	Details []Detail
 }
 
-// WithMessage returns a copy of the APIError with a custom message.
-// Useful for validation errors or other dynamic messages.
-func (e APIError) WithMessage(message string) APIError {
-	return APIError{
-		Code:    e.Code,
-		Message: message,
-		Status:  e.Status,
+// Detail explains one occurrence of an APIError. Key names its message in the message
+// catalog, whose {name} placeholders Params fill; Message is the English text used
+// when the catalog has no entry for Key.
+type Detail struct {
+	Key     string
+	Params  map[string]string
+	Message string
+}
+
+// MessageKey returns the key of the error's message in the message catalog:
+// its Key when several errors share the code, otherwise the code itself
+func (e APIError) MessageKey() string {
+	if e.Key != "" {
+		return e.Key
 	}
+	return e.Code
+}
+
+// WithDetail returns a copy of the APIError explaining this occurrence of it with the
+// catalog message of key. params are name and value pairs filling its placeholders.
+func (e APIError) WithDetail(key string, params ...string) APIError {
+	detail := Detail{Key: key}
+	if len(params) > 0 {
+		detail.Params = make(map[string]string, len(params)/2)
+		for i := 0; i+1 < len(params); i += 2 {
+			detail.Params[params[i]] = params[i+1]
+		}
+	}
+	return e.WithDetails(detail)
+}
+
+// WithDetails returns a copy of the APIError explaining this occurrence of it
+// with several details, such as every rule a request breaks
+func (e APIError) WithDetails(details ...Detail) APIError {
+	e.Details = details
+	return e
 }
 
 // Common errors - shared across multiple modules
@@ -32,11 +65,46 @@ var (
 		Message: MsgInternalError,
 		Status:  http.StatusInternalServerError,
 	}
+	ErrValidationFailed = APIError{
+		Code:    CodeValidationFailed,
+		Message: MsgValidationFailed,
+		Status:  http.StatusBadRequest,
+	}
 	ErrNotFound = APIError{
 		Code:    CodeNotFound,
 		Message: MsgNotFound,
 		Status:  http.StatusNotFound,
 	}
+	ErrMethodNotAllowed = APIError{
+		Code:    CodeMethodNotAllowed,
+		Message: MsgMethodNotAllowed,
+		Status:  http.StatusMethodNotAllowed,
+	}
+	ErrUnauthorized = APIError{
+		Code:    CodeUnauthorized,
+		Message: MsgUnauthorized,
+		Status:  http.StatusUnauthorized,
+	}
+	ErrForbidden = APIError{
+		Code:    CodeForbidden,
+		Message: MsgForbidden,
+		Status:  http.StatusForbidden,
+	}
+	ErrRateLimited = APIError{
+		Code:    CodeRateLimited,
+		Message: MsgRateLimited,
+		Status:  http.StatusTooManyRequests,
+	}
+	ErrPayloadTooLarge = APIError{
+		Code:    CodePayloadTooLarge,
+		Message: MsgPayloadTooLarge,
+		Status:  http.StatusRequestEntityTooLarge,
+	}
+	ErrUnsupportedMedia = APIError{
+		Code:    CodeUnsupportedMedia,
+		Message: MsgUnsupportedMedia,
+		Status:  http.StatusUnsupportedMediaType,
+	}
 )
 
 // Order-related errors
@@ -58,27 +126,243 @@ var (
 	}
 	ErrFailedToCreateOrder = APIError{
 		Code:    CodeInternalError,
+		Key:     KeyFailedToCreateOrder,
 		Message: MsgFailedToCreateOrder,
 		Status:  http.StatusInternalServerError,
 	}
 	ErrFailedToGetOrder = APIError{
 		Code:    CodeInternalError,
+		Key:     KeyFailedToGetOrder,
 		Message: MsgFailedToGetOrder,
 		Status:  http.StatusInternalServerError,
 	}
 	ErrFailedToGetOrderTotal = APIError{
 		Code:    CodeInternalError,
+		Key:     KeyFailedToGetOrderTotal,
 		Message: MsgFailedToGetOrderTotal,
 		Status:  http.StatusInternalServerError,
 	}
 	ErrFailedToListOrders = APIError{
 		Code:    CodeInternalError,
+		Key:     KeyFailedToListOrders,
 		Message: MsgFailedToListOrders,
 		Status:  http.StatusInternalServerError,
 	}
+	ErrFailedToGetSummary = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToGetSummary,
+		Message: MsgFailedToGetSummary,
+		Status:  http.StatusInternalServerError,
+	}
 	ErrFailedToCountOrders = APIError{
 		Code:    CodeInternalError,
+		Key:     KeyFailedToCountOrders,
 		Message: MsgFailedToCountOrders,
 		Status:  http.StatusInternalServerError,
 	}
+	ErrOrderCancelled = APIError{
+		Code:    CodeOrderCancelled,
+		Message: MsgOrderCancelled,
+		Status:  http.StatusConflict,
+	}
+	ErrVersionConflict = APIError{
+		Code:    CodeVersionConflict,
+		Message: MsgVersionConflict,
+		Status:  http.StatusConflict,
+	}
+	ErrEmptyAmendment = APIError{
+		Code:    CodeEmptyAmendment,
+		Message: MsgEmptyAmendment,
+		Status:  http.StatusBadRequest,
+	}
+	ErrFailedToCancelOrder = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToCancelOrder,
+		Message: MsgFailedToCancelOrder,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToAmendOrder = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToAmendOrder,
+		Message: MsgFailedToAmendOrder,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrInvalidReturn = APIError{
+		Code:    CodeInvalidReturn,
+		Message: MsgInvalidReturn,
+		Status:  http.StatusUnprocessableEntity,
+	}
+	ErrFailedToReturnOrder = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToReturnOrder,
+		Message: MsgFailedToReturnOrder,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrInvalidPricing = APIError{
+		Code:    CodeInvalidPricing,
+		Message: MsgInvalidPricing,
+		Status:  http.StatusUnprocessableEntity,
+	}
+)
+
+// FX-related errors
+var (
+	ErrInvalidCurrency = APIError{
+		Code:    CodeInvalidCurrency,
+		Message: MsgInvalidCurrency,
+		Status:  http.StatusBadRequest,
+	}
+	ErrFxRateNotFound = APIError{
+		Code:    CodeFxRateNotFound,
+		Message: MsgFxRateNotFound,
+		Status:  http.StatusUnprocessableEntity,
+	}
+	ErrInvalidFxRate = APIError{
+		Code:    CodeInvalidFxRate,
+		Message: MsgInvalidFxRate,
+		Status:  http.StatusBadRequest,
+	}
+	ErrFailedToImportFxRates = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToImportFxRates,
+		Message: MsgFailedToImportFxRates,
+		Status:  http.StatusInternalServerError,
+	}
+)
+
+// Product-related errors
+var (
+	ErrProductNotFound = APIError{
+		Code:    CodeProductNotFound,
+		Message: MsgProductNotFound,
+		Status:  http.StatusNotFound,
+	}
+	ErrProductExists = APIError{
+		Code:    CodeProductExists,
+		Message: MsgProductExists,
+		Status:  http.StatusConflict,
+	}
+	ErrInvalidSKU = APIError{
+		Code:    CodeInvalidSKU,
+		Message: MsgInvalidSKU,
+		Status:  http.StatusBadRequest,
+	}
+	ErrFailedToCreateProduct = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToCreateProduct,
+		Message: MsgFailedToCreateProduct,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToGetProduct = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToGetProduct,
+		Message: MsgFailedToGetProduct,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToListProducts = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToListProducts,
+		Message: MsgFailedToListProducts,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToUpdateProduct = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToUpdateProduct,
+		Message: MsgFailedToUpdateProduct,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToDeactivateProduct = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToDeactivateProduct,
+		Message: MsgFailedToDeactivateProduct,
+		Status:  http.StatusInternalServerError,
+	}
+)
+
+// Customer-related errors
+var (
+	ErrCustomerNotFound = APIError{
+		Code:    CodeCustomerNotFound,
+		Message: MsgCustomerNotFound,
+		Status:  http.StatusNotFound,
+	}
+	ErrCustomerExists = APIError{
+		Code:    CodeCustomerExists,
+		Message: MsgCustomerExists,
+		Status:  http.StatusConflict,
+	}
+	ErrCustomerBlocked = APIError{
+		Code:    CodeCustomerBlocked,
+		Message: MsgCustomerBlocked,
+		Status:  http.StatusUnprocessableEntity,
+	}
+	ErrUnknownCustomer = APIError{
+		Code:    CodeUnknownCustomer,
+		Message: MsgUnknownCustomer,
+		Status:  http.StatusUnprocessableEntity,
+	}
+	ErrInvalidDocument = APIError{
+		Code:    CodeInvalidDocument,
+		Message: MsgInvalidDocument,
+		Status:  http.StatusBadRequest,
+	}
+	ErrFailedToCreateCustomer = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToCreateCustomer,
+		Message: MsgFailedToCreateCustomer,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToGetCustomer = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToGetCustomer,
+		Message: MsgFailedToGetCustomer,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToListCustomers = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToListCustomers,
+		Message: MsgFailedToListCustomers,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToUpdateCustomer = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToUpdateCustomer,
+		Message: MsgFailedToUpdateCustomer,
+		Status:  http.StatusInternalServerError,
+	}
+)
+
+// Log level errors
+var (
+	ErrInvalidLogLevel = APIError{
+		Code:    CodeInvalidLogLevel,
+		Message: MsgInvalidLogLevel,
+		Status:  http.StatusBadRequest,
+	}
+	ErrLogLevelNotFound = APIError{
+		Code:    CodeLogLevelNotFound,
+		Message: MsgLogLevelNotFound,
+		Status:  http.StatusNotFound,
+	}
+)
+
+// Audit errors
+var (
+	ErrInvalidAuditFilter = APIError{
+		Code:    CodeInvalidAuditFilter,
+		Message: MsgInvalidAuditFilter,
+		Status:  http.StatusBadRequest,
+	}
+	ErrFailedToListAuditEntries = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToListAuditEntries,
+		Message: MsgFailedToListAuditEntries,
+		Status:  http.StatusInternalServerError,
+	}
+	ErrFailedToVerifyAuditLog = APIError{
+		Code:    CodeInternalError,
+		Key:     KeyFailedToVerifyAuditLog,
+		Message: MsgFailedToVerifyAuditLog,
+		Status:  http.StatusInternalServerError,
+	}
 )
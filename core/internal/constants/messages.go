@@ -1,12 +1,19 @@
 package constants
 
 // Error messages used in API responses.
-// These are the human-readable messages returned in the "message" field.
+// These are the human-readable messages returned in the "title" field.
 const (
 	// Common messages
 	MsgInvalidRequestBody = "Invalid request body"
 	MsgInternalError      = "An internal error occurred"
+	MsgValidationFailed   = "Validation failed"
 	MsgNotFound           = "Resource not found"
+	MsgMethodNotAllowed   = "Method not allowed for this resource"
+	MsgUnauthorized       = "A valid API key or bearer token is required"
+	MsgForbidden          = "The credentials used lack the scope this operation requires"
+	MsgRateLimited        = "Too many requests, retry after the time given in Retry-After"
+	MsgPayloadTooLarge    = "Request body is too large"
+	MsgUnsupportedMedia   = "Unsupported request content type"
 
 	// Order-specific messages
 	MsgOrderNotFound         = "Order not found"
@@ -17,4 +24,49 @@ const (
 	MsgFailedToGetOrderTotal = "Failed to retrieve order total"
 	MsgFailedToListOrders    = "Failed to list orders"
 	MsgFailedToCountOrders   = "Failed to count orders"
+	MsgFailedToGetSummary    = "Failed to retrieve customer summary"
+	MsgOrderCancelled        = "Order is cancelled and can no longer be changed"
+	MsgVersionConflict       = "Order was modified since the expected version"
+	MsgEmptyAmendment        = "Amendment must add, remove or change at least one item"
+	MsgFailedToCancelOrder   = "Failed to cancel order"
+	MsgFailedToAmendOrder    = "Failed to amend order"
+	MsgInvalidReturn         = "Return does not match the items bought"
+	MsgFailedToReturnOrder   = "Failed to return order items"
+	MsgInvalidPricing        = "Discounts exceed the amounts they are taken off"
+
+	// FX-specific messages
+	MsgInvalidCurrency       = "Currency must be an ISO 4217 code such as BRL or USD"
+	MsgFxRateNotFound        = "No exchange rate is effective for the order date"
+	MsgInvalidFxRate         = "Invalid exchange rate"
+	MsgFailedToImportFxRates = "Failed to import exchange rates"
+
+	// Product-specific messages
+	MsgProductNotFound           = "Product not found"
+	MsgProductExists             = "A product with this SKU already exists"
+	MsgInvalidSKU                = "SKU must not be blank"
+	MsgFailedToCreateProduct     = "Failed to create product"
+	MsgFailedToGetProduct        = "Failed to retrieve product"
+	MsgFailedToListProducts      = "Failed to list products"
+	MsgFailedToUpdateProduct     = "Failed to update product"
+	MsgFailedToDeactivateProduct = "Failed to deactivate product"
+
+	// Customer-specific messages
+	MsgCustomerNotFound       = "Customer not found"
+	MsgCustomerExists         = "A customer with this code or document already exists"
+	MsgCustomerBlocked        = "Customer is blocked and cannot place orders"
+	MsgUnknownCustomer        = "Customer is not registered"
+	MsgInvalidDocument        = "Document must be a valid CPF or CNPJ"
+	MsgFailedToCreateCustomer = "Failed to create customer"
+	MsgFailedToGetCustomer    = "Failed to retrieve customer"
+	MsgFailedToListCustomers  = "Failed to list customers"
+	MsgFailedToUpdateCustomer = "Failed to update customer"
+
+	// Log level messages
+	MsgInvalidLogLevel  = "Level must be debug, info, warn or error, and the revert delay a positive duration"
+	MsgLogLevelNotFound = "The logger has no level of its own"
+
+	// Audit messages
+	MsgInvalidAuditFilter       = "Audit filters must hold RFC 3339 times, a known outcome, a positive cursor and a limit from 1 to 1000"
+	MsgFailedToListAuditEntries = "Failed to list audit entries"
+	MsgFailedToVerifyAuditLog   = "Failed to verify the audit log"
 )
@@ -27,4 +27,100 @@ var (
 		Code:   CodeOrderCounted,
 		Status: http.StatusOK,
 	}
+	SuccessCustomerSummaryFound = APISuccess{
+		Code:   CodeCustomerSummaryFound,
+		Status: http.StatusOK,
+	}
+	SuccessOrderCancellationAccepted = APISuccess{
+		Code:   CodeOrderCancellationAccepted,
+		Status: http.StatusAccepted,
+	}
+	SuccessOrderAmendmentAccepted = APISuccess{
+		Code:   CodeOrderAmendmentAccepted,
+		Status: http.StatusAccepted,
+	}
+	SuccessOrderReturnAccepted = APISuccess{
+		Code:   CodeOrderReturnAccepted,
+		Status: http.StatusAccepted,
+	}
+)
+
+// FX-related success responses
+var (
+	SuccessFxRatesImported = APISuccess{
+		Code:   CodeFxRatesImported,
+		Status: http.StatusOK,
+	}
+)
+
+// Product-related success responses
+var (
+	SuccessProductCreated = APISuccess{
+		Code:   CodeProductCreated,
+		Status: http.StatusCreated,
+	}
+	SuccessProductFound = APISuccess{
+		Code:   CodeProductFound,
+		Status: http.StatusOK,
+	}
+	SuccessProductsListed = APISuccess{
+		Code:   CodeProductsListed,
+		Status: http.StatusOK,
+	}
+	SuccessProductUpdated = APISuccess{
+		Code:   CodeProductUpdated,
+		Status: http.StatusOK,
+	}
+	SuccessProductDeactivated = APISuccess{
+		Code:   CodeProductDeactivated,
+		Status: http.StatusOK,
+	}
+)
+
+// Customer-related success responses
+var (
+	SuccessCustomerCreated = APISuccess{
+		Code:   CodeCustomerCreated,
+		Status: http.StatusCreated,
+	}
+	SuccessCustomerFound = APISuccess{
+		Code:   CodeCustomerFound,
+		Status: http.StatusOK,
+	}
+	SuccessCustomersListed = APISuccess{
+		Code:   CodeCustomersListed,
+		Status: http.StatusOK,
+	}
+	SuccessCustomerUpdated = APISuccess{
+		Code:   CodeCustomerUpdated,
+		Status: http.StatusOK,
+	}
+)
+
+// Log level success responses
+var (
+	SuccessLogLevelsListed = APISuccess{
+		Code:   CodeLogLevelsListed,
+		Status: http.StatusOK,
+	}
+	SuccessLogLevelUpdated = APISuccess{
+		Code:   CodeLogLevelUpdated,
+		Status: http.StatusOK,
+	}
+	SuccessLogLevelReset = APISuccess{
+		Code:   CodeLogLevelReset,
+		Status: http.StatusOK,
+	}
+)
+
+// Audit success responses
+var (
+	SuccessAuditEntriesListed = APISuccess{
+		Code:   CodeAuditEntriesListed,
+		Status: http.StatusOK,
+	}
+	SuccessAuditLogVerified = APISuccess{
+		Code:   CodeAuditLogVerified,
+		Status: http.StatusOK,
+	}
 )
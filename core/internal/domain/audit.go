@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AuditOutcome says how an audited request ended
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess AuditOutcome = "success"
+	AuditOutcomeDenied  AuditOutcome = "denied"
+	AuditOutcomeFailure AuditOutcome = "failure"
+)
+
+// AuditOutcomes lists every outcome an audit entry may record
+var AuditOutcomes = []AuditOutcome{AuditOutcomeSuccess, AuditOutcomeDenied, AuditOutcomeFailure}
+
+// IsValid reports whether the outcome is one an entry may record
+func (o AuditOutcome) IsValid() bool {
+	return slices.Contains(AuditOutcomes, o)
+}
+
+// ErrAuditEntrySpooled is returned when an entry could not be staged in the database
+// and was kept in the local spool instead, to be replayed later
+var ErrAuditEntrySpooled = errors.New("audit entry spooled")
+
+// AuditGenesisHash is the previous hash of the first entry of the chain
+var AuditGenesisHash = strings.Repeat("0", 64)
+
+// AuditEntry records who did what to which resource, and how it ended.
+// Entries are chained: Hash covers every field and PrevHash, the hash of the entry before.
+type AuditEntry struct {
+	ID            int64
+	OccurredAt    time.Time
+	Principal     string
+	AuthMethod    string
+	Action        string
+	Resource      string
+	CorrelationID string
+	SourceIP      string
+	Outcome       AuditOutcome
+	StatusCode    int
+	PrevHash      string
+	Hash          string
+}
+
+// ComputeHash returns the SHA-256 of the entry's fields and PrevHash, hex encoded.
+// OccurredAt is taken in UTC at microsecond precision, as the database stores it.
+func (e *AuditEntry) ComputeHash() string {
+	// A JSON array keeps field boundaries unambiguous whatever the values hold
+	content, _ := json.Marshal([]string{
+		e.PrevHash,
+		e.OccurredAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		e.Principal,
+		e.AuthMethod,
+		e.Action,
+		e.Resource,
+		e.CorrelationID,
+		e.SourceIP,
+		string(e.Outcome),
+		strconv.Itoa(e.StatusCode),
+	})
+	sum := sha256.Sum256(content)
+	return hex.EncodeToString(sum[:])
+}
+
+// AuditFilter narrows the audit entries listed. Empty fields match every entry;
+// Resource matches as a prefix and To is exclusive.
+type AuditFilter struct {
+	Principal string
+	Action    string
+	Resource  string
+	Outcome   AuditOutcome
+	From      time.Time
+	To        time.Time
+	AfterID   int64
+	Limit     int
+}
+
+// AuditVerification is the outcome of checking the hash chain of the audit log.
+// When the chain is broken, BrokenAt is the first entry that does not match.
+type AuditVerification struct {
+	Valid    bool
+	Checked  int64
+	LastHash string
+	BrokenAt int64
+	Reason   string
+}
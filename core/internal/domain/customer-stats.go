@@ -0,0 +1,20 @@
+package domain
+
+import "time"
+
+// CustomerStats is the read model of a customer's orders kept up to date by the consumer.
+// Cancelled orders are left out; lifetime values are net of refunds and kept per currency.
+type CustomerStats struct {
+	CustomerCode   int
+	OrderCount     int
+	LastOrderAt    *time.Time
+	TopProduct     string
+	LifetimeValues []LifetimeValue
+	UpdatedAt      time.Time
+}
+
+// LifetimeValue is the net amount a customer spent in one currency
+type LifetimeValue struct {
+	Currency string
+	Value    Money
+}
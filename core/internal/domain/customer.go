@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// CustomerStatus represents whether a customer may place orders
+type CustomerStatus string
+
+const (
+	CustomerStatusActive  CustomerStatus = "active"
+	CustomerStatusBlocked CustomerStatus = "blocked"
+)
+
+// DocumentType identifies the Brazilian tax document of a customer
+type DocumentType string
+
+const (
+	DocumentTypeCPF  DocumentType = "CPF"
+	DocumentTypeCNPJ DocumentType = "CNPJ"
+)
+
+var (
+	// ErrCustomerNotFound is returned when no customer matches the requested code
+	ErrCustomerNotFound = errors.New("customer not found")
+
+	// ErrCustomerExists is returned when a customer is created with a code or document already registered
+	ErrCustomerExists = errors.New("customer already exists")
+
+	// ErrUnknownCustomer is returned when an order names a customer that is not registered
+	ErrUnknownCustomer = errors.New("customer is not registered")
+
+	// ErrCustomerBlocked is returned when a blocked customer places an order
+	ErrCustomerBlocked = errors.New("customer is blocked")
+
+	// ErrInvalidDocument is returned when a document is neither a valid CPF nor a valid CNPJ
+	ErrInvalidDocument = errors.New("invalid CPF/CNPJ")
+)
+
+// Customer is a registered buyer referenced by orders through its code.
+// Document holds only the digits of the CPF or CNPJ.
+type Customer struct {
+	Code         int
+	Name         string
+	Email        string
+	Document     string
+	DocumentType DocumentType
+	Status       CustomerStatus
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IsBlocked reports whether the customer is barred from placing orders
+func (c *Customer) IsBlocked() bool {
+	return c.Status == CustomerStatusBlocked
+}
+
+// NormalizeDocument strips the punctuation of a formatted CPF or CNPJ, keeping only digits.
+// Only the '.', '-' and '/' separators are dropped; a document holding any other character
+// normalizes to "", which no check accepts.
+func NormalizeDocument(document string) string {
+	var b strings.Builder
+	for _, r := range document {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '.' || r == '-' || r == '/':
+		default:
+			return ""
+		}
+	}
+	return b.String()
+}
+
+// ParseDocument normalizes a CPF or CNPJ and tells which one it is by its length.
+// Documents with wrong check digits are rejected with ErrInvalidDocument.
+func ParseDocument(document string) (string, DocumentType, error) {
+	digits := NormalizeDocument(document)
+
+	switch {
+	case IsValidCPF(digits):
+		return digits, DocumentTypeCPF, nil
+	case IsValidCNPJ(digits):
+		return digits, DocumentTypeCNPJ, nil
+	default:
+		return "", "", ErrInvalidDocument
+	}
+}
+
+// IsValidCPF checks the two check digits of a CPF, formatted or not
+func IsValidCPF(cpf string) bool {
+	digits := NormalizeDocument(cpf)
+	if len(digits) != 11 || repeatedDigits(digits) {
+		return false
+	}
+
+	for _, n := range []int{9, 10} {
+		sum := 0
+		for i := range n {
+			sum += int(digits[i]-'0') * (n + 1 - i)
+		}
+		if checkDigit(sum) != int(digits[n]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+// IsValidCNPJ checks the two check digits of a CNPJ, formatted or not
+func IsValidCNPJ(cnpj string) bool {
+	digits := NormalizeDocument(cnpj)
+	if len(digits) != 14 || repeatedDigits(digits) {
+		return false
+	}
+
+	weights := []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+	for _, n := range []int{12, 13} {
+		sum := 0
+		for i := range n {
+			sum += int(digits[i]-'0') * weights[len(weights)-n+i]
+		}
+		if checkDigit(sum) != int(digits[n]-'0') {
+			return false
+		}
+	}
+	return true
+}
+
+// checkDigit computes a modulo 11 check digit, where remainders below 2 give 0
+func checkDigit(sum int) int {
+	if rest := sum % 11; rest >= 2 {
+		return 11 - rest
+	}
+	return 0
+}
+
+// repeatedDigits reports documents such as 111.111.111-11 that pass the check digit
+// arithmetic but are never issued
+func repeatedDigits(digits string) bool {
+	return strings.Count(digits, digits[:1]) == len(digits)
+}
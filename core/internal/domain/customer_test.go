@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		wantType DocumentType
+		want     string
+	}{
+		{name: "formatted CPF", document: "529.982.247-25", wantType: DocumentTypeCPF, want: "52998224725"},
+		{name: "bare CPF", document: "11144477735", wantType: DocumentTypeCPF, want: "11144477735"},
+		{name: "formatted CNPJ", document: "11.222.333/0001-81", wantType: DocumentTypeCNPJ, want: "11222333000181"},
+		{name: "bare CNPJ", document: "04252011000110", wantType: DocumentTypeCNPJ, want: "04252011000110"},
+		{name: "CPF with a wrong first check digit", document: "529.982.247-35"},
+		{name: "CPF with a wrong second check digit", document: "529.982.247-24"},
+		{name: "CNPJ with a wrong first check digit", document: "11.222.333/0001-71"},
+		{name: "CNPJ with a wrong second check digit", document: "11.222.333/0001-80"},
+		{name: "repeated digits CPF", document: "111.111.111-11"},
+		{name: "repeated zeros CPF", document: "00000000000"},
+		{name: "repeated digits CNPJ", document: "22.222.222/2222-22"},
+		{name: "repeated zeros CNPJ", document: "00000000000000"},
+		{name: "too short", document: "5299822472"},
+		{name: "between the lengths", document: "529982247251"},
+		{name: "too long", document: "112223330001811"},
+		{name: "empty", document: ""},
+		{name: "letters around a valid CPF", document: "abc529.982.247-25xyz"},
+		{name: "letters inside a valid CNPJ", document: "11.222.333/0001-8x1"},
+		{name: "spaces as separators", document: "529 982 247 25"},
+		{name: "other punctuation", document: "529,982,247_25"},
+		{name: "non-ASCII digits", document: "٥٢٩٩٨٢٢٤٧٢٥"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			digits, documentType, err := ParseDocument(tt.document)
+			if tt.wantType == "" {
+				if !errors.Is(err, ErrInvalidDocument) {
+					t.Fatalf("ParseDocument(%q) = %q, %q, %v; want ErrInvalidDocument", tt.document, digits, documentType, err)
+				}
+				if IsValidCPF(tt.document) || IsValidCNPJ(tt.document) {
+					t.Errorf("%q passes IsValidCPF or IsValidCNPJ", tt.document)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseDocument(%q): %v", tt.document, err)
+			}
+			if digits != tt.want || documentType != tt.wantType {
+				t.Errorf("ParseDocument(%q) = %q, %q; want %q, %q", tt.document, digits, documentType, tt.want, tt.wantType)
+			}
+			if IsValidCPF(tt.document) != (tt.wantType == DocumentTypeCPF) || IsValidCNPJ(tt.document) != (tt.wantType == DocumentTypeCNPJ) {
+				t.Errorf("%q is not recognised as exactly a %s", tt.document, tt.wantType)
+			}
+		})
+	}
+}
+
+func TestNormalizeDocument(t *testing.T) {
+	tests := []struct {
+		document string
+		want     string
+	}{
+		{document: "529.982.247-25", want: "52998224725"},
+		{document: "11.222.333/0001-81", want: "11222333000181"},
+		{document: "52998224725", want: "52998224725"},
+		{document: "abc529.982.247-25xyz", want: ""},
+		{document: " 52998224725", want: ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeDocument(tt.document); got != tt.want {
+			t.Errorf("NormalizeDocument(%q) = %q, want %q", tt.document, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package domain
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PivotCurrency is used to cross two currencies that have no direct rate
+const PivotCurrency = DefaultCurrency
+
+// rateScale is the number of decimal places kept when a rate is inverted or crossed
+const rateScale = 10
+
+var (
+	// ErrFxRateNotFound is returned when no rate is effective for a currency pair at a date
+	ErrFxRateNotFound = errors.New("fx rate not found")
+
+	// ErrInvalidFxRate is returned when an imported rate is malformed
+	ErrInvalidFxRate = errors.New("invalid fx rate")
+)
+
+// Rate is an exact, positive conversion factor
+type Rate struct {
+	d decimal.Decimal
+}
+
+// ParseRate parses a decimal string such as "5.1234"
+func ParseRate(s string) (Rate, error) {
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return Rate{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	return Rate{d: d}, nil
+}
+
+// NewRate builds the rate coefficient * 10^exp, as stored by NUMERIC columns
+func NewRate(coefficient *big.Int, exp int32) Rate {
+	return Rate{d: decimal.NewFromBigInt(coefficient, exp)}
+}
+
+// OneRate converts a currency to itself
+func OneRate() Rate { return Rate{d: decimal.NewFromInt(1)} }
+
+// Coefficient and Exponent describe the rate as coefficient * 10^exponent
+func (r Rate) Coefficient() *big.Int { return r.d.Coefficient() }
+func (r Rate) Exponent() int32       { return r.d.Exponent() }
+
+func (r Rate) IsPositive() bool { return r.d.IsPositive() }
+
+// Invert returns 1 / r, rounded half-even to rateScale places. The quotient is
+// truncated and the remainder decides the last digit, so it is rounded only once.
+func (r Rate) Invert() Rate {
+	quotient, remainder := decimal.NewFromInt(1).QuoRem(r.d, rateScale)
+
+	// The dropped digits are remainder / r units of the last place; compare them with half a unit
+	unit := decimal.New(1, -rateScale)
+	switch remainder.Mul(decimal.NewFromInt(2)).Cmp(r.d.Mul(unit)) {
+	case 1:
+		quotient = quotient.Add(unit)
+	case 0:
+		if quotient.Shift(rateScale).BigInt().Bit(0) == 1 {
+			quotient = quotient.Add(unit)
+		}
+	}
+	return Rate{d: quotient}
+}
+
+// Mul chains two rates, rounded half-even to rateScale places
+func (r Rate) Mul(other Rate) Rate {
+	return Rate{d: r.d.Mul(other.d).RoundBank(rateScale)}
+}
+
+func (r Rate) String() string { return r.d.String() }
+
+// MarshalJSON emits the rate as a decimal string
+func (r Rate) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + r.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts a decimal string or a JSON number, parsed exactly from its text
+func (r *Rate) UnmarshalJSON(data []byte) error {
+	parsed, err := ParseRate(string(bytes.Trim(data, `"`)))
+	if err != nil {
+		return err
+	}
+	*r = parsed
+	return nil
+}
+
+// Convert multiplies the amount by the rate and rounds half-even to places
+func (m Money) Convert(r Rate, places int32) Money {
+	return Money{d: m.d.Mul(r.d).RoundBank(places)}
+}
+
+// ExchangeRate says one unit of Base buys Rate units of Quote from EffectiveDate on
+type ExchangeRate struct {
+	Base          string    `json:"base"`
+	Quote         string    `json:"quote"`
+	Rate          Rate      `json:"rate"`
+	EffectiveDate time.Time `json:"effectiveDate"`
+}
+
+// Validate checks the pair and the rate of an imported exchange rate.
+// The error is a *ProblemError wrapping ErrInvalidFxRate.
+func (r *ExchangeRate) Validate() error {
+	var key, reason string
+	switch {
+	case !IsCurrency(r.Base) || !IsCurrency(r.Quote):
+		key, reason = ProblemInvalidCurrencyPair, "is not a pair of ISO 4217 codes"
+	case r.Base == r.Quote:
+		key, reason = ProblemSameCurrency, "converts a currency to itself"
+	case !r.Rate.IsPositive():
+		key, reason = ProblemNonPositiveRate, "rate must be greater than 0"
+	case r.EffectiveDate.IsZero():
+		key, reason = ProblemNoEffectiveDate, "has no effective date"
+	default:
+		return nil
+	}
+
+	return &ProblemError{Err: ErrInvalidFxRate, Problems: []Problem{{
+		Key:     key,
+		Params:  map[string]string{"base": r.Base, "quote": r.Quote},
+		Message: fmt.Sprintf("%s/%s %s", r.Base, r.Quote, reason),
+	}}}
+}
+
+// IsCurrency reports whether code has the shape of an ISO 4217 alphabetic code
+func IsCurrency(code string) bool {
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
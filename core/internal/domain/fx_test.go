@@ -0,0 +1,33 @@
+package domain
+
+import "testing"
+
+func TestRateInvert(t *testing.T) {
+	tests := []struct {
+		rate string
+		want string
+	}{
+		{rate: "4", want: "0.25"},
+		{rate: "5.1234", want: "0.1951828864"},
+		// Rounding the quotient to 11 places first would turn these into ties and round them the wrong way
+		{rate: "19", want: "0.0526315789"},
+		{rate: "22", want: "0.0454545455"},
+		{rate: "0.0051", want: "196.0784313725"},
+		// An exact tie at the 11th place goes to the even digit
+		{rate: "800000000", want: "0.0000000012"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rate, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want, _ := ParseRate(tt.want)
+			if got := rate.Invert(); !got.d.Equal(want.d) {
+				t.Errorf("1/%s = %s, want %s", tt.rate, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package domain
+
+// Health statuses of the instance and of each dependency it checks
+const (
+	HealthUp   = "up"
+	HealthDown = "down"
+)
+
+// ComponentHealth is the outcome of checking one dependency. Error says why a check
+// failed; it is logged but never rendered, since the readiness probe needs no credentials.
+type ComponentHealth struct {
+	Name      string  `json:"name" example:"database"`
+	Status    string  `json:"status" example:"up"`
+	LatencyMs float64 `json:"latencyMs" example:"1.7"`
+	Error     string  `json:"-"`
+}
+
+// HealthReport says whether the instance can serve traffic, down when any component is
+type HealthReport struct {
+	Status     string            `json:"status" example:"up"`
+	Components []ComponentHealth `json:"components"`
+}
+
+// Ready reports whether every component is up
+func (r HealthReport) Ready() bool {
+	return r.Status == HealthUp
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/shopspring/decimal"
+)
+
+// Money is an exact decimal amount. The zero value is 0.
+// It never goes through float64: JSON, database and arithmetic all keep every digit.
+type Money struct {
+	d decimal.Decimal
+}
+
+// MoneyIntegerDigits is the most integer digits an amount may have, those of the
+// widest money column, NUMERIC(14, 2)
+const MoneyIntegerDigits = 12
+
+// moneyLimit is the smallest amount with more than MoneyIntegerDigits integer digits
+var moneyLimit = decimal.New(1, MoneyIntegerDigits)
+
+// ParseMoney parses a plain decimal string such as "1.10". Exponent notation and
+// amounts with more than MoneyIntegerDigits integer digits are rejected, so an input
+// such as "1e100000000" never expands into every digit when formatted.
+func ParseMoney(s string) (Money, error) {
+	if strings.ContainsAny(s, "eE") {
+		return Money{}, fmt.Errorf("invalid decimal %q: exponent notation is not accepted", s)
+	}
+
+	d, err := decimal.NewFromString(s)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if d.Abs().Cmp(moneyLimit) >= 0 {
+		return Money{}, fmt.Errorf("decimal %q has more than %d integer digits", s, MoneyIntegerDigits)
+	}
+	return Money{d: d}, nil
+}
+
+// MustParseMoney is like ParseMoney but panics on invalid input. Meant for constants and tests.
+func MustParseMoney(s string) Money {
+	m, err := ParseMoney(s)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// NewMoney builds the amount coefficient * 10^exp, as stored by NUMERIC columns
+func NewMoney(coefficient *big.Int, exp int32) Money {
+	return Money{d: decimal.NewFromBigInt(coefficient, exp)}
+}
+
+// Coefficient and Exponent describe the amount as coefficient * 10^exponent
+func (m Money) Coefficient() *big.Int { return m.d.Coefficient() }
+func (m Money) Exponent() int32       { return m.d.Exponent() }
+
+func (m Money) Add(other Money) Money { return Money{d: m.d.Add(other.d)} }
+func (m Money) Sub(other Money) Money { return Money{d: m.d.Sub(other.d)} }
+
+// Mul multiplies the amount by a quantity
+func (m Money) Mul(quantity int) Money {
+	return Money{d: m.d.Mul(decimal.NewFromInt(int64(quantity)))}
+}
+
+// Round rounds to the given number of decimal places using half-even (banker's) rounding
+func (m Money) Round(places int32) Money {
+	return Money{d: m.d.RoundBank(places)}
+}
+
+// Decimals returns the number of significant decimal places, ignoring trailing zeros
+func (m Money) Decimals() int32 {
+	c, exp := m.d.Coefficient(), m.d.Exponent()
+	ten, rem := big.NewInt(10), new(big.Int)
+	for exp < 0 && c.Sign() != 0 {
+		q, r := new(big.Int).QuoRem(c, ten, rem)
+		if r.Sign() != 0 {
+			break
+		}
+		c = q
+		exp++
+	}
+	if exp >= 0 || c.Sign() == 0 {
+		return 0
+	}
+	return -exp
+}
+
+func (m Money) IsZero() bool     { return m.d.IsZero() }
+func (m Money) IsPositive() bool { return m.d.IsPositive() }
+func (m Money) IsNegative() bool { return m.d.IsNegative() }
+
+// Cmp returns -1, 0 or +1 when m is less than, equal to or greater than other
+func (m Money) Cmp(other Money) int { return m.d.Cmp(other.d) }
+
+// String formats the amount with the scale it was created with, so "1.10" stays "1.10"
+func (m Money) String() string {
+	if exp := m.d.Exponent(); exp < 0 {
+		return m.d.StringFixed(-exp)
+	}
+	return m.d.String()
+}
+
+// StringFixed rounds half-even to places and formats with exactly that many decimals
+func (m Money) StringFixed(places int32) string {
+	return m.d.RoundBank(places).StringFixed(places)
+}
+
+// MarshalJSON emits the amount as a decimal string
+func (m Money) MarshalJSON() ([]byte, error) {
+	return []byte(`"` + m.String() + `"`), nil
+}
+
+// UnmarshalJSON accepts a decimal string or a JSON number, parsed exactly from its text
+func (m *Money) UnmarshalJSON(data []byte) error {
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+
+	text := string(bytes.Trim(data, `"`))
+	parsed, err := ParseMoney(text)
+	if err != nil {
+		return err
+	}
+
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	valid := map[string]string{
+		"1.10":            "1.10",
+		"-0.5":            "-0.5",
+		"999999999999.99": "999999999999.99",
+	}
+	for in, want := range valid {
+		m, err := ParseMoney(in)
+		if err != nil {
+			t.Errorf("ParseMoney(%q): %v", in, err)
+			continue
+		}
+		if m.String() != want {
+			t.Errorf("ParseMoney(%q) = %s, want %s", in, m, want)
+		}
+	}
+
+	// Exponents would expand into every digit when formatted; the rest exceed the columns
+	for _, in := range []string{"1e100000000", "1E3", "2.5e-1", "1000000000000", "-1000000000000.00", "abc", ""} {
+		if m, err := ParseMoney(in); err == nil {
+			t.Errorf("ParseMoney(%q) = %s, want an error", in, m)
+		}
+	}
+
+	var m Money
+	if err := json.Unmarshal([]byte(`1e2000000`), &m); err == nil {
+		t.Errorf("expected an error for a JSON number in exponent notation, got %s", m)
+	}
+}
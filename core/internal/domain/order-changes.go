@@ -0,0 +1,13 @@
+package domain
+
+// OrdersChannel is the Postgres NOTIFY channel on which the consumer announces
+// committed order changes, so every API replica can drop what it cached.
+const OrdersChannel = "orders_changed"
+
+// OrderChange is the payload notified on OrdersChannel. All is set when every order
+// may have changed, such as after a projection rebuild or an exchange rate import.
+type OrderChange struct {
+	OrderCode    int64 `json:"orderCode,omitempty"`
+	CustomerCode int   `json:"customerCode,omitempty"`
+	All          bool  `json:"all,omitempty"`
+}
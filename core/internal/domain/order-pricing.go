@@ -0,0 +1,68 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPricing is returned when a discount is larger than the amount it is taken off,
+// or when the order adds up to more than its totals can store
+var ErrInvalidPricing = errors.New("invalid pricing")
+
+// MaxOrderAmount is the largest subtotal or total an order can store, NUMERIC(14, 2)
+var MaxOrderAmount = MustParseMoney("999999999999.99")
+
+// Coupon is an order-level discount, taken off after the item discounts
+type Coupon struct {
+	Code     string `json:"code"`
+	Discount Money  `json:"discount"`
+}
+
+// ValidatePricing checks each item discount against its line, the coupon against the
+// subtotal left after item discounts, and the subtotal and total against MaxOrderAmount.
+// The error is a *ProblemError wrapping ErrInvalidPricing.
+func (o *Order) ValidatePricing() error {
+	var problems []Problem
+	var subtotal, discounted Money
+
+	for _, item := range o.Items {
+		line := item.Price.Mul(item.Quantity)
+		subtotal = subtotal.Add(line)
+		if item.Discount.Cmp(line) > 0 {
+			problems = append(problems, Problem{
+				Key:     ProblemDiscountExceedsLine,
+				Params:  map[string]string{"discount": item.Discount.String(), "product": item.Product, "line": line.String()},
+				Message: fmt.Sprintf("discount %s on %q exceeds the line amount %s", item.Discount, item.Product, line),
+			})
+		}
+		discounted = discounted.Add(line.Sub(item.Discount))
+	}
+
+	if o.Coupon != nil && o.Coupon.Discount.Cmp(discounted) > 0 {
+		problems = append(problems, Problem{
+			Key:     ProblemCouponExceedsSubtotal,
+			Params:  map[string]string{"discount": o.Coupon.Discount.String(), "subtotal": discounted.String()},
+			Message: fmt.Sprintf("coupon discount %s exceeds the discounted subtotal %s", o.Coupon.Discount, discounted),
+		})
+	}
+
+	if subtotal.Round(2).Cmp(MaxOrderAmount) > 0 {
+		problems = append(problems, Problem{
+			Key:     ProblemSubtotalTooLarge,
+			Params:  map[string]string{"subtotal": subtotal.String(), "max": MaxOrderAmount.String()},
+			Message: fmt.Sprintf("subtotal %s exceeds the maximum of %s", subtotal, MaxOrderAmount),
+		})
+	}
+	if total := o.CalculateTotal(); total.Round(2).Cmp(MaxOrderAmount) > 0 {
+		problems = append(problems, Problem{
+			Key:     ProblemTotalTooLarge,
+			Params:  map[string]string{"total": total.String(), "max": MaxOrderAmount.String()},
+			Message: fmt.Sprintf("total %s exceeds the maximum of %s", total, MaxOrderAmount),
+		})
+	}
+
+	if len(problems) > 0 {
+		return &ProblemError{Err: ErrInvalidPricing, Problems: problems}
+	}
+	return nil
+}
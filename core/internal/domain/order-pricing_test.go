@@ -0,0 +1,53 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestOrderValidatePricing(t *testing.T) {
+	tests := []struct {
+		name    string
+		order   Order
+		wantErr bool
+	}{
+		{
+			name: "discounts within their lines",
+			order: Order{
+				Items:  []OrderItem{{Product: "lápis", Quantity: 2, Price: MustParseMoney("5"), Discount: MustParseMoney("4")}},
+				Coupon: &Coupon{Code: "BEMVINDO", Discount: MustParseMoney("6")},
+			},
+		},
+		{
+			name:    "item discount above the line amount",
+			order:   Order{Items: []OrderItem{{Product: "lápis", Quantity: 2, Price: MustParseMoney("5"), Discount: MustParseMoney("10.01")}}},
+			wantErr: true,
+		},
+		{
+			name:  "subtotal at the column limit",
+			order: Order{Items: []OrderItem{{Product: "lápis", Quantity: 1, Price: MustParseMoney("999999999999.99")}}},
+		},
+		{
+			name:    "subtotal beyond the column limit",
+			order:   Order{Items: []OrderItem{{Product: "lápis", Quantity: 10001, Price: MustParseMoney("99999999.99")}}},
+			wantErr: true,
+		},
+		{
+			name: "total beyond the column limit through shipping",
+			order: Order{
+				Items:    []OrderItem{{Product: "lápis", Quantity: 1, Price: MustParseMoney("999999999999.99")}},
+				Shipping: MustParseMoney("0.01"),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.order.ValidatePricing()
+			if tt.wantErr != errors.Is(err, ErrInvalidPricing) || (!tt.wantErr && err != nil) {
+				t.Errorf("ValidatePricing() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ErrInvalidReturn is returned when a return does not match what was bought
+var ErrInvalidReturn = errors.New("invalid return")
+
+// OrderReturn is published as an order.returned message.
+// ReturnID is assigned when the return is accepted and makes redeliveries idempotent.
+type OrderReturn struct {
+	ReturnID     string       `json:"returnId"`
+	OrderCode    int64        `json:"orderCode"`
+	CustomerCode int          `json:"customerCode"`
+	Reason       string       `json:"reason,omitempty"`
+	Items        []ReturnItem `json:"items"`
+	RequestedAt  time.Time    `json:"requestedAt"`
+}
+
+// ReturnItem is a returned quantity of one of the order's products
+type ReturnItem struct {
+	Product  string `json:"product"`
+	Quantity int    `json:"quantity"`
+}
+
+// ValidateReturn checks the return against the bought items and the quantities already returned.
+// The error is a *ProblemError wrapping ErrInvalidReturn, with a problem per offending line.
+func ValidateReturn(r *OrderReturn, items []OrderItem, returned map[string]int) error {
+	bought := make(map[string]int, len(items))
+	for _, item := range items {
+		bought[item.Product] += item.Quantity
+	}
+
+	var problems []Problem
+	seen := make(map[string]bool, len(r.Items))
+
+	for _, line := range r.Items {
+		if seen[line.Product] {
+			problems = append(problems, Problem{
+				Key:     ProblemDuplicateReturnLine,
+				Params:  map[string]string{"product": line.Product},
+				Message: fmt.Sprintf("product %q appears more than once", line.Product),
+			})
+			continue
+		}
+		seen[line.Product] = true
+
+		quantity, ok := bought[line.Product]
+		if !ok {
+			problems = append(problems, Problem{
+				Key:     ProblemProductNotInOrder,
+				Params:  map[string]string{"product": line.Product},
+				Message: fmt.Sprintf("product %q is not in the order", line.Product),
+			})
+			continue
+		}
+
+		if returnable := quantity - returned[line.Product]; line.Quantity > returnable {
+			problems = append(problems, Problem{
+				Key: ProblemReturnExceeded,
+				Params: map[string]string{
+					"quantity":   strconv.Itoa(line.Quantity),
+					"product":    line.Product,
+					"returnable": strconv.Itoa(returnable),
+				},
+				Message: fmt.Sprintf("returning %d of %q, only %d returnable", line.Quantity, line.Product, returnable),
+			})
+		}
+	}
+
+	if len(problems) > 0 {
+		return &ProblemError{Err: ErrInvalidReturn, Problems: problems}
+	}
+	return nil
+}
@@ -1,26 +1,121 @@
 package domain
 
 import (
+	"errors"
 	"time"
 )
 
+// DefaultCurrency is assumed for orders that do not state an ISO 4217 currency code
+const DefaultCurrency = "BRL"
+
+// Order statuses stored in the orders table
+const (
+	OrderStatusCreated   = "created"
+	OrderStatusCancelled = "cancelled"
+)
+
+var (
+	// ErrOrderNotFound is returned when no order matches the requested code
+	ErrOrderNotFound = errors.New("order not found")
+
+	// ErrOrderCancelled is returned when a change is requested for a cancelled order
+	ErrOrderCancelled = errors.New("order is cancelled")
+
+	// ErrVersionConflict is returned when the caller's expected version is not the current one
+	ErrVersionConflict = errors.New("order version conflict")
+)
+
 type Order struct {
 	CustomerCode int         `json:"customerCode"`
 	OrderCode    int64       `json:"orderCode"`
 	Items        []OrderItem `json:"items"`
+	Currency     string      `json:"currency,omitempty"`
+	Coupon       *Coupon     `json:"coupon,omitempty"`
+	Shipping     Money       `json:"shipping,omitzero"`
+	Tax          Money       `json:"tax,omitzero"`
 	CreatedAt    time.Time   `json:"createdAt"`
+	Subtotal     Money       `json:"subtotal,omitzero"`
+	Discounts    Money       `json:"discounts,omitzero"`
+	Total        Money       `json:"total,omitzero"`
+	ItemCount    int         `json:"itemCount,omitempty"`
+	Status       string      `json:"status,omitempty"`
+	Version      int         `json:"version,omitempty"`
+
+	// RefundedTotal is the sum of refunds for returned items
+	RefundedTotal Money `json:"refundedTotal,omitzero"`
+}
+
+// OrderTotals holds how the gross total of an order is composed, its refunds and the net of both.
+// Gross = Subtotal - Discounts + Shipping + Tax.
+// Amounts are in Currency; Rate is set when they were converted from the order's currency.
+type OrderTotals struct {
+	Currency  string
+	Subtotal  string
+	Discounts string
+	Shipping  string
+	Tax       string
+	Gross     string
+	Refunded  string
+	Net       string
+	Rate      *ExchangeRate
 }
 
 type OrderItem struct {
-	Product  string  `json:"product"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
+	SKU      string `json:"sku,omitempty"`
+	Product  string `json:"product"`
+	Quantity int    `json:"quantity"`
+	Price    Money  `json:"price"`
+
+	// Discount is taken off the whole line, not off each unit
+	Discount Money `json:"discount,omitzero"`
+
+	// PriceFlagged marks a price outside the tolerance around the catalog list price
+	PriceFlagged bool `json:"priceFlagged,omitempty"`
+}
+
+// OrderCancellation is published as an order.cancelled message
+type OrderCancellation struct {
+	OrderCode       int64     `json:"orderCode"`
+	CustomerCode    int       `json:"customerCode"`
+	Reason          string    `json:"reason,omitempty"`
+	ExpectedVersion int       `json:"expectedVersion,omitempty"`
+	RequestedAt     time.Time `json:"requestedAt"`
 }
 
-func (o *Order) CalculateTotal() float64 {
-	var total float64
+// OrderAmendment is published as an order.amended message.
+// Items are matched by product name.
+type OrderAmendment struct {
+	OrderCode       int64       `json:"orderCode"`
+	CustomerCode    int         `json:"customerCode"`
+	ExpectedVersion int         `json:"expectedVersion,omitempty"`
+	AddItems        []OrderItem `json:"addItems,omitempty"`
+	RemoveProducts  []string    `json:"removeProducts,omitempty"`
+	ChangeItems     []OrderItem `json:"changeItems,omitempty"`
+	RequestedAt     time.Time   `json:"requestedAt"`
+}
+
+// CheckVersion returns ErrVersionConflict when the caller expected a different version.
+// An expected version of zero skips the check.
+func (o *Order) CheckVersion(expected int) error {
+	if expected != 0 && expected != o.Version {
+		return ErrVersionConflict
+	}
+	return nil
+}
+
+// NetTotal is the stored total minus refunds
+func (o *Order) NetTotal() Money {
+	return o.Total.Sub(o.RefundedTotal)
+}
+
+// CalculateTotal returns subtotal - discounts + shipping + tax over the items, exactly
+func (o *Order) CalculateTotal() Money {
+	var total Money
 	for _, item := range o.Items {
-		total += float64(item.Quantity) * item.Price
+		total = total.Add(item.Price.Mul(item.Quantity)).Sub(item.Discount)
+	}
+	if o.Coupon != nil {
+		total = total.Sub(o.Coupon.Discount)
 	}
-	return total
+	return total.Add(o.Shipping).Add(o.Tax)
 }
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+)
+
+// Scopes granted to API keys and tokens
+const (
+	ScopeOrdersWrite = "orders:write"
+	ScopeOrdersRead  = "orders:read"
+	ScopeReportsRead = "reports:read"
+	ScopeAdmin       = "admin"
+)
+
+// KnownScopes lists every scope a credential may be granted
+var KnownScopes = []string{ScopeOrdersWrite, ScopeOrdersRead, ScopeReportsRead, ScopeAdmin}
+
+// Authentication methods a principal may come from
+const (
+	AuthMethodAPIKey = "api_key"
+	AuthMethodJWT    = "jwt"
+)
+
+var (
+	// ErrInvalidCredentials is returned when an API key or token is unknown, inactive, expired or forged
+	ErrInvalidCredentials = errors.New("invalid credentials")
+
+	// ErrUnknownScope is returned when a credential is created with a scope the API does not define
+	ErrUnknownScope = errors.New("unknown scope")
+)
+
+// Principal is the authenticated caller of a request. A principal bound to
+// CustomerCodes, such as a customer portal token, may only see those customers
+// and their orders; without codes it is unrestricted.
+type Principal struct {
+	Subject       string
+	Method        string
+	Scopes        []string
+	CustomerCodes []int
+}
+
+// HasScope reports whether the principal was granted a scope
+func (p *Principal) HasScope(scope string) bool {
+	return slices.Contains(p.Scopes, scope)
+}
+
+// IsCustomerBound reports whether the principal is restricted to its own customers
+func (p *Principal) IsCustomerBound() bool {
+	return len(p.CustomerCodes) > 0
+}
+
+// CanAccessCustomer reports whether the principal may see a customer and its orders
+func (p *Principal) CanAccessCustomer(customerCode int) bool {
+	return !p.IsCustomerBound() || slices.Contains(p.CustomerCodes, customerCode)
+}
+
+type principalKey struct{}
+
+// ContextWithPrincipal returns a copy of ctx carrying the authenticated principal
+func ContextWithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// CanAccessCustomer applies the customer binding of the request's principal.
+// Requests without a principal, as when authentication is disabled, are unrestricted.
+func CanAccessCustomer(ctx context.Context, customerCode int) bool {
+	principal, ok := PrincipalFromContext(ctx)
+	return !ok || principal.CanAccessCustomer(customerCode)
+}
+
+// PrincipalFromContext returns the principal authenticated for the request, if any
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*Principal)
+	return principal, ok
+}
@@ -0,0 +1,61 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+)
+
+// Keys of the messages describing each problem in the message catalog
+const (
+	ProblemDiscountExceedsLine   = "detail.discount_exceeds_line"
+	ProblemCouponExceedsSubtotal = "detail.coupon_exceeds_subtotal"
+	ProblemSubtotalTooLarge      = "detail.subtotal_too_large"
+	ProblemTotalTooLarge         = "detail.total_too_large"
+	ProblemDuplicateReturnLine   = "detail.duplicate_return_line"
+	ProblemProductNotInOrder     = "detail.product_not_in_order"
+	ProblemReturnExceeded        = "detail.return_exceeded"
+	ProblemInvalidCurrencyPair   = "detail.invalid_currency_pair"
+	ProblemSameCurrency          = "detail.same_currency"
+	ProblemNonPositiveRate       = "detail.non_positive_rate"
+	ProblemNoEffectiveDate       = "detail.no_effective_date"
+	ProblemFxRateNotFound        = "detail.fx_rate_not_found"
+	ProblemInvalidRate           = "detail.invalid_rate"
+	ProblemInvalidEffectiveDate  = "detail.invalid_effective_date"
+)
+
+// Problem is one way a request breaks a business rule. Key names the message describing
+// it in the message catalog, whose {name} placeholders Params fill; Message is the same
+// text in English.
+type Problem struct {
+	Key     string
+	Params  map[string]string
+	Message string
+}
+
+// ProblemError wraps one of the sentinel errors with every problem found, so callers
+// can match the sentinel with errors.Is and still explain each problem
+type ProblemError struct {
+	Err      error
+	Problems []Problem
+}
+
+func (e *ProblemError) Error() string {
+	messages := make([]string, len(e.Problems))
+	for i, problem := range e.Problems {
+		messages[i] = problem.Message
+	}
+	return e.Err.Error() + ": " + strings.Join(messages, "; ")
+}
+
+func (e *ProblemError) Unwrap() error {
+	return e.Err
+}
+
+// Problems returns the problems carried by err, nil when it wraps no *ProblemError
+func Problems(err error) []Problem {
+	var problemErr *ProblemError
+	if errors.As(err, &problemErr) {
+		return problemErr.Problems
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// DefaultUnit is used for products created without a unit of measure
+const DefaultUnit = "un"
+
+var (
+	// ErrProductNotFound is returned when no product matches the requested SKU
+	ErrProductNotFound = errors.New("product not found")
+
+	// ErrProductExists is returned when a product is created with a SKU already in the catalog
+	ErrProductExists = errors.New("product already exists")
+)
+
+// Product is a catalog entry that order items reference through its SKU.
+// Inactive products stay in the catalog for past orders but cannot be ordered.
+type Product struct {
+	SKU       string
+	Name      string
+	Unit      string
+	ListPrice Money
+	Active    bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NormalizeSKU trims and upper-cases a SKU so lookups do not depend on how it was typed
+func NormalizeSKU(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
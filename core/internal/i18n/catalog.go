@@ -0,0 +1,308 @@
+package i18n
+
+import (
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+)
+
+// Validation message keys, one per validator tag. {param} is replaced by the tag parameter.
+const (
+	validationPrefix = "validation."
+	ParamPlaceholder = "{param}"
+)
+
+// ValidationKey returns the catalog key of the message for a validator tag
+func ValidationKey(tag string) string {
+	return validationPrefix + tag
+}
+
+// catalog holds the messages of each language, keyed by error and success codes,
+// the keys of internal errors and the validation keys. English error messages are
+// the constants themselves.
+var catalog = map[string]map[string]string{
+	English: {
+		// Common errors
+		constants.CodeInvalidRequest:   constants.MsgInvalidRequestBody,
+		constants.CodeInternalError:    constants.MsgInternalError,
+		constants.CodeValidationFailed: constants.MsgValidationFailed,
+		constants.CodeNotFound:         constants.MsgNotFound,
+		constants.CodeMethodNotAllowed: constants.MsgMethodNotAllowed,
+		constants.CodeUnauthorized:     constants.MsgUnauthorized,
+		constants.CodeForbidden:        constants.MsgForbidden,
+		constants.CodeRateLimited:      constants.MsgRateLimited,
+		constants.CodePayloadTooLarge:  constants.MsgPayloadTooLarge,
+		constants.CodeUnsupportedMedia: constants.MsgUnsupportedMedia,
+
+		// Order errors
+		constants.CodeOrderNotFound:            constants.MsgOrderNotFound,
+		constants.CodeInvalidOrderCode:         constants.MsgInvalidOrderCode,
+		constants.CodeInvalidCustomerCode:      constants.MsgInvalidCustomerCode,
+		constants.CodeOrderCancelled:           constants.MsgOrderCancelled,
+		constants.CodeVersionConflict:          constants.MsgVersionConflict,
+		constants.CodeEmptyAmendment:           constants.MsgEmptyAmendment,
+		constants.CodeInvalidReturn:            constants.MsgInvalidReturn,
+		constants.CodeInvalidPricing:           constants.MsgInvalidPricing,
+		constants.KeyFailedToCreateOrder:       constants.MsgFailedToCreateOrder,
+		constants.KeyFailedToGetOrder:          constants.MsgFailedToGetOrder,
+		constants.KeyFailedToGetOrderTotal:     constants.MsgFailedToGetOrderTotal,
+		constants.KeyFailedToListOrders:        constants.MsgFailedToListOrders,
+		constants.KeyFailedToCountOrders:       constants.MsgFailedToCountOrders,
+		constants.KeyFailedToGetSummary:        constants.MsgFailedToGetSummary,
+		constants.KeyFailedToCancelOrder:       constants.MsgFailedToCancelOrder,
+		constants.KeyFailedToAmendOrder:        constants.MsgFailedToAmendOrder,
+		constants.KeyFailedToReturnOrder:       constants.MsgFailedToReturnOrder,
+		constants.CodeInvalidCurrency:          constants.MsgInvalidCurrency,
+		constants.CodeFxRateNotFound:           constants.MsgFxRateNotFound,
+		constants.CodeInvalidFxRate:            constants.MsgInvalidFxRate,
+		constants.KeyFailedToImportFxRates:     constants.MsgFailedToImportFxRates,
+		constants.CodeProductNotFound:          constants.MsgProductNotFound,
+		constants.CodeProductExists:            constants.MsgProductExists,
+		constants.CodeInvalidSKU:               constants.MsgInvalidSKU,
+		constants.KeyFailedToCreateProduct:     constants.MsgFailedToCreateProduct,
+		constants.KeyFailedToGetProduct:        constants.MsgFailedToGetProduct,
+		constants.KeyFailedToListProducts:      constants.MsgFailedToListProducts,
+		constants.KeyFailedToUpdateProduct:     constants.MsgFailedToUpdateProduct,
+		constants.KeyFailedToDeactivateProduct: constants.MsgFailedToDeactivateProduct,
+		constants.CodeCustomerNotFound:         constants.MsgCustomerNotFound,
+		constants.CodeCustomerExists:           constants.MsgCustomerExists,
+		constants.CodeCustomerBlocked:          constants.MsgCustomerBlocked,
+		constants.CodeUnknownCustomer:          constants.MsgUnknownCustomer,
+		constants.CodeInvalidDocument:          constants.MsgInvalidDocument,
+		constants.KeyFailedToCreateCustomer:    constants.MsgFailedToCreateCustomer,
+		constants.KeyFailedToGetCustomer:       constants.MsgFailedToGetCustomer,
+		constants.KeyFailedToListCustomers:     constants.MsgFailedToListCustomers,
+		constants.KeyFailedToUpdateCustomer:    constants.MsgFailedToUpdateCustomer,
+		constants.CodeInvalidLogLevel:          constants.MsgInvalidLogLevel,
+		constants.CodeLogLevelNotFound:         constants.MsgLogLevelNotFound,
+		constants.CodeInvalidAuditFilter:       constants.MsgInvalidAuditFilter,
+		constants.KeyFailedToListAuditEntries:  constants.MsgFailedToListAuditEntries,
+		constants.KeyFailedToVerifyAuditLog:    constants.MsgFailedToVerifyAuditLog,
+
+		// Successes
+		constants.CodeOrderCreated:              "Order accepted for processing",
+		constants.CodeOrderFound:                "Order found",
+		constants.CodeOrdersListed:              "Orders listed",
+		constants.CodeOrderCounted:              "Orders counted",
+		constants.CodeCustomerSummaryFound:      "Customer summary found",
+		constants.CodeOrderCancellationAccepted: "Cancellation accepted for processing",
+		constants.CodeOrderAmendmentAccepted:    "Amendment accepted for processing",
+		constants.CodeOrderReturnAccepted:       "Return accepted for processing",
+		constants.CodeFxRatesImported:           "Exchange rates imported",
+		constants.CodeProductCreated:            "Product created",
+		constants.CodeProductFound:              "Product found",
+		constants.CodeProductsListed:            "Products listed",
+		constants.CodeProductUpdated:            "Product updated",
+		constants.CodeProductDeactivated:        "Product deactivated",
+		constants.CodeCustomerCreated:           "Customer created",
+		constants.CodeCustomerFound:             "Customer found",
+		constants.CodeCustomersListed:           "Customers listed",
+		constants.CodeCustomerUpdated:           "Customer updated",
+		constants.CodeLogLevelsListed:           "Log levels listed",
+		constants.CodeLogLevelUpdated:           "Log level updated",
+		constants.CodeLogLevelReset:             "Log level reset",
+		constants.CodeAuditEntriesListed:        "Audit entries listed",
+		constants.CodeAuditLogVerified:          "Audit log checked",
+
+		// Validation
+		ValidationKey("required"):          "This field is required",
+		ValidationKey("required_without"):  "This field is required when " + ParamPlaceholder + " is missing",
+		ValidationKey("min"):               "Value is too short or too small",
+		ValidationKey("max"):               "Value is too long or too large",
+		ValidationKey("gt"):                "Value must be greater than " + ParamPlaceholder,
+		ValidationKey("gte"):               "Value must be greater than or equal to " + ParamPlaceholder,
+		ValidationKey("lt"):                "Value must be less than " + ParamPlaceholder,
+		ValidationKey("lte"):               "Value must be less than or equal to " + ParamPlaceholder,
+		ValidationKey("email"):             "Invalid email format",
+		ValidationKey("url"):               "Invalid URL format",
+		ValidationKey("uuid"):              "Invalid UUID format",
+		ValidationKey("datetime"):          "Value must be a date in the format " + ParamPlaceholder,
+		ValidationKey("iso4217"):           "Value must be an ISO 4217 currency code",
+		ValidationKey("money_positive"):    "Value must be a decimal greater than 0",
+		ValidationKey("money_nonnegative"): "Value must be a decimal greater than or equal to 0",
+		ValidationKey("money_decimals"):    "Value must have at most " + ParamPlaceholder + " decimal places",
+		ValidationKey("money_max"):         "Value must be at most " + ParamPlaceholder,
+		ValidationKey("cpf"):               "Invalid CPF",
+		ValidationKey("cnpj"):              "Invalid CNPJ",
+		ValidationKey("cpf_cnpj"):          "Invalid CPF or CNPJ",
+
+		// Details
+		constants.DetailBodyTooLarge:         "request body must not exceed {limit} bytes",
+		constants.DetailContentTypeJSON:      "Content-Type must be application/json",
+		constants.DetailContentTypeJSONOrCSV: "Content-Type must be application/json or text/csv",
+		constants.DetailBodyRequired:         "request body is required",
+		constants.DetailSingleDocument:       "request body must contain a single JSON document",
+		constants.DetailMalformedJSON:        "malformed JSON at offset {offset}",
+		constants.DetailTruncatedJSON:        "malformed JSON: the body ends unexpectedly",
+		constants.DetailBodyNotObject:        "request body must be a JSON object",
+		constants.DetailWrongFieldType:       "field \"{field}\" has the wrong type",
+		constants.DetailUnknownField:         "unknown field \"{field}\"",
+		constants.DetailUndecodableBody:      "request body could not be decoded",
+		constants.DetailOriginNotAllowed:     "Origin not allowed",
+		constants.DetailUnknownLogLevel:      "unknown level {level}",
+		constants.DetailInvalidRevertDelay:   "invalid revert delay {delay}",
+		constants.DetailAuditFormat:          "format must be json or csv",
+		constants.DetailAuditOutcome:         "outcome must be success, denied or failure",
+		constants.DetailAuditTime:            "{name} must be an RFC 3339 time",
+		constants.DetailAuditCursor:          "after must be an entry id",
+		constants.DetailAuditLimit:           "limit must be from 1 to {max}",
+		constants.DetailCSVHeader:            "invalid csv header: expected {header}",
+		constants.DetailMalformedCSV:         "invalid csv on line {line}",
+		domain.ProblemInvalidRate:            "invalid rate \"{rate}\"",
+		domain.ProblemInvalidEffectiveDate:   "invalid effective date \"{date}\"",
+		domain.ProblemDiscountExceedsLine:    "discount {discount} on \"{product}\" exceeds the line amount {line}",
+		domain.ProblemCouponExceedsSubtotal:  "coupon discount {discount} exceeds the discounted subtotal {subtotal}",
+		domain.ProblemSubtotalTooLarge:       "subtotal {subtotal} exceeds the maximum of {max}",
+		domain.ProblemTotalTooLarge:          "total {total} exceeds the maximum of {max}",
+		domain.ProblemDuplicateReturnLine:    "product \"{product}\" appears more than once",
+		domain.ProblemProductNotInOrder:      "product \"{product}\" is not in the order",
+		domain.ProblemReturnExceeded:         "returning {quantity} of \"{product}\", only {returnable} returnable",
+		domain.ProblemInvalidCurrencyPair:    "{base}/{quote} is not a pair of ISO 4217 codes",
+		domain.ProblemSameCurrency:           "{base}/{quote} converts a currency to itself",
+		domain.ProblemNonPositiveRate:        "{base}/{quote} rate must be greater than 0",
+		domain.ProblemNoEffectiveDate:        "{base}/{quote} has no effective date",
+		domain.ProblemFxRateNotFound:         "no {base}/{quote} rate effective on {date}",
+	},
+
+	Portuguese: {
+		// Common errors
+		constants.CodeInvalidRequest:   "Corpo da requisição inválido",
+		constants.CodeInternalError:    "Ocorreu um erro interno",
+		constants.CodeValidationFailed: "Falha na validação",
+		constants.CodeNotFound:         "Recurso não encontrado",
+		constants.CodeMethodNotAllowed: "Método não permitido para este recurso",
+		constants.CodeUnauthorized:     "É necessária uma chave de API ou um token de acesso válido",
+		constants.CodeForbidden:        "As credenciais usadas não têm o escopo exigido por esta operação",
+		constants.CodeRateLimited:      "Muitas requisições, tente novamente após o tempo indicado em Retry-After",
+		constants.CodePayloadTooLarge:  "O corpo da requisição é grande demais",
+		constants.CodeUnsupportedMedia: "Tipo de conteúdo da requisição não suportado",
+
+		// Order errors
+		constants.CodeOrderNotFound:            "Pedido não encontrado",
+		constants.CodeInvalidOrderCode:         "O código do pedido deve ser um inteiro positivo",
+		constants.CodeInvalidCustomerCode:      "O código do cliente deve ser um inteiro positivo",
+		constants.CodeOrderCancelled:           "O pedido está cancelado e não pode mais ser alterado",
+		constants.CodeVersionConflict:          "O pedido foi modificado desde a versão esperada",
+		constants.CodeEmptyAmendment:           "A alteração deve adicionar, remover ou alterar ao menos um item",
+		constants.CodeInvalidReturn:            "A devolução não corresponde aos itens comprados",
+		constants.CodeInvalidPricing:           "Os descontos excedem os valores sobre os quais incidem",
+		constants.KeyFailedToCreateOrder:       "Falha ao criar o pedido",
+		constants.KeyFailedToGetOrder:          "Falha ao consultar o pedido",
+		constants.KeyFailedToGetOrderTotal:     "Falha ao consultar o total do pedido",
+		constants.KeyFailedToListOrders:        "Falha ao listar os pedidos",
+		constants.KeyFailedToCountOrders:       "Falha ao contar os pedidos",
+		constants.KeyFailedToGetSummary:        "Falha ao consultar o resumo do cliente",
+		constants.KeyFailedToCancelOrder:       "Falha ao cancelar o pedido",
+		constants.KeyFailedToAmendOrder:        "Falha ao alterar o pedido",
+		constants.KeyFailedToReturnOrder:       "Falha ao devolver os itens do pedido",
+		constants.CodeInvalidCurrency:          "A moeda deve ser um código ISO 4217, como BRL ou USD",
+		constants.CodeFxRateNotFound:           "Nenhuma taxa de câmbio vigente na data do pedido",
+		constants.CodeInvalidFxRate:            "Taxa de câmbio inválida",
+		constants.KeyFailedToImportFxRates:     "Falha ao importar as taxas de câmbio",
+		constants.CodeProductNotFound:          "Produto não encontrado",
+		constants.CodeProductExists:            "Já existe um produto com este SKU",
+		constants.CodeInvalidSKU:               "O SKU não pode ficar em branco",
+		constants.KeyFailedToCreateProduct:     "Falha ao criar o produto",
+		constants.KeyFailedToGetProduct:        "Falha ao consultar o produto",
+		constants.KeyFailedToListProducts:      "Falha ao listar os produtos",
+		constants.KeyFailedToUpdateProduct:     "Falha ao atualizar o produto",
+		constants.KeyFailedToDeactivateProduct: "Falha ao desativar o produto",
+		constants.CodeCustomerNotFound:         "Cliente não encontrado",
+		constants.CodeCustomerExists:           "Já existe um cliente com este código ou documento",
+		constants.CodeCustomerBlocked:          "O cliente está bloqueado e não pode fazer pedidos",
+		constants.CodeUnknownCustomer:          "O cliente não está cadastrado",
+		constants.CodeInvalidDocument:          "O documento deve ser um CPF ou CNPJ válido",
+		constants.KeyFailedToCreateCustomer:    "Falha ao cadastrar o cliente",
+		constants.KeyFailedToGetCustomer:       "Falha ao consultar o cliente",
+		constants.KeyFailedToListCustomers:     "Falha ao listar os clientes",
+		constants.KeyFailedToUpdateCustomer:    "Falha ao atualizar o cliente",
+		constants.CodeInvalidLogLevel:          "O nível deve ser debug, info, warn ou error, e o prazo de reversão uma duração positiva",
+		constants.CodeLogLevelNotFound:         "O logger não tem um nível próprio",
+		constants.CodeInvalidAuditFilter:       "Os filtros de auditoria devem ter horários RFC 3339, um resultado conhecido, um cursor positivo e um limite de 1 a 1000",
+		constants.KeyFailedToListAuditEntries:  "Falha ao listar os registros de auditoria",
+		constants.KeyFailedToVerifyAuditLog:    "Falha ao verificar o log de auditoria",
+
+		// Successes
+		constants.CodeOrderCreated:              "Pedido recebido para processamento",
+		constants.CodeOrderFound:                "Pedido encontrado",
+		constants.CodeOrdersListed:              "Pedidos listados",
+		constants.CodeOrderCounted:              "Pedidos contados",
+		constants.CodeCustomerSummaryFound:      "Resumo do cliente encontrado",
+		constants.CodeOrderCancellationAccepted: "Cancelamento recebido para processamento",
+		constants.CodeOrderAmendmentAccepted:    "Alteração recebida para processamento",
+		constants.CodeOrderReturnAccepted:       "Devolução recebida para processamento",
+		constants.CodeFxRatesImported:           "Taxas de câmbio importadas",
+		constants.CodeProductCreated:            "Produto criado",
+		constants.CodeProductFound:              "Produto encontrado",
+		constants.CodeProductsListed:            "Produtos listados",
+		constants.CodeProductUpdated:            "Produto atualizado",
+		constants.CodeProductDeactivated:        "Produto desativado",
+		constants.CodeCustomerCreated:           "Cliente cadastrado",
+		constants.CodeCustomerFound:             "Cliente encontrado",
+		constants.CodeCustomersListed:           "Clientes listados",
+		constants.CodeCustomerUpdated:           "Cliente atualizado",
+		constants.CodeLogLevelsListed:           "Níveis de log listados",
+		constants.CodeLogLevelUpdated:           "Nível de log atualizado",
+		constants.CodeLogLevelReset:             "Nível de log redefinido",
+		constants.CodeAuditEntriesListed:        "Registros de auditoria listados",
+		constants.CodeAuditLogVerified:          "Log de auditoria verificado",
+
+		// Validation
+		ValidationKey("required"):          "Este campo é obrigatório",
+		ValidationKey("required_without"):  "Este campo é obrigatório quando " + ParamPlaceholder + " não é informado",
+		ValidationKey("min"):               "Valor muito curto ou muito pequeno",
+		ValidationKey("max"):               "Valor muito longo ou muito grande",
+		ValidationKey("gt"):                "O valor deve ser maior que " + ParamPlaceholder,
+		ValidationKey("gte"):               "O valor deve ser maior ou igual a " + ParamPlaceholder,
+		ValidationKey("lt"):                "O valor deve ser menor que " + ParamPlaceholder,
+		ValidationKey("lte"):               "O valor deve ser menor ou igual a " + ParamPlaceholder,
+		ValidationKey("email"):             "Formato de e-mail inválido",
+		ValidationKey("url"):               "Formato de URL inválido",
+		ValidationKey("uuid"):              "Formato de UUID inválido",
+		ValidationKey("datetime"):          "O valor deve ser uma data no formato " + ParamPlaceholder,
+		ValidationKey("iso4217"):           "O valor deve ser um código de moeda ISO 4217",
+		ValidationKey("money_positive"):    "O valor deve ser um decimal maior que 0",
+		ValidationKey("money_nonnegative"): "O valor deve ser um decimal maior ou igual a 0",
+		ValidationKey("money_decimals"):    "O valor deve ter no máximo " + ParamPlaceholder + " casas decimais",
+		ValidationKey("money_max"):         "O valor deve ser no máximo " + ParamPlaceholder,
+		ValidationKey("cpf"):               "CPF inválido",
+		ValidationKey("cnpj"):              "CNPJ inválido",
+		ValidationKey("cpf_cnpj"):          "CPF ou CNPJ inválido",
+
+		// Details
+		constants.DetailBodyTooLarge:         "o corpo da requisição não deve exceder {limit} bytes",
+		constants.DetailContentTypeJSON:      "O Content-Type deve ser application/json",
+		constants.DetailContentTypeJSONOrCSV: "O Content-Type deve ser application/json ou text/csv",
+		constants.DetailBodyRequired:         "o corpo da requisição é obrigatório",
+		constants.DetailSingleDocument:       "o corpo da requisição deve conter um único documento JSON",
+		constants.DetailMalformedJSON:        "JSON malformado na posição {offset}",
+		constants.DetailTruncatedJSON:        "JSON malformado: o corpo termina inesperadamente",
+		constants.DetailBodyNotObject:        "o corpo da requisição deve ser um objeto JSON",
+		constants.DetailWrongFieldType:       "o campo \"{field}\" tem o tipo errado",
+		constants.DetailUnknownField:         "campo desconhecido \"{field}\"",
+		constants.DetailUndecodableBody:      "não foi possível decodificar o corpo da requisição",
+		constants.DetailOriginNotAllowed:     "Origem não permitida",
+		constants.DetailUnknownLogLevel:      "nível desconhecido {level}",
+		constants.DetailInvalidRevertDelay:   "prazo de reversão inválido {delay}",
+		constants.DetailAuditFormat:          "format deve ser json ou csv",
+		constants.DetailAuditOutcome:         "outcome deve ser success, denied ou failure",
+		constants.DetailAuditTime:            "{name} deve ser um horário RFC 3339",
+		constants.DetailAuditCursor:          "after deve ser o id de um registro",
+		constants.DetailAuditLimit:           "limit deve estar entre 1 e {max}",
+		constants.DetailCSVHeader:            "cabeçalho csv inválido: esperado {header}",
+		constants.DetailMalformedCSV:         "csv inválido na linha {line}",
+		domain.ProblemInvalidRate:            "taxa inválida \"{rate}\"",
+		domain.ProblemInvalidEffectiveDate:   "data de vigência inválida \"{date}\"",
+		domain.ProblemDiscountExceedsLine:    "o desconto {discount} em \"{product}\" excede o valor da linha {line}",
+		domain.ProblemCouponExceedsSubtotal:  "o desconto do cupom {discount} excede o subtotal com descontos {subtotal}",
+		domain.ProblemSubtotalTooLarge:       "o subtotal {subtotal} excede o máximo de {max}",
+		domain.ProblemTotalTooLarge:          "o total {total} excede o máximo de {max}",
+		domain.ProblemDuplicateReturnLine:    "o produto \"{product}\" aparece mais de uma vez",
+		domain.ProblemProductNotInOrder:      "o produto \"{product}\" não está no pedido",
+		domain.ProblemReturnExceeded:         "devolvendo {quantity} de \"{product}\", apenas {returnable} podem ser devolvidos",
+		domain.ProblemInvalidCurrencyPair:    "{base}/{quote} não é um par de códigos ISO 4217",
+		domain.ProblemSameCurrency:           "{base}/{quote} converte uma moeda nela mesma",
+		domain.ProblemNonPositiveRate:        "a taxa de {base}/{quote} deve ser maior que 0",
+		domain.ProblemNoEffectiveDate:        "{base}/{quote} não tem data de vigência",
+		domain.ProblemFxRateNotFound:         "nenhuma taxa {base}/{quote} vigente em {date}",
+	},
+}
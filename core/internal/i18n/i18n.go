@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Languages the API answers in, as BCP 47 tags
+const (
+	English    = "en"
+	Portuguese = "pt-BR"
+)
+
+// Supported lists every language with a message catalog
+var Supported = []string{English, Portuguese}
+
+type languageKey struct{}
+
+// ParseLanguage resolves a configured language tag, such as "pt-br" or "en-US",
+// to a supported language
+func ParseLanguage(tag string) (string, error) {
+	if language, ok := match(tag); ok {
+		return language, nil
+	}
+	return "", fmt.Errorf("unsupported language %q, expected one of %s", tag, strings.Join(Supported, ", "))
+}
+
+// Negotiate picks the supported language the client prefers in an Accept-Language
+// header, honouring q-values, or fallback when none is acceptable
+func Negotiate(acceptLanguage, fallback string) string {
+	type preference struct {
+		tag    string
+		weight float64
+	}
+
+	var preferences []preference
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(entry), ";")
+		weight := 1.0
+		if q, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(q, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+		if tag != "" && weight > 0 {
+			preferences = append(preferences, preference{tag: tag, weight: weight})
+		}
+	}
+
+	// A stable sort keeps the client's order among equal weights
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].weight > preferences[j].weight
+	})
+
+	for _, p := range preferences {
+		if p.tag == "*" {
+			return fallback
+		}
+		if language, ok := match(p.tag); ok {
+			return language
+		}
+	}
+	return fallback
+}
+
+// match resolves a language tag by its primary subtag, so "pt", "pt-PT" and
+// "pt-BR" all select Portuguese
+func match(tag string) (string, bool) {
+	primary, _, _ := strings.Cut(strings.ToLower(strings.TrimSpace(tag)), "-")
+	for _, language := range Supported {
+		supportedPrimary, _, _ := strings.Cut(strings.ToLower(language), "-")
+		if primary == supportedPrimary {
+			return language, true
+		}
+	}
+	return "", false
+}
+
+// WithLanguage returns a copy of ctx carrying the language of the response
+func WithLanguage(ctx context.Context, language string) context.Context {
+	return context.WithValue(ctx, languageKey{}, language)
+}
+
+// FromContext returns the language negotiated for the request, English when none was
+func FromContext(ctx context.Context) string {
+	if language, ok := ctx.Value(languageKey{}).(string); ok && slices.Contains(Supported, language) {
+		return language
+	}
+	return English
+}
+
+// Message looks a key up in the catalog of a language, falling back to English
+// and then to the given text when the key has no translation
+func Message(language, key, fallback string) string {
+	if message, ok := catalog[language][key]; ok {
+		return message
+	}
+	if message, ok := catalog[English][key]; ok {
+		return message
+	}
+	return fallback
+}
+
+// Format looks a key up like Message and fills its {name} placeholders with params
+func Format(language, key, fallback string, params map[string]string) string {
+	message := Message(language, key, fallback)
+	if len(params) == 0 {
+		return message
+	}
+
+	replacements := make([]string, 0, 2*len(params))
+	for name, value := range params {
+		replacements = append(replacements, "{"+name+"}", value)
+	}
+	return strings.NewReplacer(replacements...).Replace(message)
+}
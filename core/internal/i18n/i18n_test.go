@@ -0,0 +1,46 @@
+package i18n
+
+import "testing"
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", English},
+		{"pt-BR", Portuguese},
+		{"pt", Portuguese},
+		{"pt-PT,en;q=0.8", Portuguese},
+		{"en-US,pt-BR;q=0.9", English},
+		{"fr-FR, pt-BR;q=0.5, en;q=0.4", Portuguese},
+		{"en;q=0.2, pt-BR;q=0.7", Portuguese},
+		{"pt-BR;q=0, en", English},
+		{"fr, de", English},
+		{"*", English},
+	}
+
+	for _, tt := range tests {
+		if got := Negotiate(tt.acceptLanguage, English); got != tt.want {
+			t.Errorf("Accept-Language %q: expected %s, got %s", tt.acceptLanguage, tt.want, got)
+		}
+	}
+
+	if got := Negotiate("fr", Portuguese); got != Portuguese {
+		t.Errorf("expected the fallback %s, got %s", Portuguese, got)
+	}
+}
+
+func TestCatalogsHaveTheSameKeys(t *testing.T) {
+	for _, language := range Supported {
+		for key := range catalog[English] {
+			if _, ok := catalog[language][key]; !ok {
+				t.Errorf("%s has no message for %s", language, key)
+			}
+		}
+		for key := range catalog[language] {
+			if _, ok := catalog[English][key]; !ok {
+				t.Errorf("%s has a message for %s, which English lacks", language, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cacheRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_requests_total",
+			Help: "Total number of cache lookups by result (hit or miss)",
+		},
+		[]string{"cache", "result"},
+	)
+
+	cacheEvictionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "cache_evictions_total",
+			Help: "Total number of entries evicted to respect the cache size",
+		},
+		[]string{"cache"},
+	)
+
+	cacheEntries = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cache_entries",
+			Help: "Number of entries currently held in the cache",
+		},
+		[]string{"cache"},
+	)
+)
+
+// LRU is a size-bounded cache safe for concurrent use. Entries expire after the TTL
+// and the least recently used one is evicted when a new key does not fit.
+type LRU[K comparable, V any] struct {
+	name  string
+	size  int
+	ttl   time.Duration
+	now   func() time.Time
+	mu    sync.Mutex
+	items map[K]*list.Element
+	order *list.List
+}
+
+type entry[K comparable, V any] struct {
+	key       K
+	value     V
+	expiresAt time.Time
+}
+
+// NewLRU creates a cache holding at most size entries for ttl each.
+// The name labels its Prometheus metrics.
+func NewLRU[K comparable, V any](name string, size int, ttl time.Duration) *LRU[K, V] {
+	return &LRU[K, V]{
+		name:  name,
+		size:  max(size, 1),
+		ttl:   ttl,
+		now:   time.Now,
+		items: make(map[K]*list.Element),
+		order: list.New(),
+	}
+}
+
+// Get returns the value cached for key, if present and not expired
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		if c.now().Before(e.expiresAt) {
+			c.order.MoveToFront(elem)
+			cacheRequestsTotal.WithLabelValues(c.name, "hit").Inc()
+			return e.value, true
+		}
+		c.removeElement(elem)
+	}
+
+	cacheRequestsTotal.WithLabelValues(c.name, "miss").Inc()
+	var zero V
+	return zero, false
+}
+
+// Add caches value for key, evicting the least recently used entry when full
+func (c *LRU[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(c.ttl)
+	if elem, ok := c.items[key]; ok {
+		e := elem.Value.(*entry[K, V])
+		e.value, e.expiresAt = value, expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.items[key] = c.order.PushFront(&entry[K, V]{key: key, value: value, expiresAt: expiresAt})
+	if c.order.Len() > c.size {
+		c.removeElement(c.order.Back())
+		cacheEvictionsTotal.WithLabelValues(c.name).Inc()
+	}
+	cacheEntries.WithLabelValues(c.name).Set(float64(c.order.Len()))
+}
+
+// Remove drops the entry cached for key
+func (c *LRU[K, V]) Remove(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.removeElement(elem)
+	}
+}
+
+// RemoveFunc drops every entry whose key matches
+func (c *LRU[K, V]) RemoveFunc(match func(K) bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key, elem := range c.items {
+		if match(key) {
+			c.removeElement(elem)
+		}
+	}
+}
+
+// Purge drops every entry
+func (c *LRU[K, V]) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	clear(c.items)
+	c.order.Init()
+	cacheEntries.WithLabelValues(c.name).Set(0)
+}
+
+// Len returns the number of entries held, expired ones included until they are looked up
+func (c *LRU[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// removeElement unlinks an entry. The caller holds the lock.
+func (c *LRU[K, V]) removeElement(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.items, elem.Value.(*entry[K, V]).key)
+	cacheEntries.WithLabelValues(c.name).Set(float64(c.order.Len()))
+}
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRUEvictsLeastRecentlyUsed(t *testing.T) {
+	c := NewLRU[int, string]("test_evict", 2, time.Minute)
+
+	c.Add(1, "one")
+	c.Add(2, "two")
+	c.Get(1)
+	c.Add(3, "three")
+
+	if _, ok := c.Get(2); ok {
+		t.Error("expected key 2 to be evicted")
+	}
+	if v, ok := c.Get(1); !ok || v != "one" {
+		t.Errorf("expected key 1 to be kept, got %q, %v", v, ok)
+	}
+	if v, ok := c.Get(3); !ok || v != "three" {
+		t.Errorf("expected key 3 to be kept, got %q, %v", v, ok)
+	}
+}
+
+func TestLRUExpiresEntries(t *testing.T) {
+	now := time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+	c := NewLRU[int, string]("test_ttl", 10, time.Second)
+	c.now = func() time.Time { return now }
+
+	c.Add(1, "one")
+	now = now.Add(999 * time.Millisecond)
+	if _, ok := c.Get(1); !ok {
+		t.Fatal("expected entry before its TTL")
+	}
+
+	now = now.Add(time.Millisecond)
+	if _, ok := c.Get(1); ok {
+		t.Error("expected entry to expire after its TTL")
+	}
+	if c.Len() != 0 {
+		t.Errorf("expected expired entry to be dropped, len %d", c.Len())
+	}
+}
+
+func TestLRURemoveFunc(t *testing.T) {
+	c := NewLRU[string, int]("test_remove", 10, time.Minute)
+	c.Add("a:1", 1)
+	c.Add("a:2", 2)
+	c.Add("b:1", 3)
+
+	c.RemoveFunc(func(key string) bool { return key[0] == 'a' })
+
+	if c.Len() != 1 {
+		t.Fatalf("expected 1 entry left, got %d", c.Len())
+	}
+	if _, ok := c.Get("b:1"); !ok {
+		t.Error("expected b:1 to be kept")
+	}
+}
@@ -0,0 +1,159 @@
+package debug
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
+)
+
+// StateFunc describes the current state of a dependency, rendered as JSON
+type StateFunc func() any
+
+// StateReporter is implemented by adapters that can describe their connections
+type StateReporter interface {
+	DebugState() map[string]any
+}
+
+// Server is an opt-in listener, apart from the API, serving pprof profiles and
+// runtime diagnostics to callers presenting the debug token as a bearer token
+type Server struct {
+	server  *http.Server
+	started time.Time
+}
+
+// NewServer creates the debug listener on addr. Each state is served at
+// /debug/state/<name>, and all of them together at /debug/state.
+func NewServer(addr, token string, states map[string]StateFunc) *Server {
+	s := &Server{started: time.Now()}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /debug/pprof/", pprof.Index)
+	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("POST /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /debug/goroutines", goroutineDump)
+	mux.HandleFunc("GET /debug/runtime", s.runtimeStats)
+
+	for name, state := range states {
+		mux.HandleFunc("GET /debug/state/"+name, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, state())
+		})
+	}
+	mux.HandleFunc("GET /debug/state", func(w http.ResponseWriter, r *http.Request) {
+		all := make(map[string]any, len(states))
+		for name, state := range states {
+			all[name] = state()
+		}
+		writeJSON(w, all)
+	})
+
+	// No write timeout: CPU profiles and traces stream for as long as the caller asks
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           requireToken(token, mux),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+	return s
+}
+
+// Start serves the debug endpoints in the background
+func (s *Server) Start() {
+	logger.Warn("Debug listener enabled, profiles and runtime state are exposed",
+		zap.String("address", s.server.Addr),
+	)
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Debug listener stopped", zap.Error(err))
+		}
+	}()
+}
+
+// Shutdown stops the debug listener, waiting for requests in flight until ctx ends
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// PoolStats reports the pgx pool's connection counts and acquire statistics
+func PoolStats(pool *pgxpool.Pool) StateFunc {
+	return func() any {
+		stat := pool.Stat()
+		return map[string]any{
+			"max_conns":                  stat.MaxConns(),
+			"total_conns":                stat.TotalConns(),
+			"acquired_conns":             stat.AcquiredConns(),
+			"idle_conns":                 stat.IdleConns(),
+			"constructing_conns":         stat.ConstructingConns(),
+			"acquire_count":              stat.AcquireCount(),
+			"acquire_duration_ms":        stat.AcquireDuration().Milliseconds(),
+			"empty_acquire_count":        stat.EmptyAcquireCount(),
+			"canceled_acquire_count":     stat.CanceledAcquireCount(),
+			"new_conns_count":            stat.NewConnsCount(),
+			"max_lifetime_destroy_count": stat.MaxLifetimeDestroyCount(),
+			"max_idle_destroy_count":     stat.MaxIdleDestroyCount(),
+		}
+	}
+}
+
+// requireToken rejects requests without the debug token. The comparison takes the
+// same time whatever the token sent, so it cannot be guessed byte by byte.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="debug"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// goroutineDump writes every goroutine's stack, as a panic would
+func goroutineDump(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	runtimepprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// runtimeStats reports the scheduler and memory figures worth a first look
+func (s *Server) runtimeStats(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	writeJSON(w, map[string]any{
+		"go_version":     runtime.Version(),
+		"uptime_seconds": int64(time.Since(s.started).Seconds()),
+		"goroutines":     runtime.NumGoroutine(),
+		"gomaxprocs":     runtime.GOMAXPROCS(0),
+		"num_cpu":        runtime.NumCPU(),
+		"heap_alloc":     mem.HeapAlloc,
+		"heap_inuse":     mem.HeapInuse,
+		"heap_objects":   mem.HeapObjects,
+		"sys":            mem.Sys,
+		"num_gc":         mem.NumGC,
+		"gc_pause_total": time.Duration(mem.PauseTotalNs).String(),
+		"next_gc":        mem.NextGC,
+		"last_gc":        time.Unix(0, int64(mem.LastGC)).UTC().Format(time.RFC3339),
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,249 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// ErrInvalidToken is returned for malformed, forged, expired or misaddressed tokens
+var ErrInvalidToken = errors.New("invalid token")
+
+// Claims are the registered and scope claims read from a verified token
+type Claims struct {
+	Subject   string
+	Issuer    string
+	Audience  []string
+	ExpiresAt time.Time
+	NotBefore time.Time
+	Scopes    []string
+
+	// Raw holds every claim of the token, for application-specific claims
+	Raw map[string]json.RawMessage
+}
+
+// Verifier checks signed JWTs (JWS compact serialization) against a key set.
+// Only asymmetric algorithms are accepted, so "none" and HMAC tokens are rejected.
+type Verifier struct {
+	keys     *KeySet
+	issuer   string
+	audience string
+	leeway   time.Duration
+	now      func() time.Time
+}
+
+// NewVerifier creates a verifier. Empty issuer or audience are not checked;
+// leeway absorbs clock skew on exp and nbf.
+func NewVerifier(keys *KeySet, issuer, audience string, leeway time.Duration) *Verifier {
+	return &Verifier{
+		keys:     keys,
+		issuer:   issuer,
+		audience: audience,
+		leeway:   leeway,
+		now:      time.Now,
+	}
+}
+
+type header struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+type rawClaims struct {
+	Subject   string          `json:"sub"`
+	Issuer    string          `json:"iss"`
+	Audience  json.RawMessage `json:"aud"`
+	ExpiresAt *json.Number    `json:"exp"`
+	NotBefore *json.Number    `json:"nbf"`
+	Scope     string          `json:"scope"`
+	Scp       []string        `json:"scp"`
+}
+
+// Verify checks the signature and time and audience claims of a token.
+// An exp claim is required; scopes come from "scope" (space separated) or "scp".
+func (v *Verifier) Verify(token string) (*Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: expected three segments", ErrInvalidToken)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, fmt.Errorf("%w: header: %v", ErrInvalidToken, err)
+	}
+
+	key, ok := v.keys.lookup(h.Kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown key id %q", ErrInvalidToken, h.Kid)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: signature encoding", ErrInvalidToken)
+	}
+	if err := verifySignature(h.Alg, key, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var raw rawClaims
+	if err := decodeSegment(parts[1], &raw); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+
+	claims, err := raw.toClaims()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err := decodeSegment(parts[1], &claims.Raw); err != nil {
+		return nil, fmt.Errorf("%w: claims: %v", ErrInvalidToken, err)
+	}
+	if err := v.validate(claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	return claims, nil
+}
+
+// validate checks the time window, issuer and audience of verified claims
+func (v *Verifier) validate(claims *Claims) error {
+	now := v.now()
+
+	if claims.ExpiresAt.IsZero() {
+		return fmt.Errorf("missing exp claim")
+	}
+	if now.After(claims.ExpiresAt.Add(v.leeway)) {
+		return fmt.Errorf("token expired")
+	}
+	if !claims.NotBefore.IsZero() && now.Add(v.leeway).Before(claims.NotBefore) {
+		return fmt.Errorf("token not valid yet")
+	}
+	if v.issuer != "" && claims.Issuer != v.issuer {
+		return fmt.Errorf("unexpected issuer %q", claims.Issuer)
+	}
+	if v.audience != "" && !slices.Contains(claims.Audience, v.audience) {
+		return fmt.Errorf("token not issued for this audience")
+	}
+	return nil
+}
+
+func (r rawClaims) toClaims() (*Claims, error) {
+	claims := &Claims{
+		Subject: r.Subject,
+		Issuer:  r.Issuer,
+		Scopes:  r.Scp,
+	}
+	if r.Scope != "" {
+		claims.Scopes = strings.Fields(r.Scope)
+	}
+
+	// aud is either a single string or an array of strings
+	if len(r.Audience) > 0 {
+		var single string
+		if err := json.Unmarshal(r.Audience, &single); err == nil {
+			claims.Audience = []string{single}
+		} else if err := json.Unmarshal(r.Audience, &claims.Audience); err != nil {
+			return nil, fmt.Errorf("invalid aud claim")
+		}
+	}
+
+	var err error
+	if claims.ExpiresAt, err = numericDate(r.ExpiresAt); err != nil {
+		return nil, fmt.Errorf("invalid exp claim")
+	}
+	if claims.NotBefore, err = numericDate(r.NotBefore); err != nil {
+		return nil, fmt.Errorf("invalid nbf claim")
+	}
+
+	return claims, nil
+}
+
+// verifySignature checks a JWS signature with the algorithm named in the header,
+// which must match the type of the key
+func verifySignature(alg string, key crypto.PublicKey, signingInput string, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "PS256", "ES256":
+		hash = crypto.SHA256
+	case "RS384", "PS384", "ES384":
+		hash = crypto.SHA384
+	case "RS512", "PS512", "ES512":
+		hash = crypto.SHA512
+	case "EdDSA":
+	default:
+		return fmt.Errorf("unsupported algorithm %q", alg)
+	}
+
+	var digest []byte
+	if hash != 0 {
+		hasher := hash.New()
+		hasher.Write([]byte(signingInput))
+		digest = hasher.Sum(nil)
+	}
+
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		switch alg[:2] {
+		case "RS":
+			return rsa.VerifyPKCS1v15(k, hash, digest, signature)
+		case "PS":
+			return rsa.VerifyPSS(k, hash, digest, signature, nil)
+		}
+
+	case *ecdsa.PublicKey:
+		if alg[:2] != "ES" {
+			break
+		}
+		size := (k.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return fmt.Errorf("invalid signature length")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(k, digest, r, s) {
+			return fmt.Errorf("signature mismatch")
+		}
+		return nil
+
+	case ed25519.PublicKey:
+		if alg != "EdDSA" {
+			break
+		}
+		if !ed25519.Verify(k, []byte(signingInput), signature) {
+			return fmt.Errorf("signature mismatch")
+		}
+		return nil
+	}
+
+	return fmt.Errorf("algorithm %q does not match the key type", alg)
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	return decoder.Decode(target)
+}
+
+// numericDate converts a NumericDate claim (seconds since the epoch, possibly fractional)
+func numericDate(value *json.Number) (time.Time, error) {
+	if value == nil {
+		return time.Time{}, nil
+	}
+	seconds, err := value.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Unix(0, int64(seconds*float64(time.Second))), nil
+}
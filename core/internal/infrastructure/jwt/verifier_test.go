@@ -0,0 +1,127 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+var testNow = time.Date(2026, 1, 2, 10, 0, 0, 0, time.UTC)
+
+func newTestVerifier(t *testing.T) (*Verifier, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jwks := fmt.Sprintf(`{"keys":[{"kty":"EC","kid":"k1","use":"sig","crv":"P-256","x":%q,"y":%q}]}`,
+		base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, 32))),
+		base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, 32))),
+	)
+	keys, err := ParseKeySet([]byte(jwks))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	verifier := NewVerifier(keys, "https://issuer.example", "btg-core-api", time.Minute)
+	verifier.now = func() time.Time { return testNow }
+	return verifier, key
+}
+
+func sign(t *testing.T, key *ecdsa.PrivateKey, header, claims map[string]any) string {
+	t.Helper()
+
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	input := encode(header) + "." + encode(claims)
+	digest := sha256.Sum256([]byte(input))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func validClaims() map[string]any {
+	return map[string]any{
+		"sub":   "client-42",
+		"iss":   "https://issuer.example",
+		"aud":   []string{"btg-core-api"},
+		"exp":   testNow.Add(time.Hour).Unix(),
+		"scope": "orders:read orders:write",
+	}
+}
+
+func TestVerifierAcceptsValidToken(t *testing.T) {
+	verifier, key := newTestVerifier(t)
+
+	claims, err := verifier.Verify(sign(t, key, map[string]any{"alg": "ES256", "kid": "k1"}, validClaims()))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "client-42" {
+		t.Errorf("expected subject client-42, got %q", claims.Subject)
+	}
+	if len(claims.Scopes) != 2 || claims.Scopes[0] != "orders:read" || claims.Scopes[1] != "orders:write" {
+		t.Errorf("unexpected scopes %v", claims.Scopes)
+	}
+}
+
+func TestVerifierRejectsInvalidTokens(t *testing.T) {
+	verifier, key := newTestVerifier(t)
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	with := func(name string, value any) map[string]any {
+		claims := validClaims()
+		if value == nil {
+			delete(claims, name)
+		} else {
+			claims[name] = value
+		}
+		return claims
+	}
+	es256 := map[string]any{"alg": "ES256", "kid": "k1"}
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"expired", sign(t, key, es256, with("exp", testNow.Add(-2*time.Minute).Unix()))},
+		{"missing exp", sign(t, key, es256, with("exp", nil))},
+		{"not yet valid", sign(t, key, es256, with("nbf", testNow.Add(2*time.Minute).Unix()))},
+		{"wrong issuer", sign(t, key, es256, with("iss", "https://other.example"))},
+		{"wrong audience", sign(t, key, es256, with("aud", "other-api"))},
+		{"unknown key id", sign(t, key, map[string]any{"alg": "ES256", "kid": "k2"}, validClaims())},
+		{"forged signature", sign(t, other, es256, validClaims())},
+		{"alg none", sign(t, key, map[string]any{"alg": "none", "kid": "k1"}, validClaims())},
+		{"hmac", sign(t, key, map[string]any{"alg": "HS256", "kid": "k1"}, validClaims())},
+		{"malformed", "not-a-token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := verifier.Verify(tt.token); !errors.Is(err, ErrInvalidToken) {
+				t.Errorf("expected ErrInvalidToken, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package spool
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// File is a local append-only file of JSON records that keeps what could not be
+// stored elsewhere until it can be replayed. Every append is synced to disk before
+// it returns, so an accepted record survives a crash of the process.
+type File struct {
+	path string
+
+	// mu guards the spool file; drainMu lets one drain at a time own the draining file
+	mu      sync.Mutex
+	drainMu sync.Mutex
+}
+
+// NewFile creates a spool kept at path; the file and its directory are created on
+// the first append
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Append writes a record as one JSON line and syncs it to disk
+func (f *File) Append(record any) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("error marshalling spooled record: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(f.path), 0o700); err != nil {
+		return fmt.Errorf("error creating spool directory: %w", err)
+	}
+
+	file, err := os.OpenFile(f.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return fmt.Errorf("error opening spool: %w", err)
+	}
+
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("error writing spool: %w", err)
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return fmt.Errorf("error syncing spool: %w", err)
+	}
+	return file.Close()
+}
+
+// Drain hands every spooled record to replay and deletes them once replay succeeds;
+// when it fails the records are kept for the next drain. A line cut short by a crash
+// mid-append is skipped. The records are first moved aside to a draining file, so
+// appends go on to a fresh spool while replay runs, however long it takes.
+func (f *File) Drain(replay func(records []json.RawMessage) error) (int, error) {
+	f.drainMu.Lock()
+	defer f.drainMu.Unlock()
+
+	draining := f.path + ".draining"
+	if err := f.moveAside(draining); err != nil {
+		return 0, err
+	}
+
+	content, err := os.ReadFile(draining)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("error reading spool: %w", err)
+	}
+
+	var records []json.RawMessage
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		if line := scanner.Bytes(); json.Valid(line) {
+			records = append(records, json.RawMessage(bytes.Clone(line)))
+		}
+	}
+
+	if len(records) > 0 {
+		if err := replay(records); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := os.Remove(draining); err != nil {
+		return len(records), fmt.Errorf("error emptying spool: %w", err)
+	}
+	return len(records), nil
+}
+
+// moveAside renames the spool to draining unless records left by a failed drain are
+// still there; those are replayed first and the spool waits for the next drain
+func (f *File) moveAside(draining string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if _, err := os.Stat(draining); err == nil {
+		return nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error reading spool: %w", err)
+	}
+
+	if err := os.Rename(f.path, draining); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("error moving spool aside: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package spool
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type record struct {
+	ID int `json:"id"`
+}
+
+func TestFileDrain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "spool", "records.jsonl")
+	spool := NewFile(path)
+
+	if n, err := spool.Drain(func([]json.RawMessage) error { return nil }); n != 0 || err != nil {
+		t.Fatalf("empty spool drained %d records, err %v", n, err)
+	}
+
+	for id := range 3 {
+		if err := spool.Append(record{ID: id + 1}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A crash mid-append leaves a partial line behind
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file.WriteString(`{"id":`)
+	file.Close()
+
+	failed := errors.New("store unavailable")
+	if _, err := spool.Drain(func([]json.RawMessage) error { return failed }); !errors.Is(err, failed) {
+		t.Fatalf("expected the replay error, got %v", err)
+	}
+
+	// Appends made while the failed records are replayed wait for the next drain
+	var replayed []int
+	collect := func(records []json.RawMessage) error {
+		for _, raw := range records {
+			var r record
+			if err := json.Unmarshal(raw, &r); err != nil {
+				return err
+			}
+			replayed = append(replayed, r.ID)
+		}
+		return nil
+	}
+	if err := spool.Append(record{ID: 4}); err != nil {
+		t.Fatal(err)
+	}
+	n, err := spool.Drain(func(records []json.RawMessage) error {
+		if err := spool.Append(record{ID: 5}); err != nil {
+			return err
+		}
+		return collect(records)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 || len(replayed) != 3 || replayed[0] != 1 || replayed[2] != 3 {
+		t.Fatalf("replayed %d records %v, want [1 2 3] kept through the failed drain", n, replayed)
+	}
+
+	replayed = nil
+	if n, err := spool.Drain(collect); err != nil || n != 2 || replayed[0] != 4 || replayed[1] != 5 {
+		t.Fatalf("replayed %d records %v, err %v, want [4 5]", n, replayed, err)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("spool not emptied after a successful drain: %v", err)
+	}
+	if _, err := os.Stat(path + ".draining"); !os.IsNotExist(err) {
+		t.Errorf("draining file left after a successful drain: %v", err)
+	}
+}
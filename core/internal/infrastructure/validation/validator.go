@@ -1,9 +1,15 @@
 package validation
 
 import (
+	"reflect"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/go-playground/validator/v10"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/i18n"
 )
 
 var (
@@ -17,9 +23,25 @@ func Get() *validator.Validate {
 	once.Do(func() {
 		validate = validator.New(validator.WithRequiredStructEnabled())
 
-		// Register custom validators here if needed
-		// Example:
-		// validate.RegisterValidation("custom_rule", customValidator)
+		// Report fields by the JSON names clients send rather than the Go field names
+		validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+			name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+			if name == "-" {
+				return ""
+			}
+			if name == "" {
+				return field.Name
+			}
+			return name
+		})
+
+		validate.RegisterValidation("money_positive", moneyPositive)
+		validate.RegisterValidation("money_nonnegative", moneyNonNegative)
+		validate.RegisterValidation("money_decimals", moneyDecimals)
+		validate.RegisterValidation("money_max", moneyMax)
+		validate.RegisterValidation("cpf", cpf)
+		validate.RegisterValidation("cnpj", cnpj)
+		validate.RegisterValidation("cpf_cnpj", cpfOrCNPJ)
 	})
 	return validate
 }
@@ -31,42 +53,84 @@ func Validate(s any) error {
 }
 
 // ValidationErrors extracts field-level error details from a validation error.
-// Returns a map of field names to error messages.
-func ValidationErrors(err error) map[string]string {
+// Returns a map of JSON field paths, such as "itens[0].quantidade", to error messages
+// in the given language.
+func ValidationErrors(err error, language string) map[string]string {
 	if validationErrs, ok := err.(validator.ValidationErrors); ok {
 		details := make(map[string]string)
 		for _, e := range validationErrs {
-			details[e.Field()] = formatValidationError(e)
+			details[fieldPath(e)] = formatValidationError(e, language)
 		}
 		return details
 	}
 	return nil
 }
 
-// formatValidationError formats a single validation error into a human-readable message.
-func formatValidationError(e validator.FieldError) string {
-	switch e.Tag() {
-	case "required":
-		return "This field is required"
-	case "min":
-		return "Value is too short or too small"
-	case "max":
-		return "Value is too long or too large"
-	case "gt":
-		return "Value must be greater than " + e.Param()
-	case "gte":
-		return "Value must be greater than or equal to " + e.Param()
-	case "lt":
-		return "Value must be less than " + e.Param()
-	case "lte":
-		return "Value must be less than or equal to " + e.Param()
-	case "email":
-		return "Invalid email format"
-	case "url":
-		return "Invalid URL format"
-	case "uuid":
-		return "Invalid UUID format"
-	default:
-		return e.Error()
+// fieldPath drops the struct name that starts a field's namespace
+func fieldPath(e validator.FieldError) string {
+	if _, path, ok := strings.Cut(e.Namespace(), "."); ok {
+		return path
+	}
+	return e.Field()
+}
+
+// formatValidationError formats a single validation error into a human-readable
+// message in the given language, keeping the validator's own text for unknown tags.
+func formatValidationError(e validator.FieldError, language string) string {
+	message := i18n.Message(language, i18n.ValidationKey(e.Tag()), e.Error())
+	return strings.ReplaceAll(message, i18n.ParamPlaceholder, e.Param())
+}
+
+// moneyPositive checks a domain.Money field is greater than zero
+func moneyPositive(fl validator.FieldLevel) bool {
+	m, ok := fl.Field().Interface().(domain.Money)
+	return ok && m.IsPositive()
+}
+
+// moneyNonNegative checks a domain.Money field is zero or greater
+func moneyNonNegative(fl validator.FieldLevel) bool {
+	m, ok := fl.Field().Interface().(domain.Money)
+	return ok && !m.IsNegative()
+}
+
+// moneyDecimals checks a domain.Money field has at most the given number of decimal places
+func moneyDecimals(fl validator.FieldLevel) bool {
+	m, ok := fl.Field().Interface().(domain.Money)
+	if !ok {
+		return false
 	}
+	places, err := strconv.Atoi(fl.Param())
+	if err != nil {
+		return false
+	}
+	return m.Decimals() <= int32(places)
+}
+
+// moneyMax checks a domain.Money field is at most the given amount
+func moneyMax(fl validator.FieldLevel) bool {
+	m, ok := fl.Field().Interface().(domain.Money)
+	if !ok {
+		return false
+	}
+	limit, err := domain.ParseMoney(fl.Param())
+	if err != nil {
+		return false
+	}
+	return m.Cmp(limit) <= 0
+}
+
+// cpf checks a string field is a CPF with valid check digits, formatted or not
+func cpf(fl validator.FieldLevel) bool {
+	return domain.IsValidCPF(fl.Field().String())
+}
+
+// cnpj checks a string field is a CNPJ with valid check digits, formatted or not
+func cnpj(fl validator.FieldLevel) bool {
+	return domain.IsValidCNPJ(fl.Field().String())
+}
+
+// cpfOrCNPJ checks a string field is either a valid CPF or a valid CNPJ
+func cpfOrCNPJ(fl validator.FieldLevel) bool {
+	document := fl.Field().String()
+	return domain.IsValidCPF(document) || domain.IsValidCNPJ(document)
 }
@@ -0,0 +1,206 @@
+package logger
+
+import (
+	"errors"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Level is the global level, changed at runtime through SetLevel. Loggers created
+// with Named may be given their own level, which applies to their children too.
+var Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+
+// LevelSetting describes the level in force for the global logger (empty Name)
+// or a named one, and when it reverts, if it was changed temporarily
+type LevelSetting struct {
+	Name     string
+	Level    zapcore.Level
+	RevertAt time.Time
+}
+
+// namedLevel is the level a named logger was given and the timer reverting it
+type namedLevel struct {
+	level    zapcore.Level
+	revertAt time.Time
+	timer    *time.Timer
+}
+
+var levels = struct {
+	sync.RWMutex
+	named        map[string]*namedLevel
+	globalRevert *time.Timer
+	globalBase   zapcore.Level
+	globalAt     time.Time
+}{named: make(map[string]*namedLevel)}
+
+// Named returns the global logger under a name, so its level can be changed on
+// its own. Children of a named logger are matched by the "name." prefix.
+func Named(name string) *zap.Logger {
+	if Log == nil {
+		return zap.NewNop()
+	}
+	return Log.Named(name)
+}
+
+// SetLevel changes the level of the global logger, when name is empty, or of a
+// named one. With revertAfter > 0 the change is undone once it elapses: the global
+// level returns to what it was, a named logger goes back to the global level.
+func SetLevel(name string, level zapcore.Level, revertAfter time.Duration) (LevelSetting, error) {
+	if revertAfter < 0 {
+		return LevelSetting{}, errors.New("revert delay must not be negative")
+	}
+
+	levels.Lock()
+	defer levels.Unlock()
+
+	return setLevel(name, level, revertAfter), nil
+}
+
+// setLevel applies SetLevel with levels locked
+func setLevel(name string, level zapcore.Level, revertAfter time.Duration) LevelSetting {
+	var revertAt time.Time
+	if revertAfter > 0 {
+		revertAt = time.Now().Add(revertAfter)
+	}
+
+	if name == "" {
+		// A pending revert still returns to the level in force before the first temporary change
+		previous := Level.Level()
+		if levels.globalRevert != nil {
+			previous = levels.globalBase
+			levels.globalRevert.Stop()
+			levels.globalRevert = nil
+		}
+		Level.SetLevel(level)
+		levels.globalAt = revertAt
+		if revertAfter > 0 {
+			levels.globalBase = previous
+			// A timer that fired while a newer change held the lock finds itself replaced
+			var timer *time.Timer
+			timer = time.AfterFunc(revertAfter, func() {
+				levels.Lock()
+				defer levels.Unlock()
+				if levels.globalRevert != timer {
+					return
+				}
+				Level.SetLevel(previous)
+				levels.globalRevert = nil
+				levels.globalAt = time.Time{}
+			})
+			levels.globalRevert = timer
+		}
+		return LevelSetting{Level: level, RevertAt: revertAt}
+	}
+
+	if current, ok := levels.named[name]; ok && current.timer != nil {
+		current.timer.Stop()
+	}
+	setting := &namedLevel{level: level, revertAt: revertAt}
+	if revertAfter > 0 {
+		setting.timer = time.AfterFunc(revertAfter, func() {
+			levels.Lock()
+			defer levels.Unlock()
+			if levels.named[name] == setting {
+				delete(levels.named, name)
+			}
+		})
+	}
+	levels.named[name] = setting
+	return LevelSetting{Name: name, Level: level, RevertAt: revertAt}
+}
+
+// ResetLevel drops the level given to a named logger, which then follows the global level.
+// It reports whether the logger had a level of its own.
+func ResetLevel(name string) bool {
+	levels.Lock()
+	defer levels.Unlock()
+
+	current, ok := levels.named[name]
+	if !ok {
+		return false
+	}
+	if current.timer != nil {
+		current.timer.Stop()
+	}
+	delete(levels.named, name)
+	return true
+}
+
+// Levels lists the global level first, then the named loggers given their own level
+func Levels() []LevelSetting {
+	levels.RLock()
+	defer levels.RUnlock()
+
+	settings := []LevelSetting{{Level: Level.Level(), RevertAt: levels.globalAt}}
+	for name, setting := range levels.named {
+		settings = append(settings, LevelSetting{Name: name, Level: setting.level, RevertAt: setting.revertAt})
+	}
+	sort.Slice(settings[1:], func(i, j int) bool {
+		return settings[i+1].Name < settings[j+1].Name
+	})
+	return settings
+}
+
+// enabled reports whether an entry of a logger passes the level in force for it:
+// that of the longest configured name the logger's name equals or starts with,
+// otherwise the global level
+func enabled(loggerName string, level zapcore.Level) bool {
+	levels.RLock()
+	defer levels.RUnlock()
+
+	if len(levels.named) > 0 && loggerName != "" {
+		matched := ""
+		var matchedLevel zapcore.Level
+		for name, setting := range levels.named {
+			if (loggerName == name || strings.HasPrefix(loggerName, name+".")) && len(name) > len(matched) {
+				matched, matchedLevel = name, setting.level
+			}
+		}
+		if matched != "" {
+			return level >= matchedLevel
+		}
+	}
+	return Level.Enabled(level)
+}
+
+// lowestEnabled reports whether any logger may write entries of the level,
+// so cheap checks can bail out before the logger name is known
+func lowestEnabled(level zapcore.Level) bool {
+	if Level.Enabled(level) {
+		return true
+	}
+
+	levels.RLock()
+	defer levels.RUnlock()
+	for _, setting := range levels.named {
+		if level >= setting.level {
+			return true
+		}
+	}
+	return false
+}
+
+// levelCore filters entries by the level of the logger that wrote them
+type levelCore struct {
+	zapcore.Core
+}
+
+func (c levelCore) Enabled(level zapcore.Level) bool {
+	return lowestEnabled(level)
+}
+
+func (c levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return levelCore{c.Core.With(fields)}
+}
+
+func (c levelCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !enabled(entry.LoggerName, entry.Level) {
+		return checked
+	}
+	return c.Core.Check(entry, checked)
+}
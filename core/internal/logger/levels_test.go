@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestNamedLevels(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	log := zap.New(levelCore{core})
+	t.Cleanup(func() {
+		Level.SetLevel(zap.InfoLevel)
+		ResetLevel("http")
+	})
+
+	Level.SetLevel(zap.InfoLevel)
+	if _, err := SetLevel("http", zap.DebugLevel, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	log.Debug("root debug")
+	log.Named("rabbitmq").Debug("rabbitmq debug")
+	log.Named("http").Debug("http debug")
+	log.Named("http").Named("access").Debug("http child debug")
+	log.Named("httpx").Debug("sibling debug")
+
+	var got []string
+	for _, entry := range logs.All() {
+		got = append(got, entry.Message)
+	}
+	if len(got) != 2 || got[0] != "http debug" || got[1] != "http child debug" {
+		t.Errorf("unexpected entries %v", got)
+	}
+
+	if !ResetLevel("http") || ResetLevel("http") {
+		t.Error("expected the http level to be reset once")
+	}
+}
+
+func TestSetLevelReverts(t *testing.T) {
+	t.Cleanup(func() { Level.SetLevel(zap.InfoLevel) })
+	Level.SetLevel(zap.InfoLevel)
+
+	if _, err := SetLevel("", zap.DebugLevel, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	setting, err := SetLevel("", zap.WarnLevel, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setting.RevertAt.IsZero() || Level.Level() != zap.WarnLevel {
+		t.Fatalf("unexpected setting %+v at level %s", setting, Level.Level())
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for Level.Level() != zap.InfoLevel && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if Level.Level() != zap.InfoLevel {
+		t.Errorf("expected the level to revert to info, got %s", Level.Level())
+	}
+	if levels := Levels(); !levels[0].RevertAt.IsZero() {
+		t.Errorf("expected no pending revert, got %+v", levels[0])
+	}
+}
+
+func TestStaleGlobalRevertIsIgnored(t *testing.T) {
+	t.Cleanup(func() { Level.SetLevel(zap.InfoLevel) })
+	Level.SetLevel(zap.InfoLevel)
+
+	// The revert fires while the lock is held and waits for it; the permanent change
+	// made meanwhile must survive it
+	levels.Lock()
+	setLevel("", zap.DebugLevel, time.Millisecond)
+	time.Sleep(20 * time.Millisecond)
+	setLevel("", zap.WarnLevel, 0)
+	levels.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+	if Level.Level() != zap.WarnLevel {
+		t.Errorf("expected the level to stay warn, got %s", Level.Level())
+	}
+}
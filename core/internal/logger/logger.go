@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -8,10 +10,18 @@ import (
 // Log is the global logger instance
 var Log *zap.Logger
 
-// Init initializes the Zap logger with JSON output
-func Init(env string) error {
+// Init initializes the Zap logger with JSON output at the given level, such as "debug" or "info".
+// The level can be changed later through SetLevel.
+func Init(env, level string) error {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid log level: %w", err)
+	}
+	Level.SetLevel(parsed)
+
 	config := zap.Config{
-		Level:       zap.NewAtomicLevelAt(zap.InfoLevel),
+		// The built core passes every level; levelCore filters by Level and the named levels
+		Level:       zap.NewAtomicLevelAt(zap.DebugLevel),
 		Development: env == "development",
 		Encoding:    "json",
 		EncoderConfig: zapcore.EncoderConfig{
@@ -32,8 +42,9 @@ func Init(env string) error {
 		ErrorOutputPaths: []string{"stderr"},
 	}
 
-	var err error
-	Log, err = config.Build()
+	Log, err = config.Build(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return levelCore{core}
+	}))
 	if err != nil {
 		return err
 	}
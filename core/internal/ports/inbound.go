@@ -2,14 +2,19 @@ package ports
 
 import (
 	"context"
+	"time"
 
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
 )
 
 // OrderService defines the interface for order business logic
 type OrderService interface {
-	// GetOrderTotal retrieves the total value of an order by code
-	GetOrderTotal(ctx context.Context, orderCode int32) (string, error)
+	// GetOrderTotal retrieves the gross, refunded and net value of an order by code.
+	// An empty currency keeps the order's own currency.
+	GetOrderTotal(ctx context.Context, orderCode int32, currency string) (*domain.OrderTotals, error)
+
+	// ConvertTotals computes the totals of an order in the given currency, or its own when empty
+	ConvertTotals(ctx context.Context, order *domain.Order, currency string) (*domain.OrderTotals, error)
 
 	// GetOrderByCode retrieves an order by its code
 	GetOrderByCode(ctx context.Context, orderCode int32) (*domain.Order, error)
@@ -20,9 +25,109 @@ type OrderService interface {
 	// CountOrdersByCustomer counts the number of orders for a customer
 	CountOrdersByCustomer(ctx context.Context, customerCode int32) (int64, error)
 
-	// CreateOrder creates a new order with items
+	// GetCustomerStats retrieves the order aggregates of a customer
+	GetCustomerStats(ctx context.Context, customerCode int32) (*domain.CustomerStats, error)
+
+	// CreateOrder creates a new order with items, rejecting customers that may not place orders
 	CreateOrder(ctx context.Context, order *domain.Order) error
 
+	// CancelOrder requests the cancellation of an existing order
+	CancelOrder(ctx context.Context, cancellation *domain.OrderCancellation) error
+
+	// AmendOrder requests items to be added, removed or changed on an existing order
+	AmendOrder(ctx context.Context, amendment *domain.OrderAmendment) error
+
+	// ReturnOrder checks a partial return against the order and requests it
+	ReturnOrder(ctx context.Context, orderReturn *domain.OrderReturn) error
+
 	// GetOrderItems retrieves all items for an order
 	GetOrderItems(ctx context.Context, orderID int64) ([]*domain.OrderItem, error)
 }
+
+// FxService defines the interface for exchange rate management
+type FxService interface {
+	// ImportRates stores exchange rates, replacing rates for the same pair and date
+	ImportRates(ctx context.Context, rates []domain.ExchangeRate) (int, error)
+
+	// GetRate returns the rate converting from into to that is effective on the given date
+	GetRate(ctx context.Context, from, to string, on time.Time) (*domain.ExchangeRate, error)
+}
+
+// ProductService defines the interface for product catalog management
+type ProductService interface {
+	// CreateProduct adds a product to the catalog
+	CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
+
+	// GetProduct retrieves a product by its SKU
+	GetProduct(ctx context.Context, sku string) (*domain.Product, error)
+
+	// ListProducts retrieves every product in the catalog, active or not
+	ListProducts(ctx context.Context) ([]*domain.Product, error)
+
+	// UpdateProduct replaces the name, unit, list price and active flag of a product
+	UpdateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error)
+
+	// DeactivateProduct stops a product from being ordered, keeping it for past orders
+	DeactivateProduct(ctx context.Context, sku string) error
+}
+
+// CustomerService defines the interface for the customer registry
+type CustomerService interface {
+	// CreateCustomer registers a customer under its code
+	CreateCustomer(ctx context.Context, customer *domain.Customer) (*domain.Customer, error)
+
+	// GetCustomer retrieves a customer by its code
+	GetCustomer(ctx context.Context, code int32) (*domain.Customer, error)
+
+	// ListCustomers retrieves every registered customer
+	ListCustomers(ctx context.Context) ([]*domain.Customer, error)
+
+	// UpdateCustomer replaces the name, email, document and status of a customer
+	UpdateCustomer(ctx context.Context, customer *domain.Customer) (*domain.Customer, error)
+
+	// CheckCanOrder reports whether a customer may place an order
+	CheckCanOrder(ctx context.Context, code int32) error
+}
+
+// OrderCacheInvalidator drops cached reads made stale by committed order changes
+type OrderCacheInvalidator interface {
+	// InvalidateOrderChange drops what was cached for the changed order and its customer, or everything when change.All is set
+	InvalidateOrderChange(change domain.OrderChange)
+}
+
+// AuthService defines the interface for authenticating API callers
+type AuthService interface {
+	// AuthenticateAPIKey resolves an API key to the principal it was issued to
+	AuthenticateAPIKey(ctx context.Context, key string) (*domain.Principal, error)
+
+	// AuthenticateToken verifies a JWT bearer token and resolves its principal
+	AuthenticateToken(ctx context.Context, token string) (*domain.Principal, error)
+
+	// CreateAPIKey issues a key with the given scopes, bound to the given customer codes
+	// when any. The key is only returned here; just its hash is stored.
+	CreateAPIKey(ctx context.Context, name string, scopes []string, customerCodes []int) (string, error)
+}
+
+// HealthService checks the dependencies an instance needs to serve traffic
+type HealthService interface {
+	// Readiness checks every dependency and reports each one's status and latency
+	Readiness(ctx context.Context) domain.HealthReport
+}
+
+// AuditService records who accessed or changed what, in a hash-chained append-only log
+type AuditService interface {
+	// Record stages an entry to be chained onto the log, falling back to the local spool
+	Record(ctx context.Context, entry *domain.AuditEntry) error
+
+	// ChainStaged appends a batch of staged entries to the log, unless another instance is chaining
+	ChainStaged(ctx context.Context) (int, error)
+
+	// ReplaySpooled stages the entries this instance spooled while the database was unavailable
+	ReplaySpooled(ctx context.Context) (int, error)
+
+	// ListEntries retrieves the entries matching a filter in the order they were recorded
+	ListEntries(ctx context.Context, filter domain.AuditFilter) ([]*domain.AuditEntry, error)
+
+	// VerifyChain recomputes the hash of every entry and checks each links to the one before
+	VerifyChain(ctx context.Context) (domain.AuditVerification, error)
+}
@@ -12,6 +12,18 @@ type MessagePublisher interface {
 	// PublishOrder pushes the order to the message broker
 	PublishOrder(ctx context.Context, order *domain.Order) error
 
+	// PublishCancellation pushes an order cancellation request to the message broker
+	PublishCancellation(ctx context.Context, cancellation *domain.OrderCancellation) error
+
+	// PublishAmendment pushes an order amendment request to the message broker
+	PublishAmendment(ctx context.Context, amendment *domain.OrderAmendment) error
+
+	// PublishReturn pushes a partial return of an order to the message broker
+	PublishReturn(ctx context.Context, orderReturn *domain.OrderReturn) error
+
+	// CheckHealth fails when the broker connection or channel has been closed
+	CheckHealth(ctx context.Context) error
+
 	// CLoses the pub/sub connection
 	Close() error
 }
@@ -1,6 +1,7 @@
 package server
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"time"
@@ -8,25 +9,72 @@ import (
 	"go.uber.org/zap"
 
 	httphandler "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/http"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/inbound/notifications"
 	db "github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/adapters/outbound/database"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/application/services"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/jwt"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/infrastructure/spool"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/logger"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/ports"
 )
 
 type Server struct {
-	router       http.Handler
-	server       *http.Server
-	orderService ports.OrderService
+	router         http.Handler
+	server         *http.Server
+	orderService   ports.OrderService
+	listener       *notifications.OrderChangeListener
+	auditChainer   *services.AuditChainer
+	stopBackground context.CancelFunc
 }
 
 func NewServer(cfg *config.Config, dbStore *db.Store, messagePublisher ports.MessagePublisher) *Server {
 	// Initialize service with dependency injection
-	orderService := services.NewOrderService(dbStore, messagePublisher)
+	fxService := services.NewFxService(dbStore)
+	productService := services.NewProductService(dbStore)
+	customerService := services.NewCustomerService(dbStore, cfg.Orders.RequireRegisteredCustomer)
+	var orderService ports.OrderService = services.NewOrderService(dbStore, messagePublisher, fxService, customerService)
+
+	// Cache order reads, invalidated by changes the consumer notifies through Postgres
+	var listener *notifications.OrderChangeListener
+	if cfg.Cache.Enabled {
+		cachedOrderService := services.NewCachedOrderService(orderService, cfg.Cache.Size, cfg.Cache.TTL)
+		orderService = cachedOrderService
+		listener = notifications.NewOrderChangeListener(dbStore.Pool, cachedOrderService)
+	}
+
+	// Bearer tokens are only accepted when a JWKS file is configured
+	var verifier *jwt.Verifier
+	if cfg.Auth.JWKSFile != "" {
+		keys, err := jwt.LoadKeySetFile(cfg.Auth.JWKSFile)
+		if err != nil {
+			logger.Fatal("Failed to load JWKS", zap.String("path", cfg.Auth.JWKSFile), zap.Error(err))
+		}
+		verifier = jwt.NewVerifier(keys, cfg.Auth.JWTIssuer, cfg.Auth.JWTAudience, cfg.Auth.JWTLeeway)
+	}
+	authService := services.NewAuthService(dbStore, verifier)
+	if !cfg.Auth.Enabled {
+		logger.Warn("Authentication disabled, every route is anonymous")
+	}
+
+	// Readiness covers the database, the broker and the outbox backlog
+	healthService := services.NewHealthService(dbStore, messagePublisher, cfg.Health.CheckTimeout, cfg.Health.OutboxBacklogThreshold)
+
+	// Writes and sensitive reads are staged by requests and chained in the background
+	var auditSpool *spool.File
+	if cfg.Audit.SpoolFile != "" {
+		auditSpool = spool.NewFile(cfg.Audit.SpoolFile)
+	}
+	auditService := services.NewAuditService(dbStore, auditSpool)
+	var auditChainer *services.AuditChainer
+	if cfg.Audit.Enabled {
+		auditChainer = services.NewAuditChainer(auditService, cfg.Audit.ChainInterval)
+	} else {
+		logger.Warn("Audit log disabled, requests are not recorded")
+	}
 
 	// Initialize router with service
-	router := httphandler.NewRouter(cfg, orderService)
+	router := httphandler.NewRouter(cfg, orderService, fxService, productService, customerService, authService, healthService, auditService)
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port),
@@ -37,9 +85,12 @@ func NewServer(cfg *config.Config, dbStore *db.Store, messagePublisher ports.Mes
 	}
 
 	return &Server{
-		router:       router,
-		server:       server,
-		orderService: orderService,
+		router:         router,
+		server:         server,
+		orderService:   orderService,
+		listener:       listener,
+		auditChainer:   auditChainer,
+		stopBackground: func() {},
 	}
 }
 
@@ -51,21 +102,41 @@ func (s *Server) Start() error {
 	)
 
 	logger.Info("Available endpoints",
-		zap.String("health", "GET /health"),
+		zap.String("health", "GET /health/live, /health/ready"),
 		zap.String("metrics", "GET /metrics"),
 		zap.String("swagger", "GET /swagger/index.html"),
 		zap.String("order_total", "GET /api/v1/orders/{code}/total"),
+		zap.String("customers", "POST|GET /api/v1/customers"),
+		zap.String("customer", "GET|PUT /api/v1/customers/{code}"),
 		zap.String("customer_orders", "GET /api/v1/customers/{code}/orders"),
 		zap.String("customer_orders_count", "GET /api/v1/customers/{code}/orders/count"),
+		zap.String("customer_summary", "GET /api/v1/customers/{code}/summary"),
 		zap.String("create_order", "POST /api/v1/orders"),
+		zap.String("cancel_order", "POST /api/v1/orders/{code}/cancel"),
+		zap.String("amend_order", "PATCH /api/v1/orders/{code}"),
+		zap.String("return_order", "POST /api/v1/orders/{code}/returns"),
+		zap.String("import_fx_rates", "POST /api/v1/admin/fx-rates"),
+		zap.String("log_levels", "GET|PUT /api/v1/admin/log-levels, DELETE /api/v1/admin/log-levels/{logger}"),
+		zap.String("products", "POST|GET /api/v1/products"),
+		zap.String("product", "GET|PUT|DELETE /api/v1/products/{sku}"),
 	)
 
 	logger.Info("OrderService initialized", zap.String("status", "ready"))
 
+	ctx, cancel := context.WithCancel(context.Background())
+	s.stopBackground = cancel
+	if s.listener != nil {
+		go s.listener.Start(ctx)
+	}
+	if s.auditChainer != nil {
+		go s.auditChainer.Start(ctx)
+	}
+
 	return s.server.ListenAndServe()
 }
 
 func (s *Server) Shutdown() error {
 	logger.Info("Server shutdown initiated")
+	s.stopBackground()
 	return s.server.Close()
 }
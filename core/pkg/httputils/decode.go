@@ -0,0 +1,122 @@
+package httputils
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+)
+
+// MaxBodyBytes caps the size of request bodies
+const MaxBodyBytes int64 = 1 << 20
+
+// DecodeJSONBody strictly decodes a required JSON request body into dst. The body must be
+// labelled as JSON, fit in MaxBodyBytes, hold a single document and name only fields dst
+// knows. When it is rejected, the returned APIError is the 400, 413 or 415 to write.
+func DecodeJSONBody(w http.ResponseWriter, r *http.Request, dst any) (constants.APIError, bool) {
+	return decodeJSONBody(w, r, dst, false)
+}
+
+// DecodeOptionalJSONBody is DecodeJSONBody for endpoints whose body may be left out.
+// An empty body leaves dst untouched and needs no Content-Type.
+func DecodeOptionalJSONBody(w http.ResponseWriter, r *http.Request, dst any) (constants.APIError, bool) {
+	return decodeJSONBody(w, r, dst, true)
+}
+
+// LimitBody caps the request body at MaxBodyBytes, for handlers that read it themselves.
+// Reads past the limit fail with an error BodyTooLarge recognises.
+func LimitBody(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, MaxBodyBytes)
+}
+
+// BodyTooLarge reports whether err comes from reading past the limit set by LimitBody
+func BodyTooLarge(err error) bool {
+	var maxBytesErr *http.MaxBytesError
+	return errors.As(err, &maxBytesErr)
+}
+
+// PayloadTooLargeError is the 413 written when a body exceeds MaxBodyBytes
+func PayloadTooLargeError() constants.APIError {
+	return constants.ErrPayloadTooLarge.WithDetail(constants.DetailBodyTooLarge, "limit", strconv.FormatInt(MaxBodyBytes, 10))
+}
+
+// MediaType returns the media type of the request's Content-Type, lowercased and
+// without parameters, or "" when the header is missing or malformed
+func MediaType(r *http.Request) string {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return ""
+	}
+	return mediaType
+}
+
+// IsJSONMediaType reports whether a media type is application/json or a +json suffix type
+func IsJSONMediaType(mediaType string) bool {
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+}
+
+func decodeJSONBody(w http.ResponseWriter, r *http.Request, dst any, optional bool) (constants.APIError, bool) {
+	mediaType := MediaType(r)
+	labelled := r.Header.Get("Content-Type") != ""
+	if labelled && !IsJSONMediaType(mediaType) {
+		return constants.ErrUnsupportedMedia.WithDetail(constants.DetailContentTypeJSON), false
+	}
+
+	LimitBody(w, r)
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(dst); err != nil {
+		if errors.Is(err, io.EOF) {
+			if optional {
+				return constants.APIError{}, true
+			}
+			return constants.ErrInvalidRequestBody.WithDetail(constants.DetailBodyRequired), false
+		}
+		return decodeError(err), false
+	}
+
+	// Only an empty optional body may go unlabelled
+	if !labelled {
+		return constants.ErrUnsupportedMedia.WithDetail(constants.DetailContentTypeJSON), false
+	}
+
+	if err := decoder.Decode(&json.RawMessage{}); !errors.Is(err, io.EOF) {
+		if BodyTooLarge(err) {
+			return PayloadTooLargeError(), false
+		}
+		return constants.ErrInvalidRequestBody.WithDetail(constants.DetailSingleDocument), false
+	}
+
+	return constants.APIError{}, true
+}
+
+// decodeError explains why a body could not be decoded without exposing Go types
+func decodeError(err error) constants.APIError {
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+
+	switch {
+	case BodyTooLarge(err):
+		return PayloadTooLargeError()
+	case errors.As(err, &syntaxErr):
+		return constants.ErrInvalidRequestBody.WithDetail(constants.DetailMalformedJSON, "offset", strconv.FormatInt(syntaxErr.Offset, 10))
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return constants.ErrInvalidRequestBody.WithDetail(constants.DetailTruncatedJSON)
+	case errors.As(err, &typeErr):
+		if typeErr.Field == "" {
+			return constants.ErrInvalidRequestBody.WithDetail(constants.DetailBodyNotObject)
+		}
+		return constants.ErrInvalidRequestBody.WithDetail(constants.DetailWrongFieldType, "field", typeErr.Field)
+	}
+
+	if field, ok := strings.CutPrefix(err.Error(), "json: unknown field "); ok {
+		return constants.ErrInvalidRequestBody.WithDetail(constants.DetailUnknownField, "field", strings.Trim(field, `"`))
+	}
+	return constants.ErrInvalidRequestBody.WithDetail(constants.DetailUndecodableBody)
+}
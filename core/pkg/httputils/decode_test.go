@@ -0,0 +1,64 @@
+package httputils
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+)
+
+func TestDecodeJSONBody(t *testing.T) {
+	type payload struct {
+		Name     string `json:"nome"`
+		Quantity int    `json:"quantidade"`
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		optional    bool
+		wantCode    string
+	}{
+		{"valid", "application/json", `{"nome":"lápis","quantidade":2}`, false, ""},
+		{"charset parameter", "application/json; charset=utf-8", `{"nome":"lápis"}`, false, ""},
+		{"unknown field", "application/json", `{"nome":"lápis","cor":"azul"}`, false, constants.CodeInvalidRequest},
+		{"trailing document", "application/json", `{"nome":"lápis"}{"nome":"caneta"}`, false, constants.CodeInvalidRequest},
+		{"trailing garbage", "application/json", `{"nome":"lápis"} x`, false, constants.CodeInvalidRequest},
+		{"malformed", "application/json", `{"nome":`, false, constants.CodeInvalidRequest},
+		{"wrong type", "application/json", `{"quantidade":"dois"}`, false, constants.CodeInvalidRequest},
+		{"empty body", "application/json", ``, false, constants.CodeInvalidRequest},
+		{"empty optional body", "", ``, true, ""},
+		{"unlabelled body", "", `{"nome":"lápis"}`, true, constants.CodeUnsupportedMedia},
+		{"form body", "application/x-www-form-urlencoded", `nome=lapis`, false, constants.CodeUnsupportedMedia},
+		{"too large", "application/json", `{"nome":"` + strings.Repeat("a", int(MaxBodyBytes)) + `"}`, false, constants.CodePayloadTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				r.Header.Set("Content-Type", tt.contentType)
+			}
+
+			var dst payload
+			decode := DecodeJSONBody
+			if tt.optional {
+				decode = DecodeOptionalJSONBody
+			}
+			apiErr, ok := decode(httptest.NewRecorder(), r, &dst)
+
+			if tt.wantCode == "" {
+				if !ok {
+					t.Fatalf("expected the body to decode, got %+v", apiErr)
+				}
+				return
+			}
+			if ok || apiErr.Code != tt.wantCode {
+				t.Errorf("expected %s, got ok=%v %+v", tt.wantCode, ok, apiErr)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/i18n"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 )
@@ -20,20 +21,14 @@ func init() {
 	validate = validator.New()
 }
 
-// APIResponse wraps all API responses with metadata
+// APIResponse wraps all successful API responses with metadata.
+// Errors are written as problem details instead, see WriteProblem.
 type APIResponse struct {
 	ResponseTime  time.Time `json:"responseTime" example:"2024-01-15T10:30:00Z"`
 	CorrelationId string    `json:"correlationId" example:"550e8400-e29b-41d4-a716-446655440000"`
 	Code          string    `json:"code,omitempty" example:"ORDER_CREATED"`
+	Message       string    `json:"message,omitempty" example:"Order accepted for processing"`
 	Data          any       `json:"data,omitempty"`
-	Error         string    `json:"error,omitempty" example:"INVALID_REQUEST"`
-	Message       string    `json:"message,omitempty" example:"Request processed successfully"`
-}
-
-// ErrorResponse represents a simple error response (legacy)
-type ErrorResponse struct {
-	Error   string            `json:"error"`
-	Details map[string]string `json:"details,omitempty"`
 }
 
 // SuccessResponse represents a simple success response (legacy)
@@ -80,39 +75,8 @@ func WriteAPIResponse(w http.ResponseWriter, r *http.Request, status int, data a
 	json.NewEncoder(w).Encode(response)
 }
 
-// WriteAPIError writes an error response with metadata using a predefined APIError
-func WriteAPIError(w http.ResponseWriter, r *http.Request, apiErr constants.APIError) {
-	correlationID := GetCorrelationID(r)
-
-	w.Header().Set(CorrelationIDHeader, correlationID)
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(apiErr.Status)
-
-	response := APIResponse{
-		ResponseTime:  time.Now().UTC(),
-		CorrelationId: correlationID,
-		Error:         apiErr.Code,
-		Message:       apiErr.Message,
-	}
-
-	json.NewEncoder(w).Encode(response)
-}
-
-// WriteError writes an error response without requiring an http.Request
-func WriteError(w http.ResponseWriter, apiErr constants.APIError) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(apiErr.Status)
-
-	response := APIResponse{
-		ResponseTime: time.Now().UTC(),
-		Error:        apiErr.Code,
-		Message:      apiErr.Message,
-	}
-
-	json.NewEncoder(w).Encode(response)
-}
-
-// WriteAPISuccess writes a success response with metadata using a predefined APISuccess
+// WriteAPISuccess writes a success response with metadata using a predefined APISuccess,
+// described in the language negotiated for the request
 func WriteAPISuccess(w http.ResponseWriter, r *http.Request, apiSuccess constants.APISuccess, data any) {
 	correlationID := GetCorrelationID(r)
 
@@ -124,6 +88,7 @@ func WriteAPISuccess(w http.ResponseWriter, r *http.Request, apiSuccess constant
 		ResponseTime:  time.Now().UTC(),
 		CorrelationId: correlationID,
 		Code:          apiSuccess.Code,
+		Message:       i18n.Message(i18n.FromContext(r.Context()), apiSuccess.Code, ""),
 		Data:          data,
 	}
 
@@ -142,39 +107,3 @@ func RespondJSON(w http.ResponseWriter, status int, data any) {
 		slog.Error("failed to encode json response", "error", err)
 	}
 }
-
-// RespondError writes an error response (legacy)
-func RespondError(w http.ResponseWriter, status int, message string, details map[string]string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(status)
-
-	response := ErrorResponse{
-		Error:   message,
-		Details: details,
-	}
-	if err := json.NewEncoder(w).Encode(response); err != nil {
-		slog.Error("failed to encode error response", "error", err)
-	}
-}
-
-// RespondValidationError writes a validation error response
-func RespondValidationError(w http.ResponseWriter, err error) {
-	if validationErrs, ok := err.(validator.ValidationErrors); ok {
-		details := make(map[string]string)
-		for _, e := range validationErrs {
-			details[e.Field()] = e.Error()
-		}
-		RespondError(w, http.StatusBadRequest, "Validation failed", details)
-		return
-	}
-	RespondError(w, http.StatusBadRequest, "Validation failed", nil)
-}
-
-// DecodeJSON decodes JSON from request body
-func DecodeJSON(w http.ResponseWriter, r *http.Request, target any) error {
-	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
-		RespondError(w, http.StatusBadRequest, "Invalid request body", nil)
-		return err
-	}
-	return nil
-}
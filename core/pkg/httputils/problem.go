@@ -0,0 +1,113 @@
+package httputils
+
+import (
+	"encoding/json"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/i18n"
+)
+
+// ProblemContentType is the media type of RFC 7807 problem details
+const ProblemContentType = "application/problem+json"
+
+// problemTypePrefix turns an error code into the problem type URI,
+// e.g. ORDER_NOT_FOUND becomes urn:btg:problem:order-not-found
+const problemTypePrefix = "urn:btg:problem:"
+
+// ProblemDetails is the RFC 7807 body of every error response. Code and
+// CorrelationId are extension members; Errors lists the fields that failed validation.
+type ProblemDetails struct {
+	Type          string       `json:"type" example:"urn:btg:problem:order-not-found"`
+	Title         string       `json:"title" example:"Order not found"`
+	Status        int          `json:"status" example:"404"`
+	Detail        string       `json:"detail,omitempty" example:"No exchange rate from USD to BRL on 2026-01-02"`
+	Instance      string       `json:"instance,omitempty" example:"/api/v1/orders/1001/total"`
+	Code          string       `json:"code" example:"ORDER_NOT_FOUND"`
+	CorrelationId string       `json:"correlationId" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Errors        []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why one request field was rejected
+type FieldError struct {
+	Field   string `json:"field" example:"itens[0].quantidade"`
+	Message string `json:"message" example:"This field is required"`
+}
+
+// WriteProblem writes an APIError as problem details, titled and explained in the language
+// negotiated for the request. The body is labelled application/problem+json unless the client
+// only accepts application/json.
+func WriteProblem(w http.ResponseWriter, r *http.Request, apiErr constants.APIError, fieldErrors ...FieldError) {
+	correlationID := GetCorrelationID(r)
+	language := i18n.FromContext(r.Context())
+
+	problem := ProblemDetails{
+		Type:          ProblemType(apiErr.Code),
+		Title:         i18n.Message(language, apiErr.MessageKey(), apiErr.Message),
+		Status:        apiErr.Status,
+		Detail:        problemDetail(language, apiErr.Details),
+		Instance:      r.URL.Path,
+		Code:          apiErr.Code,
+		CorrelationId: correlationID,
+		Errors:        fieldErrors,
+	}
+
+	w.Header().Set(CorrelationIDHeader, correlationID)
+	w.Header().Set("Content-Type", problemContentType(r))
+	w.WriteHeader(apiErr.Status)
+
+	if err := json.NewEncoder(w).Encode(problem); err != nil {
+		slog.Error("failed to encode problem response", "error", err)
+	}
+}
+
+// problemDetail writes the details of an APIError in a language, separated by semicolons
+func problemDetail(language string, details []constants.Detail) string {
+	messages := make([]string, len(details))
+	for i, detail := range details {
+		messages[i] = i18n.Format(language, detail.Key, detail.Message, detail.Params)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// ProblemType returns the problem type URI of an error code
+func ProblemType(code string) string {
+	return problemTypePrefix + strings.ToLower(strings.ReplaceAll(code, "_", "-"))
+}
+
+// problemContentType negotiates the media type of a problem response from the
+// Accept header. Problem details are the default; plain JSON is only chosen
+// when the client accepts it and not problem details or a wildcard.
+func problemContentType(r *http.Request) string {
+	accept := r.Header.Get("Accept")
+	if accept == "" {
+		return ProblemContentType
+	}
+
+	acceptsJSON := false
+	for _, mediaRange := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(mediaRange))
+		if err != nil {
+			continue
+		}
+		if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+			continue
+		}
+
+		switch mediaType {
+		case ProblemContentType, "application/*", "*/*":
+			return ProblemContentType
+		case "application/json":
+			acceptsJSON = true
+		}
+	}
+
+	if acceptsJSON {
+		return "application/json"
+	}
+	return ProblemContentType
+}
@@ -0,0 +1,95 @@
+package httputils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/constants"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/core/internal/i18n"
+)
+
+func TestWriteProblem(t *testing.T) {
+	r := httptest.NewRequest(http.MethodGet, "/api/v1/orders/7/total", nil)
+	r.Header.Set(CorrelationIDHeader, "corr-1")
+	w := httptest.NewRecorder()
+
+	WriteProblem(w, r, constants.ErrFxRateNotFound.WithDetail(domain.ProblemFxRateNotFound,
+		"base", "USD", "quote", "BRL", "date", "2026-01-02"),
+		FieldError{Field: "currency", Message: "unsupported"})
+
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Fatalf("expected 422, got %d", w.Code)
+	}
+	if got := w.Header().Get("Content-Type"); got != ProblemContentType {
+		t.Errorf("expected %s, got %q", ProblemContentType, got)
+	}
+
+	var problem ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	want := ProblemDetails{
+		Type:          "urn:btg:problem:fx-rate-not-found",
+		Title:         constants.MsgFxRateNotFound,
+		Status:        http.StatusUnprocessableEntity,
+		Detail:        "no USD/BRL rate effective on 2026-01-02",
+		Instance:      "/api/v1/orders/7/total",
+		Code:          constants.CodeFxRateNotFound,
+		CorrelationId: "corr-1",
+	}
+	if len(problem.Errors) != 1 || problem.Errors[0].Field != "currency" {
+		t.Errorf("unexpected field errors %+v", problem.Errors)
+	}
+	problem.Errors = nil
+	if !reflect.DeepEqual(problem, want) {
+		t.Errorf("expected %+v, got %+v", want, problem)
+	}
+}
+
+func TestWriteProblemTranslatesDetails(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+	r = r.WithContext(i18n.WithLanguage(r.Context(), i18n.Portuguese))
+	w := httptest.NewRecorder()
+
+	WriteProblem(w, r, constants.ErrInvalidRequestBody.WithDetails(
+		constants.Detail{Key: constants.DetailUnknownField, Params: map[string]string{"field": "foo"}},
+		constants.Detail{Key: "detail.missing", Message: "untranslated"},
+	))
+
+	var problem ProblemDetails
+	if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+		t.Fatal(err)
+	}
+	if want := `campo desconhecido "foo"; untranslated`; problem.Detail != want {
+		t.Errorf("expected detail %q, got %q", want, problem.Detail)
+	}
+}
+
+func TestProblemContentType(t *testing.T) {
+	tests := []struct {
+		accept string
+		want   string
+	}{
+		{"", ProblemContentType},
+		{"application/problem+json", ProblemContentType},
+		{"*/*", ProblemContentType},
+		{"application/json", "application/json"},
+		{"application/json, application/problem+json;q=0.5", ProblemContentType},
+		{"application/json, application/problem+json;q=0", "application/json"},
+		{"text/html", ProblemContentType},
+	}
+
+	for _, tt := range tests {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.accept != "" {
+			r.Header.Set("Accept", tt.accept)
+		}
+		if got := problemContentType(r); got != tt.want {
+			t.Errorf("Accept %q: expected %s, got %s", tt.accept, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database"
+	database "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/application/services"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
+)
+
+// backfill-totals fills orders.total and orders.item_count for rows written
+// before those columns existed. It is safe to run more than once.
+func main() {
+	batchSize := flag.Int64("batch-size", 1000, "number of order ids updated per statement")
+	flag.Parse()
+
+	if *batchSize < 1 {
+		fmt.Fprintln(os.Stderr, "batch-size must be positive")
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	if err := logger.Init(cfg.App.Env, cfg.App.LogLevel); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	// Initialize database connection
+	dbConn, err := db.NewDB(ctx, cfg.Database.DSN())
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer dbConn.Close()
+
+	dbStore := db.NewStore(dbConn, database.New(dbConn.Pool))
+	orderService := services.NewOrderProcessingService(dbStore, domain.ValidationRules{})
+
+	start := time.Now()
+	logger.Info("Backfilling order totals", zap.Int64("batch_size", *batchSize))
+
+	updated, err := orderService.BackfillOrderTotals(ctx, *batchSize)
+	if err != nil {
+		logger.Fatal("Failed to backfill order totals",
+			zap.Error(err),
+			zap.Int64("updated", updated),
+		)
+	}
+
+	logger.Info("Order totals backfilled",
+		zap.Int64("updated", updated),
+		zap.Int64("duration_ms", time.Since(start).Milliseconds()),
+	)
+}
@@ -13,8 +13,11 @@ import (
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/inbound/consumer"
 	db "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database"
 	database "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/messaging"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/application/services"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/infrastructure/debug"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/infrastructure/telemetry"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
 )
@@ -31,7 +34,7 @@ func main() {
 	}
 
 	// Initialize logger
-	if err := logger.Init(cfg.App.Env); err != nil {
+	if err := logger.Init(cfg.App.Env, cfg.App.LogLevel); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
 		os.Exit(1)
 	}
@@ -75,15 +78,51 @@ func main() {
 	dbStore := db.NewStore(dbConn, queries)
 
 	// Initialize service with dependency injection
-	orderService := services.NewOrderProcessingService(dbStore)
+	orderService := services.NewOrderProcessingService(dbStore, domain.ValidationRules{
+		MaxItems:       cfg.Validation.MaxItems,
+		MaxQuantity:    cfg.Validation.MaxQuantity,
+		PricePrecision: cfg.Validation.PricePrecision,
+		PriceTolerance: cfg.Validation.PriceTolerance,
+	})
 
 	logger.Info("OrderProcessingService initialized")
 
+	// Initialize event publisher and outbox relay
+	eventPublisher, err := messaging.NewRabbitMQPublisher(
+		cfg.RabbitMQ.URL(),
+		cfg.RabbitMQ.EventsExchange,
+	)
+	if err != nil {
+		logger.Fatal("Failed to initialize RabbitMQ event publisher",
+			zap.Error(err),
+			zap.String("exchange", cfg.RabbitMQ.EventsExchange),
+		)
+	}
+	defer eventPublisher.Close()
+
+	outboxRelay := services.NewOutboxRelay(
+		dbStore,
+		eventPublisher,
+		cfg.Outbox.PollInterval,
+		int32(cfg.Outbox.BatchSize),
+	)
+	go outboxRelay.Start(ctx)
+
+	// Register message processors by message type
+	dispatcher := consumer.NewDispatcher()
+	dispatcher.Register(domain.EventOrderCreated, services.NewOrderCreatedHandler(orderService))
+	dispatcher.Register(domain.EventOrderCancelled, services.NewOrderCancelledHandler(orderService))
+	dispatcher.Register(domain.EventOrderAmended, services.NewOrderAmendedHandler(orderService))
+	dispatcher.Register(domain.EventOrderReturned, services.NewOrderReturnedHandler(orderService))
+	dispatcher.Register(domain.EventCustomerUpdated, services.NewCustomerUpdatedHandler(orderService))
+
 	// Initialize RabbitMQ consumer
 	rabbitConsumer, err := consumer.NewRabbitMQConsumer(
 		cfg.RabbitMQ.URL(),
 		cfg.RabbitMQ.Queue,
-		orderService,
+		cfg.RabbitMQ.QuarantineQueue,
+		cfg.RabbitMQ.Partitions,
+		dispatcher,
 	)
 	if err != nil {
 		logger.Fatal("Failed to initialize RabbitMQ consumer",
@@ -94,7 +133,9 @@ func main() {
 	}
 	defer rabbitConsumer.Close()
 
-	logger.Info("RabbitMQ consumer initialized")
+	logger.Info("RabbitMQ consumer initialized",
+		zap.Strings("message_types", dispatcher.Types()),
+	)
 
 	// Start consuming messages
 	if err := rabbitConsumer.Start(ctx); err != nil {
@@ -103,9 +144,24 @@ func main() {
 
 	logger.Info("Consumer is running and processing messages",
 		zap.String("queue", cfg.RabbitMQ.Queue),
+		zap.Int("partitions", cfg.RabbitMQ.Partitions),
 		zap.String("status", "active"),
 	)
 
+	// Opt-in profiling and diagnostics on a separate, token-protected listener
+	var debugServer *debug.Server
+	if cfg.Debug.Enabled {
+		states := map[string]debug.StateFunc{
+			"pgx":  debug.PoolStats(dbConn.Pool),
+			"amqp": func() any { return rabbitConsumer.DebugState() },
+		}
+		if reporter, ok := eventPublisher.(debug.StateReporter); ok {
+			states["amqp_events"] = func() any { return reporter.DebugState() }
+		}
+		debugServer = debug.NewServer(cfg.Debug.Addr, cfg.Debug.Token, states)
+		debugServer.Start()
+	}
+
 	// Keep service running - wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -113,10 +169,20 @@ func main() {
 
 	logger.Info("Shutting down consumer gracefully...")
 
+	// Stop the consumer loop and outbox relay before connections are closed
+	cancel()
+
+	shutdownCtx, cancelShutdown := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelShutdown()
+
+	if debugServer != nil {
+		if err := debugServer.Shutdown(shutdownCtx); err != nil {
+			logger.Error("Failed to shutdown debug listener", zap.Error(err))
+		}
+	}
+
 	// Shutdown tracer
 	if shutdownTracer != nil {
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer cancel()
 		if err := shutdownTracer(shutdownCtx); err != nil {
 			logger.Error("Failed to shutdown tracer", zap.Error(err))
 		}
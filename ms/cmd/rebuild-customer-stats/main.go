@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"go.uber.org/zap"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database"
+	database "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/application/services"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/config"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
+)
+
+// rebuild-customer-stats recomputes the customer_stats read model from scratch
+// out of the orders table. It is safe to run while the consumer is processing.
+func main() {
+	ctx := context.Background()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Initialize logger
+	if err := logger.Init(cfg.App.Env, cfg.App.LogLevel); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to initialize logger: %v\n", err)
+		os.Exit(1)
+	}
+	defer logger.Sync()
+
+	// Initialize database connection
+	dbConn, err := db.NewDB(ctx, cfg.Database.DSN())
+	if err != nil {
+		logger.Fatal("Failed to connect to database", zap.Error(err))
+	}
+	defer dbConn.Close()
+
+	dbStore := db.NewStore(dbConn, database.New(dbConn.Pool))
+	orderService := services.NewOrderProcessingService(dbStore, domain.ValidationRules{})
+
+	start := time.Now()
+	logger.Info("Rebuilding customer stats")
+
+	customers, err := orderService.RebuildCustomerStats(ctx)
+	if err != nil {
+		logger.Fatal("Failed to rebuild customer stats", zap.Error(err))
+	}
+
+	logger.Info("Customer stats rebuilt",
+		zap.Int("customers", customers),
+		zap.Int64("duration_ms", time.Since(start).Milliseconds()),
+	)
+}
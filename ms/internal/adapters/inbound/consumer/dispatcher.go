@@ -0,0 +1,79 @@
+package consumer
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/ports"
+)
+
+// Dispatcher routes consumed messages to the processor registered for their type.
+// New event types are added by registering a processor, without touching the consumer loop.
+type Dispatcher struct {
+	mu       sync.RWMutex
+	handlers map[string]ports.MessageProcessor
+}
+
+// NewDispatcher creates an empty Dispatcher
+func NewDispatcher() *Dispatcher {
+	return &Dispatcher{handlers: make(map[string]ports.MessageProcessor)}
+}
+
+// Register associates a message type with its processor, replacing any previous one
+func (d *Dispatcher) Register(messageType string, handler ports.MessageProcessor) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.handlers[messageType] = handler
+}
+
+// Handler returns the processor for a message type
+func (d *Dispatcher) Handler(messageType string) (ports.MessageProcessor, bool) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	handler, ok := d.handlers[messageType]
+	return handler, ok
+}
+
+// Types returns the registered message types in alphabetical order
+func (d *Dispatcher) Types() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	types := make([]string, 0, len(d.handlers))
+	for t := range d.handlers {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// envelope is the optional wrapper {"type": "...", "data": {...}} for typed messages
+type envelope struct {
+	Type string          `json:"type"`
+	Data json.RawMessage `json:"data"`
+}
+
+// resolveMessage returns the message type and the body to hand to its processor.
+// The AMQP type property wins; otherwise an envelope type is used; untyped
+// messages are the original order payloads published by core.
+func resolveMessage(msg amqp.Delivery) (string, []byte, error) {
+	if msg.Type != "" {
+		return msg.Type, msg.Body, nil
+	}
+
+	var env envelope
+	if err := json.Unmarshal(msg.Body, &env); err != nil {
+		return "", nil, fmt.Errorf("%w: %v", domain.ErrMalformedMessage, err)
+	}
+
+	if env.Type != "" {
+		return env.Type, env.Data, nil
+	}
+
+	return domain.EventOrderCreated, msg.Body, nil
+}
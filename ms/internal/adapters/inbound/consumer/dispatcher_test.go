@@ -0,0 +1,120 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+)
+
+func TestResolveMessage(t *testing.T) {
+	tests := []struct {
+		name     string
+		msg      amqp.Delivery
+		wantType string
+		wantBody string
+		wantErr  error
+	}{
+		{
+			name:     "type property",
+			msg:      amqp.Delivery{Type: "order.cancelled", Body: []byte(`{"orderCode":1}`)},
+			wantType: "order.cancelled",
+			wantBody: `{"orderCode":1}`,
+		},
+		{
+			name:     "envelope",
+			msg:      amqp.Delivery{Body: []byte(`{"type":"customer.updated","data":{"code":7}}`)},
+			wantType: "customer.updated",
+			wantBody: `{"code":7}`,
+		},
+		{
+			name:     "untyped order payload",
+			msg:      amqp.Delivery{Body: []byte(`{"orderCode":1,"customerCode":2}`)},
+			wantType: domain.EventOrderCreated,
+			wantBody: `{"orderCode":1,"customerCode":2}`,
+		},
+		{
+			name:    "invalid json",
+			msg:     amqp.Delivery{Body: []byte(`{`)},
+			wantErr: domain.ErrMalformedMessage,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotType, gotBody, err := resolveMessage(tt.msg)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if gotType != tt.wantType {
+				t.Errorf("expected type %q, got %q", tt.wantType, gotType)
+			}
+			if string(gotBody) != tt.wantBody {
+				t.Errorf("expected body %s, got %s", tt.wantBody, gotBody)
+			}
+		})
+	}
+}
+
+// recordingProcessor records the bodies it processes
+type recordingProcessor struct {
+	bodies []string
+}
+
+func (p *recordingProcessor) ProcessMessage(ctx context.Context, messageData []byte) error {
+	p.bodies = append(p.bodies, string(messageData))
+	return nil
+}
+
+// recordingAcknowledger records how deliveries were settled
+type recordingAcknowledger struct {
+	acks, nacks int
+}
+
+func (a *recordingAcknowledger) Ack(tag uint64, multiple bool) error {
+	a.acks++
+	return nil
+}
+
+func (a *recordingAcknowledger) Nack(tag uint64, multiple, requeue bool) error {
+	a.nacks++
+	return nil
+}
+
+func (a *recordingAcknowledger) Reject(tag uint64, requeue bool) error {
+	a.nacks++
+	return nil
+}
+
+func TestProcessMessageDispatchesCustomerUpdated(t *testing.T) {
+	processor := &recordingProcessor{}
+	dispatcher := NewDispatcher()
+	dispatcher.Register(domain.EventCustomerUpdated, processor)
+
+	// Without a quarantine channel, quarantining the delivery would panic
+	c := &RabbitMQConsumer{dispatcher: dispatcher, tracer: noop.NewTracerProvider().Tracer("test")}
+
+	for name, msg := range map[string]amqp.Delivery{
+		"type property": {Type: domain.EventCustomerUpdated, Body: []byte(`{"customerCode":7}`)},
+		"envelope":      {Body: []byte(`{"type":"customer.updated","data":{"customerCode":7}}`)},
+	} {
+		t.Run(name, func(t *testing.T) {
+			processor.bodies = nil
+			acknowledger := &recordingAcknowledger{}
+			msg.Acknowledger = acknowledger
+
+			c.processMessage(context.Background(), "orders", msg)
+
+			if len(processor.bodies) != 1 || processor.bodies[0] != `{"customerCode":7}` {
+				t.Fatalf("expected the customer.updated handler to get the body once, got %v", processor.bodies)
+			}
+			if acknowledger.acks != 1 || acknowledger.nacks != 0 {
+				t.Errorf("expected a single ack, got %d acks and %d nacks", acknowledger.acks, acknowledger.nacks)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package consumer
+
+import (
+	"fmt"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// QueueNames returns the queues to consume. It must match the core publisher:
+// a single partition keeps the plain queue name, otherwise "<queue>.0" .. "<queue>.N-1".
+func QueueNames(queue string, partitions int) []string {
+	if partitions <= 1 {
+		return []string{queue}
+	}
+
+	names := make([]string, partitions)
+	for i := range partitions {
+		names[i] = fmt.Sprintf("%s.%d", queue, i)
+	}
+	return names
+}
+
+// QueueArguments returns the declaration arguments for the order queues.
+// Partition queues use single active consumer so that, across all consumer
+// replicas, only one worker reads a partition at a time.
+func QueueArguments(partitions int) amqp.Table {
+	if partitions <= 1 {
+		return nil
+	}
+	return amqp.Table{"x-single-active-consumer": true}
+}
@@ -0,0 +1,37 @@
+package consumer
+
+import (
+	"reflect"
+	"testing"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// The core publisher declares the same queues; these names and arguments must not drift
+func TestQueueNames(t *testing.T) {
+	tests := []struct {
+		partitions int
+		want       []string
+	}{
+		{partitions: 0, want: []string{"orders"}},
+		{partitions: 1, want: []string{"orders"}},
+		{partitions: 4, want: []string{"orders.0", "orders.1", "orders.2", "orders.3"}},
+	}
+
+	for _, tt := range tests {
+		if got := QueueNames("orders", tt.partitions); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("QueueNames(orders, %d) = %v, want %v", tt.partitions, got, tt.want)
+		}
+	}
+}
+
+func TestQueueArguments(t *testing.T) {
+	if got := QueueArguments(1); got != nil {
+		t.Errorf("QueueArguments(1) = %v, want nil", got)
+	}
+
+	want := amqp.Table{"x-single-active-consumer": true}
+	if got := QueueArguments(4); !reflect.DeepEqual(got, want) {
+		t.Errorf("QueueArguments(4) = %v, want %v", got, want)
+	}
+}
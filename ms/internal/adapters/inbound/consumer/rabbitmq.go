@@ -2,7 +2,7 @@ package consumer
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
@@ -12,29 +12,80 @@ import (
 	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 
-	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/application/services"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type RabbitMQConsumer struct {
-	conn    *amqp.Connection
-	channel *amqp.Channel
+	conn       *amqp.Connection
+	workers    []*queueWorker
+	quarantine *amqp.Channel
+	dispatcher *Dispatcher
+	tracer     trace.Tracer
+
+	quarantineQueue string
+}
+
+// queueWorker is a channel dedicated to a single queue.
+// Each worker handles its deliveries one at a time, in order.
+type queueWorker struct {
 	queue   string
-	service *services.OrderProcessingService
-	tracer  trace.Tracer
+	channel *amqp.Channel
 }
 
-func NewRabbitMQConsumer(url, queueName string, service *services.OrderProcessingService) (*RabbitMQConsumer, error) {
+// NewRabbitMQConsumer connects to RabbitMQ and prepares one worker per order queue.
+// With partitions > 1 it consumes "<queue>.0" .. "<queue>.N-1", giving parallelism
+// across customers while each customer's orders stay in sequence on their partition.
+// Messages whose type has no processor in the dispatcher are moved to quarantineQueue.
+func NewRabbitMQConsumer(url, queueName, quarantineQueue string, partitions int, dispatcher *Dispatcher) (*RabbitMQConsumer, error) {
 	conn, err := amqp.Dial(url)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
 
+	c := &RabbitMQConsumer{
+		conn:            conn,
+		dispatcher:      dispatcher,
+		tracer:          otel.Tracer("rabbitmq-consumer"),
+		quarantineQueue: quarantineQueue,
+	}
+
+	for _, q := range QueueNames(queueName, partitions) {
+		worker, err := newQueueWorker(conn, q, QueueArguments(partitions))
+		if err != nil {
+			c.Close()
+			return nil, err
+		}
+		c.workers = append(c.workers, worker)
+	}
+
+	c.quarantine, err = conn.Channel()
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to open quarantine channel: %w", err)
+	}
+
+	// Declare quarantine queue (idempotent operation)
+	_, err = c.quarantine.QueueDeclare(
+		quarantineQueue, // name
+		true,            // durable
+		false,           // delete when unused
+		false,           // exclusive
+		false,           // no-wait
+		nil,             // arguments
+	)
+	if err != nil {
+		c.Close()
+		return nil, fmt.Errorf("failed to declare quarantine queue: %w", err)
+	}
+
+	return c, nil
+}
+
+func newQueueWorker(conn *amqp.Connection, queueName string, args amqp.Table) (*queueWorker, error) {
 	channel, err := conn.Channel()
 	if err != nil {
-		conn.Close()
 		return nil, fmt.Errorf("failed to open channel: %w", err)
 	}
 
@@ -45,12 +96,11 @@ func NewRabbitMQConsumer(url, queueName string, service *services.OrderProcessin
 		false,     // delete when unused
 		false,     // exclusive
 		false,     // no-wait
-		nil,       // arguments
+		args,      // arguments
 	)
 	if err != nil {
 		channel.Close()
-		conn.Close()
-		return nil, fmt.Errorf("failed to declare queue: %w", err)
+		return nil, fmt.Errorf("failed to declare queue %s: %w", queueName, err)
 	}
 
 	// Set QoS - process one message at a time
@@ -61,67 +111,65 @@ func NewRabbitMQConsumer(url, queueName string, service *services.OrderProcessin
 	)
 	if err != nil {
 		channel.Close()
-		conn.Close()
 		return nil, fmt.Errorf("failed to set QoS: %w", err)
 	}
 
-	return &RabbitMQConsumer{
-		conn:    conn,
-		channel: channel,
-		queue:   queueName,
-		service: service,
-		tracer:  otel.Tracer("rabbitmq-consumer"),
-	}, nil
+	return &queueWorker{queue: queueName, channel: channel}, nil
 }
 
 func (c *RabbitMQConsumer) Start(ctx context.Context) error {
-	msgs, err := c.channel.Consume(
-		c.queue, // queue
-		"",      // consumer
-		false,   // auto-ack (disabled - we'll ack manually)
-		false,   // exclusive
-		false,   // no-local
-		false,   // no-wait
-		nil,     // args
-	)
-	if err != nil {
-		return fmt.Errorf("failed to register consumer: %w", err)
-	}
+	for _, worker := range c.workers {
+		msgs, err := worker.channel.Consume(
+			worker.queue, // queue
+			"",           // consumer
+			false,        // auto-ack (disabled - we'll ack manually)
+			false,        // exclusive
+			false,        // no-local
+			false,        // no-wait
+			nil,          // args
+		)
+		if err != nil {
+			return fmt.Errorf("failed to register consumer on %s: %w", worker.queue, err)
+		}
 
-	logger.Info("Consumer started successfully",
-		zap.String("queue", c.queue),
-		zap.String("status", "waiting_for_messages"),
-	)
+		logger.Info("Consumer started successfully",
+			zap.String("queue", worker.queue),
+			zap.String("status", "waiting_for_messages"),
+		)
 
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				logger.Info("Consumer context cancelled", zap.String("reason", "shutdown"))
-				return
-			case msg, ok := <-msgs:
-				if !ok {
-					logger.Warn("Message channel closed")
+		go func(queue string) {
+			for {
+				select {
+				case <-ctx.Done():
+					logger.Info("Consumer context cancelled",
+						zap.String("reason", "shutdown"),
+						zap.String("queue", queue),
+					)
 					return
-				}
+				case msg, ok := <-msgs:
+					if !ok {
+						logger.Warn("Message channel closed", zap.String("queue", queue))
+						return
+					}
 
-				c.processMessage(ctx, msg)
+					c.processMessage(ctx, queue, msg)
+				}
 			}
-		}
-	}()
+		}(worker.queue)
+	}
 
 	return nil
 }
 
-func (c *RabbitMQConsumer) processMessage(ctx context.Context, msg amqp.Delivery) {
+func (c *RabbitMQConsumer) processMessage(ctx context.Context, queue string, msg amqp.Delivery) {
 	startTime := time.Now()
 
 	// Create a span for the message processing
-	ctx, span := c.tracer.Start(ctx, "process_order_message",
+	ctx, span := c.tracer.Start(ctx, "process_message",
 		trace.WithSpanKind(trace.SpanKindConsumer),
 		trace.WithAttributes(
 			attribute.Int64("messaging.delivery_tag", int64(msg.DeliveryTag)),
-			attribute.String("messaging.queue", c.queue),
+			attribute.String("messaging.queue", queue),
 			attribute.Int("messaging.body_size", len(msg.Body)),
 		),
 	)
@@ -133,57 +181,46 @@ func (c *RabbitMQConsumer) processMessage(ctx context.Context, msg amqp.Delivery
 	spanID := spanCtx.SpanID().String()
 
 	logger.Info("Message received",
+		zap.String("queue", queue),
 		zap.Uint64("delivery_tag", msg.DeliveryTag),
 		zap.Int("size_bytes", len(msg.Body)),
 		zap.String("trace_id", traceID),
 		zap.String("span_id", spanID),
 	)
 
-	var orderMsg *domain.Order
-	if err := json.Unmarshal(msg.Body, &orderMsg); err != nil {
-		span.RecordError(err)
-		span.SetStatus(codes.Error, "failed to unmarshal message")
-		span.SetAttributes(attribute.String("error.type", "unmarshal_error"))
+	messageType, body, err := resolveMessage(msg)
+	if err != nil {
+		c.dropMessage(ctx, msg, err)
+		return
+	}
 
-		logger.Error("Failed to unmarshal message",
-			zap.Error(err),
-			zap.String("body", string(msg.Body)),
-			zap.String("trace_id", traceID),
-			zap.String("span_id", spanID),
-		)
+	span.SetAttributes(attribute.String("messaging.message_type", messageType))
 
-		// Reject message without requeue
-		if nackErr := msg.Nack(false, false); nackErr != nil {
-			logger.Error("Failed to nack message", zap.Error(nackErr))
-		}
+	handler, ok := c.dispatcher.Handler(messageType)
+	if !ok {
+		c.quarantineMessage(ctx, msg, messageType)
 		return
 	}
 
-	// Add order attributes to span
-	span.SetAttributes(
-		attribute.Int64("order.code", orderMsg.OrderCode),
-		attribute.Int("order.customer_code", orderMsg.CustomerCode),
-		attribute.Int("order.items_count", len(orderMsg.Items)),
-	)
+	if err := handler.ProcessMessage(ctx, body); err != nil {
+		var validationErr *domain.OrderValidationError
+		if errors.As(err, &validationErr) {
+			c.rejectMessage(ctx, msg, validationErr, startTime)
+			return
+		}
 
-	logger.Info("Processing order",
-		zap.Int64("order_code", orderMsg.OrderCode),
-		zap.Int("customer_code", orderMsg.CustomerCode),
-		zap.String("items: ", fmt.Sprintf("%v", orderMsg.Items)),
-		zap.String("created_at", orderMsg.CreatedAt.String()),
-		zap.String("trace_id", traceID),
-		zap.String("span_id", spanID),
-	)
+		if errors.Is(err, domain.ErrMalformedMessage) {
+			c.dropMessage(ctx, msg, err)
+			return
+		}
 
-	// Process the order using the service
-	if err := c.service.ProcessOrder(ctx, orderMsg); err != nil {
 		span.RecordError(err)
-		span.SetStatus(codes.Error, "failed to process order")
+		span.SetStatus(codes.Error, "failed to process message")
 		span.SetAttributes(attribute.String("error.type", "processing_error"))
 
-		logger.Error("Failed to process order",
+		logger.Error("Failed to process message",
 			zap.Error(err),
-			zap.Int64("order_code", orderMsg.OrderCode),
+			zap.String("message_type", messageType),
 			zap.Int64("duration_ms", time.Since(startTime).Milliseconds()),
 			zap.String("trace_id", traceID),
 			zap.String("span_id", spanID),
@@ -203,17 +240,17 @@ func (c *RabbitMQConsumer) processMessage(ctx context.Context, msg amqp.Delivery
 
 		logger.Error("Failed to ack message",
 			zap.Error(err),
-			zap.Int64("order_code", orderMsg.OrderCode),
+			zap.String("message_type", messageType),
 			zap.String("trace_id", traceID),
 			zap.String("span_id", spanID),
 		)
 		return
 	}
 
-	span.SetStatus(codes.Ok, "order processed successfully")
+	span.SetStatus(codes.Ok, "message processed successfully")
 
-	logger.Info("Order processed successfully",
-		zap.Int64("order_code", orderMsg.OrderCode),
+	logger.Info("Message processed successfully",
+		zap.String("message_type", messageType),
 		zap.Int64("duration_ms", time.Since(startTime).Milliseconds()),
 		zap.String("status", "acknowledged"),
 		zap.String("trace_id", traceID),
@@ -221,12 +258,155 @@ func (c *RabbitMQConsumer) processMessage(ctx context.Context, msg amqp.Delivery
 	)
 }
 
+// dropMessage rejects a message that cannot be decoded, without requeue
+func (c *RabbitMQConsumer) dropMessage(ctx context.Context, msg amqp.Delivery, err error) {
+	span := trace.SpanFromContext(ctx)
+	spanCtx := span.SpanContext()
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, "failed to unmarshal message")
+	span.SetAttributes(attribute.String("error.type", "unmarshal_error"))
+
+	logger.Error("Failed to unmarshal message",
+		zap.Error(err),
+		zap.String("body", string(msg.Body)),
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+	)
+
+	// Reject message without requeue
+	if nackErr := msg.Nack(false, false); nackErr != nil {
+		logger.Error("Failed to nack message", zap.Error(nackErr))
+	}
+}
+
+// quarantineMessage moves a message with an unregistered type to the quarantine queue.
+// The original is only acknowledged once the copy is published, otherwise it is requeued.
+func (c *RabbitMQConsumer) quarantineMessage(ctx context.Context, msg amqp.Delivery, messageType string) {
+	span := trace.SpanFromContext(ctx)
+	spanCtx := span.SpanContext()
+
+	span.SetStatus(codes.Error, "unknown message type")
+	span.SetAttributes(attribute.String("error.type", "unknown_message_type"))
+
+	headers := amqp.Table{}
+	for k, v := range msg.Headers {
+		headers[k] = v
+	}
+	headers["x-quarantine-reason"] = "unknown_message_type"
+	headers["x-original-routing-key"] = msg.RoutingKey
+
+	err := c.quarantine.PublishWithContext(
+		ctx,
+		"",                // default exchange
+		c.quarantineQueue, // routing key
+		false,             // mandatory
+		false,             // immediate
+		amqp.Publishing{
+			ContentType:  msg.ContentType,
+			Type:         messageType,
+			Headers:      headers,
+			Body:         msg.Body,
+			DeliveryMode: amqp.Persistent,
+			Timestamp:    time.Now(),
+		},
+	)
+	if err != nil {
+		span.RecordError(err)
+
+		logger.Error("Failed to quarantine message",
+			zap.Error(err),
+			zap.String("message_type", messageType),
+			zap.String("trace_id", spanCtx.TraceID().String()),
+			zap.String("span_id", spanCtx.SpanID().String()),
+		)
+
+		if nackErr := msg.Nack(false, true); nackErr != nil {
+			logger.Error("Failed to nack message for requeue", zap.Error(nackErr))
+		}
+		return
+	}
+
+	logger.Warn("Message quarantined",
+		zap.String("message_type", messageType),
+		zap.String("quarantine_queue", c.quarantineQueue),
+		zap.Strings("registered_types", c.dispatcher.Types()),
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+	)
+
+	if err := msg.Ack(false); err != nil {
+		logger.Error("Failed to ack quarantined message", zap.Error(err))
+	}
+}
+
+// rejectMessage acknowledges an order that failed business validation.
+// The rejection is already stored by the service, so requeueing would only loop.
+func (c *RabbitMQConsumer) rejectMessage(ctx context.Context, msg amqp.Delivery, validationErr *domain.OrderValidationError, startTime time.Time) {
+	span := trace.SpanFromContext(ctx)
+	spanCtx := span.SpanContext()
+
+	span.SetStatus(codes.Error, "order rejected")
+	span.SetAttributes(
+		attribute.String("error.type", "validation_error"),
+		attribute.Int("order.rejection_reasons", len(validationErr.Reasons)),
+	)
+
+	reasons := make([]string, len(validationErr.Reasons))
+	for i, reason := range validationErr.Reasons {
+		reasons[i] = reason.Field + ": " + reason.Code
+	}
+
+	logger.Warn("Order rejected",
+		zap.Int64("order_code", validationErr.OrderCode),
+		zap.Strings("reasons", reasons),
+		zap.Int64("duration_ms", time.Since(startTime).Milliseconds()),
+		zap.String("status", "rejected"),
+		zap.String("trace_id", spanCtx.TraceID().String()),
+		zap.String("span_id", spanCtx.SpanID().String()),
+	)
+
+	if err := msg.Ack(false); err != nil {
+		logger.Error("Failed to ack rejected message",
+			zap.Error(err),
+			zap.Int64("order_code", validationErr.OrderCode),
+		)
+	}
+}
+
+// DebugState describes the broker connection and each worker's channel for the debug listener
+func (c *RabbitMQConsumer) DebugState() map[string]any {
+	workers := make([]map[string]any, 0, len(c.workers))
+	for _, worker := range c.workers {
+		workers = append(workers, map[string]any{
+			"queue":          worker.queue,
+			"channel_closed": worker.channel.IsClosed(),
+		})
+	}
+
+	return map[string]any{
+		"connection_closed":         c.conn.IsClosed(),
+		"workers":                   workers,
+		"quarantine_queue":          c.quarantineQueue,
+		"quarantine_channel_closed": c.quarantine.IsClosed(),
+	}
+}
+
 func (c *RabbitMQConsumer) Close() error {
 	logger.Info("Closing RabbitMQ consumer")
 
-	if c.channel != nil {
-		if err := c.channel.Close(); err != nil {
-			logger.Error("Failed to close channel", zap.Error(err))
+	for _, worker := range c.workers {
+		if err := worker.channel.Close(); err != nil {
+			logger.Error("Failed to close channel",
+				zap.Error(err),
+				zap.String("queue", worker.queue),
+			)
+		}
+	}
+
+	if c.quarantine != nil {
+		if err := c.quarantine.Close(); err != nil {
+			logger.Error("Failed to close quarantine channel", zap.Error(err))
 		}
 	}
 
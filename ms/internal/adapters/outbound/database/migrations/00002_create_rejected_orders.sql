@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS rejected_orders (
+    id BIGSERIAL PRIMARY KEY,
+    order_code BIGINT NOT NULL,
+    customer_code BIGINT NOT NULL,
+    payload JSONB NOT NULL,
+    reasons JSONB NOT NULL,
+    rejected_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_rejected_orders_order_code ON rejected_orders(order_code);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS rejected_orders;
+-- +goose StatementEnd
@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS subtotal NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS discount_total NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS coupon_code VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS coupon_discount NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS shipping NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS tax NUMERIC(14, 2) NOT NULL DEFAULT 0;
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS discount NUMERIC(10, 2) NOT NULL DEFAULT 0;
+
+-- Orders stored so far had no discounts, shipping or tax: their total is the subtotal
+UPDATE orders SET subtotal = total;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS discount;
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS tax,
+    DROP COLUMN IF EXISTS shipping,
+    DROP COLUMN IF EXISTS coupon_discount,
+    DROP COLUMN IF EXISTS coupon_code,
+    DROP COLUMN IF EXISTS discount_total,
+    DROP COLUMN IF EXISTS subtotal;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS products (
+    id BIGSERIAL PRIMARY KEY,
+    sku VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    unit VARCHAR(20) NOT NULL DEFAULT 'un',
+    list_price NUMERIC(10, 2) NOT NULL CHECK (list_price > 0),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS sku VARCHAR(64) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS price_flagged BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_order_items_sku ON order_items(sku) WHERE sku <> '';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_order_items_sku;
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS price_flagged,
+    DROP COLUMN IF EXISTS sku;
+DROP TABLE IF EXISTS products;
+-- +goose StatementEnd
@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Read model of per-customer aggregates, maintained by the consumer with every
+-- order change and rebuilt from orders by cmd/rebuild-customer-stats
+CREATE TABLE IF NOT EXISTS customer_stats (
+    customer_code INTEGER PRIMARY KEY,
+    order_count INTEGER NOT NULL DEFAULT 0,
+    last_order_at TIMESTAMP,
+    top_product VARCHAR(255) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Lifetime value is kept per currency since orders are not converted when stored
+CREATE TABLE IF NOT EXISTS customer_lifetime_values (
+    customer_code INTEGER NOT NULL,
+    currency CHAR(3) NOT NULL,
+    lifetime_value NUMERIC(14, 2) NOT NULL DEFAULT 0,
+    PRIMARY KEY (customer_code, currency)
+);
+
+INSERT INTO customer_stats (customer_code, order_count, last_order_at, top_product)
+SELECT o.customer_code,
+       COUNT(*) FILTER (WHERE o.status <> 'cancelled'),
+       MAX(o.created_at) FILTER (WHERE o.status <> 'cancelled'),
+       COALESCE((
+           SELECT oi.product
+           FROM order_items oi
+           JOIN orders io ON io.id = oi.order_id
+           WHERE io.customer_code = o.customer_code AND io.status <> 'cancelled'
+           GROUP BY oi.product
+           ORDER BY SUM(oi.quantity) DESC, oi.product
+           LIMIT 1
+       ), '')
+FROM orders o
+GROUP BY o.customer_code
+ON CONFLICT (customer_code) DO NOTHING;
+
+INSERT INTO customer_lifetime_values (customer_code, currency, lifetime_value)
+SELECT customer_code, currency, SUM(total - refunded_total)
+FROM orders
+WHERE status <> 'cancelled'
+GROUP BY customer_code, currency
+ON CONFLICT (customer_code, currency) DO NOTHING;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS customer_lifetime_values;
+DROP TABLE IF EXISTS customer_stats;
+-- +goose StatementEnd
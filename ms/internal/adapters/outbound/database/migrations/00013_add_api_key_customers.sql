@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Keys bound to customer codes may only see those customers and their orders;
+-- an empty list leaves the key unrestricted
+ALTER TABLE api_keys ADD COLUMN IF NOT EXISTS customer_codes INTEGER[] NOT NULL DEFAULT '{}';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE api_keys DROP COLUMN IF EXISTS customer_codes;
+-- +goose StatementEnd
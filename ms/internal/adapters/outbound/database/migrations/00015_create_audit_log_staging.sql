@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Requests stage their audit entries here with a plain insert; a single chainer moves
+-- them into audit_log in id order, hashing each onto the entry before
+CREATE TABLE IF NOT EXISTS audit_log_staging (
+    id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMP NOT NULL,
+    principal VARCHAR(255) NOT NULL DEFAULT '',
+    auth_method VARCHAR(20) NOT NULL DEFAULT '',
+    action VARCHAR(100) NOT NULL,
+    resource VARCHAR(500) NOT NULL,
+    correlation_id VARCHAR(100) NOT NULL DEFAULT '',
+    source_ip VARCHAR(64) NOT NULL DEFAULT '',
+    outcome VARCHAR(20) NOT NULL,
+    status_code INTEGER NOT NULL
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS audit_log_staging;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+package database
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NumericFromMoney converts an amount to a NUMERIC parameter without losing digits
+func NumericFromMoney(m domain.Money) pgtype.Numeric {
+	return pgtype.Numeric{Int: m.Coefficient(), Exp: m.Exponent(), Valid: true}
+}
+
+// MoneyFromNumeric converts a NUMERIC column to an amount. NULL maps to zero.
+func MoneyFromNumeric(n pgtype.Numeric) (domain.Money, error) {
+	if !n.Valid {
+		return domain.Money{}, nil
+	}
+	if n.NaN || n.InfinityModifier != pgtype.Finite {
+		return domain.Money{}, fmt.Errorf("numeric value is not a finite number")
+	}
+	if n.Int == nil {
+		return domain.NewMoney(new(big.Int), n.Exp), nil
+	}
+	return domain.NewMoney(n.Int, n.Exp), nil
+}
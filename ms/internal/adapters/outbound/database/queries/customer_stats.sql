@@ -0,0 +1,46 @@
+-- name: LockCustomerStats :exec
+SELECT pg_advisory_xact_lock(sqlc.arg(customer_code)::BIGINT);
+
+-- name: RefreshCustomerStats :exec
+INSERT INTO customer_stats (customer_code, order_count, last_order_at, top_product, updated_at)
+SELECT sqlc.arg(customer_code)::INTEGER,
+       COUNT(*) FILTER (WHERE o.status <> 'cancelled'),
+       MAX(o.created_at) FILTER (WHERE o.status <> 'cancelled'),
+       COALESCE((
+           SELECT oi.product
+           FROM order_items oi
+           JOIN orders io ON io.id = oi.order_id
+           WHERE io.customer_code = sqlc.arg(customer_code)::INTEGER AND io.status <> 'cancelled'
+           GROUP BY oi.product
+           ORDER BY SUM(oi.quantity) DESC, oi.product
+           LIMIT 1
+       ), ''),
+       NOW()
+FROM orders o
+WHERE o.customer_code = sqlc.arg(customer_code)::INTEGER
+ON CONFLICT (customer_code) DO UPDATE
+SET order_count = EXCLUDED.order_count,
+    last_order_at = EXCLUDED.last_order_at,
+    top_product = EXCLUDED.top_product,
+    updated_at = EXCLUDED.updated_at;
+
+-- name: DeleteCustomerLifetimeValues :exec
+DELETE FROM customer_lifetime_values
+WHERE customer_code = $1;
+
+-- name: RefreshCustomerLifetimeValues :exec
+INSERT INTO customer_lifetime_values (customer_code, currency, lifetime_value)
+SELECT customer_code, currency, SUM(total - refunded_total)
+FROM orders
+WHERE customer_code = $1 AND status <> 'cancelled'
+GROUP BY customer_code, currency;
+
+-- name: DeleteAllCustomerStats :exec
+DELETE FROM customer_stats;
+
+-- name: DeleteAllCustomerLifetimeValues :exec
+DELETE FROM customer_lifetime_values;
+
+-- name: ListOrderCustomerCodes :many
+SELECT DISTINCT customer_code FROM orders
+ORDER BY customer_code;
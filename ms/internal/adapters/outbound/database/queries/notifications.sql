@@ -0,0 +1,2 @@
+-- name: NotifyOrderChange :exec
+SELECT pg_notify(sqlc.arg(channel)::TEXT, sqlc.arg(payload)::TEXT);
@@ -0,0 +1,10 @@
+-- name: CreateOrderRevision :one
+INSERT INTO order_revisions (order_id, version, event_type, payload, created_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING *;
+
+-- name: GetFirstOrderRevision :one
+SELECT * FROM order_revisions
+WHERE order_id = $1 AND event_type = $2
+ORDER BY version
+LIMIT 1;
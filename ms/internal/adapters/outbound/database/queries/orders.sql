@@ -1,11 +1,14 @@
 -- name: CreateOrder :one
-INSERT INTO orders (code, customer_code, created_at)
-VALUES ($1, $2, NOW())
+INSERT INTO orders (
+    code, customer_code, total, item_count, currency,
+    subtotal, discount_total, coupon_code, coupon_discount, shipping, tax, created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
 RETURNING *;
 
 -- name: CreateOrderItem :one
-INSERT INTO order_items (order_id, product, quantity, price, created_at)
-VALUES ($1, $2, $3, $4, NOW())
+INSERT INTO order_items (order_id, product, quantity, price, discount, sku, price_flagged, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
 RETURNING *;
 
 -- name: GetOrderByID :one
@@ -27,4 +30,56 @@ WHERE order_id = $1;
 
 -- name: CountOrdersByCustomer :one
 SELECT COUNT(*) FROM orders
-WHERE customer_code = $1;
+WHERE customer_code = $1 AND status <> 'cancelled';
+
+-- name: GetMaxOrderID :one
+SELECT COALESCE(MAX(id), 0)::BIGINT AS max_id FROM orders;
+
+-- name: BackfillOrderTotals :execrows
+UPDATE orders o
+SET subtotal = t.subtotal,
+    discount_total = t.item_discount + o.coupon_discount,
+    total = t.subtotal - t.item_discount - o.coupon_discount + o.shipping + o.tax,
+    item_count = t.item_count
+FROM (
+    SELECT order_id,
+           SUM(quantity * price)::NUMERIC(14, 2) AS subtotal,
+           SUM(discount)::NUMERIC(14, 2) AS item_discount,
+           COUNT(*)::INTEGER AS item_count
+    FROM order_items
+    WHERE order_id BETWEEN sqlc.arg(min_id) AND sqlc.arg(max_id)
+    GROUP BY order_id
+) t
+WHERE o.id = t.order_id
+  AND (o.subtotal <> t.subtotal
+       OR o.discount_total <> t.item_discount + o.coupon_discount
+       OR o.total <> t.subtotal - t.item_discount - o.coupon_discount + o.shipping + o.tax
+       OR o.item_count <> t.item_count);
+
+-- name: UpdateOrderStatus :execrows
+UPDATE orders
+SET status = $2,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $1 AND version = $3;
+
+-- name: UpdateOrderContents :execrows
+UPDATE orders
+SET total = $2,
+    item_count = $3,
+    subtotal = $4,
+    discount_total = $5,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $1 AND version = $6;
+
+-- name: DeleteOrderItems :exec
+DELETE FROM order_items
+WHERE order_id = $1;
+
+-- name: AddOrderRefund :execrows
+UPDATE orders
+SET refunded_total = refunded_total + $2,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $1 AND version = $3;
@@ -0,0 +1,16 @@
+-- name: CreateOutboxEvent :one
+INSERT INTO outbox_events (event_type, routing_key, payload, created_at)
+VALUES ($1, $2, $3, NOW())
+RETURNING *;
+
+-- name: GetPendingOutboxEvents :many
+SELECT * FROM outbox_events
+WHERE published_at IS NULL
+ORDER BY id
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkOutboxEventPublished :exec
+UPDATE outbox_events
+SET published_at = NOW()
+WHERE id = $1;
@@ -0,0 +1,3 @@
+-- name: GetProductsBySKUs :many
+SELECT * FROM products
+WHERE sku = ANY(sqlc.arg(skus)::TEXT[]);
@@ -0,0 +1,4 @@
+-- name: CreateRejectedOrder :one
+INSERT INTO rejected_orders (order_code, customer_code, payload, reasons, rejected_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING *;
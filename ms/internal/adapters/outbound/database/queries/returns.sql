@@ -0,0 +1,23 @@
+-- name: CreateReturn :one
+INSERT INTO returns (return_id, order_id, reason, refund_total, created_at)
+VALUES ($1, $2, $3, $4, NOW())
+RETURNING *;
+
+-- name: CreateReturnItem :one
+INSERT INTO return_items (return_id, product, quantity, price)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ReturnExists :one
+SELECT EXISTS (
+    SELECT 1 FROM returns
+    WHERE return_id = $1
+);
+
+-- name: GetReturnedQuantities :many
+SELECT ri.product, SUM(ri.quantity)::BIGINT AS quantity
+FROM return_items ri
+JOIN returns r ON r.id = ri.return_id
+WHERE r.order_id = $1
+GROUP BY ri.product
+ORDER BY ri.product;
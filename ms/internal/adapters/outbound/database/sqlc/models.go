@@ -8,18 +8,163 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type Order struct {
+type ApiKey struct {
+	ID            int64            `json:"id"`
+	Name          string           `json:"name"`
+	KeyPrefix     string           `json:"key_prefix"`
+	KeyHash       string           `json:"key_hash"`
+	Scopes        []string         `json:"scopes"`
+	Active        bool             `json:"active"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+	CustomerCodes []int32          `json:"customer_codes"`
+}
+
+type AuditLog struct {
+	ID            int64            `json:"id"`
+	OccurredAt    pgtype.Timestamp `json:"occurred_at"`
+	Principal     string           `json:"principal"`
+	AuthMethod    string           `json:"auth_method"`
+	Action        string           `json:"action"`
+	Resource      string           `json:"resource"`
+	CorrelationID string           `json:"correlation_id"`
+	SourceIp      string           `json:"source_ip"`
+	Outcome       string           `json:"outcome"`
+	StatusCode    int32            `json:"status_code"`
+	PrevHash      string           `json:"prev_hash"`
+	Hash          string           `json:"hash"`
+}
+
+type AuditLogStaging struct {
+	ID            int64            `json:"id"`
+	OccurredAt    pgtype.Timestamp `json:"occurred_at"`
+	Principal     string           `json:"principal"`
+	AuthMethod    string           `json:"auth_method"`
+	Action        string           `json:"action"`
+	Resource      string           `json:"resource"`
+	CorrelationID string           `json:"correlation_id"`
+	SourceIp      string           `json:"source_ip"`
+	Outcome       string           `json:"outcome"`
+	StatusCode    int32            `json:"status_code"`
+}
+
+type Customer struct {
 	ID           int64            `json:"id"`
 	Code         int32            `json:"code"`
-	CustomerCode int32            `json:"customer_code"`
+	Name         string           `json:"name"`
+	Email        string           `json:"email"`
+	Document     string           `json:"document"`
+	DocumentType string           `json:"document_type"`
+	Status       string           `json:"status"`
 	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+}
+
+type CustomerLifetimeValue struct {
+	CustomerCode  int32          `json:"customer_code"`
+	Currency      string         `json:"currency"`
+	LifetimeValue pgtype.Numeric `json:"lifetime_value"`
+}
+
+type CustomerStat struct {
+	CustomerCode int32            `json:"customer_code"`
+	OrderCount   int32            `json:"order_count"`
+	LastOrderAt  pgtype.Timestamp `json:"last_order_at"`
+	TopProduct   string           `json:"top_product"`
+	UpdatedAt    pgtype.Timestamp `json:"updated_at"`
+}
+
+type FxRate struct {
+	ID            int64            `json:"id"`
+	BaseCurrency  string           `json:"base_currency"`
+	QuoteCurrency string           `json:"quote_currency"`
+	Rate          pgtype.Numeric   `json:"rate"`
+	EffectiveDate pgtype.Date      `json:"effective_date"`
+	CreatedAt     pgtype.Timestamp `json:"created_at"`
+}
+
+type Order struct {
+	ID             int64            `json:"id"`
+	Code           int32            `json:"code"`
+	CustomerCode   int32            `json:"customer_code"`
+	CreatedAt      pgtype.Timestamp `json:"created_at"`
+	Total          pgtype.Numeric   `json:"total"`
+	ItemCount      int32            `json:"item_count"`
+	Status         string           `json:"status"`
+	Version        int32            `json:"version"`
+	UpdatedAt      pgtype.Timestamp `json:"updated_at"`
+	RefundedTotal  pgtype.Numeric   `json:"refunded_total"`
+	Currency       string           `json:"currency"`
+	Subtotal       pgtype.Numeric   `json:"subtotal"`
+	DiscountTotal  pgtype.Numeric   `json:"discount_total"`
+	CouponCode     string           `json:"coupon_code"`
+	CouponDiscount pgtype.Numeric   `json:"coupon_discount"`
+	Shipping       pgtype.Numeric   `json:"shipping"`
+	Tax            pgtype.Numeric   `json:"tax"`
 }
 
 type OrderItem struct {
+	ID           int64            `json:"id"`
+	OrderID      int64            `json:"order_id"`
+	Product      string           `json:"product"`
+	Quantity     int32            `json:"quantity"`
+	Price        pgtype.Numeric   `json:"price"`
+	CreatedAt    pgtype.Timestamp `json:"created_at"`
+	Discount     pgtype.Numeric   `json:"discount"`
+	Sku          string           `json:"sku"`
+	PriceFlagged bool             `json:"price_flagged"`
+}
+
+type OrderRevision struct {
 	ID        int64            `json:"id"`
 	OrderID   int64            `json:"order_id"`
-	Product   string           `json:"product"`
-	Quantity  int32            `json:"quantity"`
-	Price     pgtype.Numeric   `json:"price"`
+	Version   int32            `json:"version"`
+	EventType string           `json:"event_type"`
+	Payload   []byte           `json:"payload"`
 	CreatedAt pgtype.Timestamp `json:"created_at"`
 }
+
+type OutboxEvent struct {
+	ID          int64            `json:"id"`
+	EventType   string           `json:"event_type"`
+	RoutingKey  string           `json:"routing_key"`
+	Payload     []byte           `json:"payload"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+	PublishedAt pgtype.Timestamp `json:"published_at"`
+}
+
+type Product struct {
+	ID        int64            `json:"id"`
+	Sku       string           `json:"sku"`
+	Name      string           `json:"name"`
+	Unit      string           `json:"unit"`
+	ListPrice pgtype.Numeric   `json:"list_price"`
+	Active    bool             `json:"active"`
+	CreatedAt pgtype.Timestamp `json:"created_at"`
+	UpdatedAt pgtype.Timestamp `json:"updated_at"`
+}
+
+type RejectedOrder struct {
+	ID           int64            `json:"id"`
+	OrderCode    int64            `json:"order_code"`
+	CustomerCode int64            `json:"customer_code"`
+	Payload      []byte           `json:"payload"`
+	Reasons      []byte           `json:"reasons"`
+	RejectedAt   pgtype.Timestamp `json:"rejected_at"`
+}
+
+type Return struct {
+	ID          int64            `json:"id"`
+	ReturnID    pgtype.UUID      `json:"return_id"`
+	OrderID     int64            `json:"order_id"`
+	Reason      string           `json:"reason"`
+	RefundTotal pgtype.Numeric   `json:"refund_total"`
+	CreatedAt   pgtype.Timestamp `json:"created_at"`
+}
+
+type ReturnItem struct {
+	ID       int64          `json:"id"`
+	ReturnID int64          `json:"return_id"`
+	Product  string         `json:"product"`
+	Quantity int32          `json:"quantity"`
+	Price    pgtype.Numeric `json:"price"`
+}
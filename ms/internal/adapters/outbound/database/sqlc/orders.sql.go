@@ -11,9 +11,66 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addOrderRefund = `-- name: AddOrderRefund :execrows
+UPDATE orders
+SET refunded_total = refunded_total + $2,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $1 AND version = $3
+`
+
+type AddOrderRefundParams struct {
+	ID            int64          `json:"id"`
+	RefundedTotal pgtype.Numeric `json:"refunded_total"`
+	Version       int32          `json:"version"`
+}
+
+func (q *Queries) AddOrderRefund(ctx context.Context, arg AddOrderRefundParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addOrderRefund, arg.ID, arg.RefundedTotal, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const backfillOrderTotals = `-- name: BackfillOrderTotals :execrows
+UPDATE orders o
+SET subtotal = t.subtotal,
+    discount_total = t.item_discount + o.coupon_discount,
+    total = t.subtotal - t.item_discount - o.coupon_discount + o.shipping + o.tax,
+    item_count = t.item_count
+FROM (
+    SELECT order_id,
+           SUM(quantity * price)::NUMERIC(14, 2) AS subtotal,
+           SUM(discount)::NUMERIC(14, 2) AS item_discount,
+           COUNT(*)::INTEGER AS item_count
+    FROM order_items
+    WHERE order_id BETWEEN $1 AND $2
+    GROUP BY order_id
+) t
+WHERE o.id = t.order_id
+  AND (o.subtotal <> t.subtotal
+       OR o.discount_total <> t.item_discount + o.coupon_discount
+       OR o.total <> t.subtotal - t.item_discount - o.coupon_discount + o.shipping + o.tax
+       OR o.item_count <> t.item_count)
+`
+
+type BackfillOrderTotalsParams struct {
+	MinID int64 `json:"min_id"`
+	MaxID int64 `json:"max_id"`
+}
+
+func (q *Queries) BackfillOrderTotals(ctx context.Context, arg BackfillOrderTotalsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, backfillOrderTotals, arg.MinID, arg.MaxID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const countOrdersByCustomer = `-- name: CountOrdersByCustomer :one
 SELECT COUNT(*) FROM orders
-WHERE customer_code = $1
+WHERE customer_code = $1 AND status <> 'cancelled'
 `
 
 func (q *Queries) CountOrdersByCustomer(ctx context.Context, customerCode int32) (int64, error) {
@@ -24,39 +81,79 @@ func (q *Queries) CountOrdersByCustomer(ctx context.Context, customerCode int32)
 }
 
 const createOrder = `-- name: CreateOrder :one
-INSERT INTO orders (code, customer_code, created_at)
-VALUES ($1, $2, NOW())
-RETURNING id, code, customer_code, created_at
+INSERT INTO orders (
+    code, customer_code, total, item_count, currency,
+    subtotal, discount_total, coupon_code, coupon_discount, shipping, tax, created_at
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, NOW())
+RETURNING id, code, customer_code, created_at, total, item_count, status, version, updated_at, refunded_total, currency, subtotal, discount_total, coupon_code, coupon_discount, shipping, tax
 `
 
 type CreateOrderParams struct {
-	Code         int32 `json:"code"`
-	CustomerCode int32 `json:"customer_code"`
+	Code           int32          `json:"code"`
+	CustomerCode   int32          `json:"customer_code"`
+	Total          pgtype.Numeric `json:"total"`
+	ItemCount      int32          `json:"item_count"`
+	Currency       string         `json:"currency"`
+	Subtotal       pgtype.Numeric `json:"subtotal"`
+	DiscountTotal  pgtype.Numeric `json:"discount_total"`
+	CouponCode     string         `json:"coupon_code"`
+	CouponDiscount pgtype.Numeric `json:"coupon_discount"`
+	Shipping       pgtype.Numeric `json:"shipping"`
+	Tax            pgtype.Numeric `json:"tax"`
 }
 
 func (q *Queries) CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error) {
-	row := q.db.QueryRow(ctx, createOrder, arg.Code, arg.CustomerCode)
+	row := q.db.QueryRow(ctx, createOrder,
+		arg.Code,
+		arg.CustomerCode,
+		arg.Total,
+		arg.ItemCount,
+		arg.Currency,
+		arg.Subtotal,
+		arg.DiscountTotal,
+		arg.CouponCode,
+		arg.CouponDiscount,
+		arg.Shipping,
+		arg.Tax,
+	)
 	var i Order
 	err := row.Scan(
 		&i.ID,
 		&i.Code,
 		&i.CustomerCode,
 		&i.CreatedAt,
+		&i.Total,
+		&i.ItemCount,
+		&i.Status,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.RefundedTotal,
+		&i.Currency,
+		&i.Subtotal,
+		&i.DiscountTotal,
+		&i.CouponCode,
+		&i.CouponDiscount,
+		&i.Shipping,
+		&i.Tax,
 	)
 	return i, err
 }
 
 const createOrderItem = `-- name: CreateOrderItem :one
-INSERT INTO order_items (order_id, product, quantity, price, created_at)
-VALUES ($1, $2, $3, $4, NOW())
-RETURNING id, order_id, product, quantity, price, created_at
+INSERT INTO order_items (order_id, product, quantity, price, discount, sku, price_flagged, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+RETURNING id, order_id, product, quantity, price, created_at, discount, sku, price_flagged
 `
 
 type CreateOrderItemParams struct {
-	OrderID  int64          `json:"order_id"`
-	Product  string         `json:"product"`
-	Quantity int32          `json:"quantity"`
-	Price    pgtype.Numeric `json:"price"`
+	OrderID      int64          `json:"order_id"`
+	Product      string         `json:"product"`
+	Quantity     int32          `json:"quantity"`
+	Price        pgtype.Numeric `json:"price"`
+	Discount     pgtype.Numeric `json:"discount"`
+	Sku          string         `json:"sku"`
+	PriceFlagged bool           `json:"price_flagged"`
 }
 
 func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error) {
@@ -65,6 +162,9 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		arg.Product,
 		arg.Quantity,
 		arg.Price,
+		arg.Discount,
+		arg.Sku,
+		arg.PriceFlagged,
 	)
 	var i OrderItem
 	err := row.Scan(
@@ -74,12 +174,36 @@ func (q *Queries) CreateOrderItem(ctx context.Context, arg CreateOrderItemParams
 		&i.Quantity,
 		&i.Price,
 		&i.CreatedAt,
+		&i.Discount,
+		&i.Sku,
+		&i.PriceFlagged,
 	)
 	return i, err
 }
 
+const deleteOrderItems = `-- name: DeleteOrderItems :exec
+DELETE FROM order_items
+WHERE order_id = $1
+`
+
+func (q *Queries) DeleteOrderItems(ctx context.Context, orderID int64) error {
+	_, err := q.db.Exec(ctx, deleteOrderItems, orderID)
+	return err
+}
+
+const getMaxOrderID = `-- name: GetMaxOrderID :one
+SELECT COALESCE(MAX(id), 0)::BIGINT AS max_id FROM orders
+`
+
+func (q *Queries) GetMaxOrderID(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, getMaxOrderID)
+	var max_id int64
+	err := row.Scan(&max_id)
+	return max_id, err
+}
+
 const getOrderByCode = `-- name: GetOrderByCode :one
-SELECT id, code, customer_code, created_at FROM orders
+SELECT id, code, customer_code, created_at, total, item_count, status, version, updated_at, refunded_total, currency, subtotal, discount_total, coupon_code, coupon_discount, shipping, tax FROM orders
 WHERE code = $1
 `
 
@@ -91,12 +215,25 @@ func (q *Queries) GetOrderByCode(ctx context.Context, code int32) (Order, error)
 		&i.Code,
 		&i.CustomerCode,
 		&i.CreatedAt,
+		&i.Total,
+		&i.ItemCount,
+		&i.Status,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.RefundedTotal,
+		&i.Currency,
+		&i.Subtotal,
+		&i.DiscountTotal,
+		&i.CouponCode,
+		&i.CouponDiscount,
+		&i.Shipping,
+		&i.Tax,
 	)
 	return i, err
 }
 
 const getOrderByID = `-- name: GetOrderByID :one
-SELECT id, code, customer_code, created_at FROM orders
+SELECT id, code, customer_code, created_at, total, item_count, status, version, updated_at, refunded_total, currency, subtotal, discount_total, coupon_code, coupon_discount, shipping, tax FROM orders
 WHERE id = $1
 `
 
@@ -108,12 +245,25 @@ func (q *Queries) GetOrderByID(ctx context.Context, id int64) (Order, error) {
 		&i.Code,
 		&i.CustomerCode,
 		&i.CreatedAt,
+		&i.Total,
+		&i.ItemCount,
+		&i.Status,
+		&i.Version,
+		&i.UpdatedAt,
+		&i.RefundedTotal,
+		&i.Currency,
+		&i.Subtotal,
+		&i.DiscountTotal,
+		&i.CouponCode,
+		&i.CouponDiscount,
+		&i.Shipping,
+		&i.Tax,
 	)
 	return i, err
 }
 
 const getOrderItems = `-- name: GetOrderItems :many
-SELECT id, order_id, product, quantity, price, created_at FROM order_items
+SELECT id, order_id, product, quantity, price, created_at, discount, sku, price_flagged FROM order_items
 WHERE order_id = $1
 `
 
@@ -133,6 +283,9 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem
 			&i.Quantity,
 			&i.Price,
 			&i.CreatedAt,
+			&i.Discount,
+			&i.Sku,
+			&i.PriceFlagged,
 		); err != nil {
 			return nil, err
 		}
@@ -145,7 +298,7 @@ func (q *Queries) GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem
 }
 
 const getOrdersByCustomerCode = `-- name: GetOrdersByCustomerCode :many
-SELECT id, code, customer_code, created_at FROM orders
+SELECT id, code, customer_code, created_at, total, item_count, status, version, updated_at, refunded_total, currency, subtotal, discount_total, coupon_code, coupon_discount, shipping, tax FROM orders
 WHERE customer_code = $1
 ORDER BY created_at DESC
 `
@@ -164,6 +317,19 @@ func (q *Queries) GetOrdersByCustomerCode(ctx context.Context, customerCode int3
 			&i.Code,
 			&i.CustomerCode,
 			&i.CreatedAt,
+			&i.Total,
+			&i.ItemCount,
+			&i.Status,
+			&i.Version,
+			&i.UpdatedAt,
+			&i.RefundedTotal,
+			&i.Currency,
+			&i.Subtotal,
+			&i.DiscountTotal,
+			&i.CouponCode,
+			&i.CouponDiscount,
+			&i.Shipping,
+			&i.Tax,
 		); err != nil {
 			return nil, err
 		}
@@ -174,3 +340,60 @@ func (q *Queries) GetOrdersByCustomerCode(ctx context.Context, customerCode int3
 	}
 	return items, nil
 }
+
+const updateOrderContents = `-- name: UpdateOrderContents :execrows
+UPDATE orders
+SET total = $2,
+    item_count = $3,
+    subtotal = $4,
+    discount_total = $5,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $1 AND version = $6
+`
+
+type UpdateOrderContentsParams struct {
+	ID            int64          `json:"id"`
+	Total         pgtype.Numeric `json:"total"`
+	ItemCount     int32          `json:"item_count"`
+	Subtotal      pgtype.Numeric `json:"subtotal"`
+	DiscountTotal pgtype.Numeric `json:"discount_total"`
+	Version       int32          `json:"version"`
+}
+
+func (q *Queries) UpdateOrderContents(ctx context.Context, arg UpdateOrderContentsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateOrderContents,
+		arg.ID,
+		arg.Total,
+		arg.ItemCount,
+		arg.Subtotal,
+		arg.DiscountTotal,
+		arg.Version,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const updateOrderStatus = `-- name: UpdateOrderStatus :execrows
+UPDATE orders
+SET status = $2,
+    version = version + 1,
+    updated_at = NOW()
+WHERE id = $1 AND version = $3
+`
+
+type UpdateOrderStatusParams struct {
+	ID      int64  `json:"id"`
+	Status  string `json:"status"`
+	Version int32  `json:"version"`
+}
+
+func (q *Queries) UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateOrderStatus, arg.ID, arg.Status, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
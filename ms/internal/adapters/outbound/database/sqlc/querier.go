@@ -6,16 +6,43 @@ package database
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type Querier interface {
+	AddOrderRefund(ctx context.Context, arg AddOrderRefundParams) (int64, error)
+	BackfillOrderTotals(ctx context.Context, arg BackfillOrderTotalsParams) (int64, error)
 	CountOrdersByCustomer(ctx context.Context, customerCode int32) (int64, error)
 	CreateOrder(ctx context.Context, arg CreateOrderParams) (Order, error)
 	CreateOrderItem(ctx context.Context, arg CreateOrderItemParams) (OrderItem, error)
+	CreateOrderRevision(ctx context.Context, arg CreateOrderRevisionParams) (OrderRevision, error)
+	CreateOutboxEvent(ctx context.Context, arg CreateOutboxEventParams) (OutboxEvent, error)
+	CreateRejectedOrder(ctx context.Context, arg CreateRejectedOrderParams) (RejectedOrder, error)
+	CreateReturn(ctx context.Context, arg CreateReturnParams) (Return, error)
+	CreateReturnItem(ctx context.Context, arg CreateReturnItemParams) (ReturnItem, error)
+	DeleteAllCustomerLifetimeValues(ctx context.Context) error
+	DeleteAllCustomerStats(ctx context.Context) error
+	DeleteCustomerLifetimeValues(ctx context.Context, customerCode int32) error
+	DeleteOrderItems(ctx context.Context, orderID int64) error
+	GetFirstOrderRevision(ctx context.Context, arg GetFirstOrderRevisionParams) (OrderRevision, error)
+	GetMaxOrderID(ctx context.Context) (int64, error)
 	GetOrderByCode(ctx context.Context, code int32) (Order, error)
 	GetOrderByID(ctx context.Context, id int64) (Order, error)
 	GetOrderItems(ctx context.Context, orderID int64) ([]OrderItem, error)
 	GetOrdersByCustomerCode(ctx context.Context, customerCode int32) ([]Order, error)
+	GetPendingOutboxEvents(ctx context.Context, limit int32) ([]OutboxEvent, error)
+	GetProductsBySKUs(ctx context.Context, skus []string) ([]Product, error)
+	GetReturnedQuantities(ctx context.Context, orderID int64) ([]GetReturnedQuantitiesRow, error)
+	ListOrderCustomerCodes(ctx context.Context) ([]int32, error)
+	LockCustomerStats(ctx context.Context, customerCode int64) error
+	MarkOutboxEventPublished(ctx context.Context, id int64) error
+	NotifyOrderChange(ctx context.Context, arg NotifyOrderChangeParams) error
+	RefreshCustomerLifetimeValues(ctx context.Context, customerCode int32) error
+	RefreshCustomerStats(ctx context.Context, customerCode int32) error
+	ReturnExists(ctx context.Context, returnID pgtype.UUID) (bool, error)
+	UpdateOrderContents(ctx context.Context, arg UpdateOrderContentsParams) (int64, error)
+	UpdateOrderStatus(ctx context.Context, arg UpdateOrderStatusParams) (int64, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,124 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/ports"
+)
+
+type RabbitMQPublisher struct {
+	conn     *amqp.Connection
+	channel  *amqp.Channel
+	exchange string
+}
+
+func NewRabbitMQPublisher(url, exchange string) (ports.EventPublisher, error) {
+	conn, err := amqp.Dial(url)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to RabbitMQ: %w", err)
+	}
+
+	channel, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to open channel: %w", err)
+	}
+
+	// Declare exchange (idempotent operation)
+	err = channel.ExchangeDeclare(
+		exchange, // name
+		"topic",  // type
+		true,     // durable
+		false,    // auto-deleted
+		false,    // internal
+		false,    // no-wait
+		nil,      // arguments
+	)
+	if err != nil {
+		channel.Close()
+		conn.Close()
+		return nil, fmt.Errorf("failed to declare exchange: %w", err)
+	}
+
+	// Enable publisher confirms so an outbox row is only marked once the broker has it
+	if err := channel.Confirm(false); err != nil {
+		channel.Close()
+		conn.Close()
+		return nil, fmt.Errorf("failed to enable publisher confirms: %w", err)
+	}
+
+	logger.Info("RabbitMQ event publisher initialized",
+		zap.String("exchange", exchange),
+	)
+
+	return &RabbitMQPublisher{
+		conn:     conn,
+		channel:  channel,
+		exchange: exchange,
+	}, nil
+}
+
+func (p *RabbitMQPublisher) Publish(ctx context.Context, eventType, routingKey string, body []byte) error {
+	confirmation, err := p.channel.PublishWithDeferredConfirmWithContext(
+		ctx,
+		p.exchange, // exchange
+		routingKey, // routing key
+		false,      // mandatory
+		false,      // immediate
+		amqp.Publishing{
+			ContentType:  "application/json",
+			Type:         eventType,
+			Body:         body,
+			DeliveryMode: amqp.Persistent, // make message persistent
+			Timestamp:    time.Now(),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	acked, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to confirm event: %w", err)
+	}
+	if !acked {
+		return fmt.Errorf("event %s was nacked by the broker", eventType)
+	}
+
+	return nil
+}
+
+// DebugState describes the broker connection and channel for the debug listener
+func (p *RabbitMQPublisher) DebugState() map[string]any {
+	return map[string]any{
+		"connection_closed": p.conn.IsClosed(),
+		"channel_closed":    p.channel.IsClosed(),
+		"exchange":          p.exchange,
+	}
+}
+
+func (p *RabbitMQPublisher) Close() error {
+	logger.Info("Closing RabbitMQ event publisher")
+
+	if p.channel != nil {
+		if err := p.channel.Close(); err != nil {
+			logger.Error("Failed to close channel", zap.Error(err))
+		}
+	}
+
+	if p.conn != nil {
+		if err := p.conn.Close(); err != nil {
+			logger.Error("Failed to close connection", zap.Error(err))
+			return err
+		}
+	}
+
+	logger.Info("RabbitMQ event publisher closed successfully")
+	return nil
+}
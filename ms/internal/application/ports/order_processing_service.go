@@ -14,8 +14,8 @@ type OrderProcessingService interface {
 	// ValidateOrder validates order data before processing
 	ValidateOrder(ctx context.Context, order *domain.Order) error
 
-	// CalculateOrderTotal calculates the total value of an order
-	CalculateOrderTotal(order *domain.Order) float64
+	// CalculateOrderTotal calculates the exact total value of an order
+	CalculateOrderTotal(order *domain.Order) domain.Money
 
 	// OrderExists checks if an order already exists by code
 	OrderExists(ctx context.Context, orderCode int32) (bool, error)
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	database "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// refreshCustomerStats recomputes the customer_stats read model of a customer from its
+// orders using the caller's transaction, so the projection commits with the order change.
+// The advisory lock serializes refreshes of the same customer with the rebuild command.
+func refreshCustomerStats(ctx context.Context, queries *database.Queries, customerCode int) error {
+	if err := queries.LockCustomerStats(ctx, int64(customerCode)); err != nil {
+		return fmt.Errorf("error locking customer stats %d %v", customerCode, err)
+	}
+
+	if err := queries.RefreshCustomerStats(ctx, int32(customerCode)); err != nil {
+		return fmt.Errorf("error refreshing customer stats %d: %w", customerCode, err)
+	}
+
+	if err := queries.DeleteCustomerLifetimeValues(ctx, int32(customerCode)); err != nil {
+		return fmt.Errorf("error clearing customer lifetime values %d %v", customerCode, err)
+	}
+
+	if err := queries.RefreshCustomerLifetimeValues(ctx, int32(customerCode)); err != nil {
+		return fmt.Errorf("error refreshing customer lifetime values %d: %w", customerCode, err)
+	}
+
+	return nil
+}
+
+// RefreshCustomerStats recomputes the customer_stats read model of one customer in its
+// own transaction and announces the change, so cached summaries of the customer are dropped
+func (s *OrderProcessingService) RefreshCustomerStats(ctx context.Context, customerCode int) error {
+	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("error opening transaction %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+
+	if err := refreshCustomerStats(ctx, queries, customerCode); err != nil {
+		return err
+	}
+
+	if err := notifyOrderChange(ctx, queries, domain.OrderChange{CustomerCode: customerCode}); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction %v", err)
+	}
+
+	return nil
+}
+
+// RebuildCustomerStats recomputes the whole customer_stats read model from the orders table
+// in a single transaction, so readers keep the previous projection until it commits.
+// It returns the number of customers written.
+func (s *OrderProcessingService) RebuildCustomerStats(ctx context.Context) (int, error) {
+	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("error opening transaction %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+
+	if err := queries.DeleteAllCustomerStats(ctx); err != nil {
+		return 0, fmt.Errorf("error clearing customer stats %v", err)
+	}
+	if err := queries.DeleteAllCustomerLifetimeValues(ctx); err != nil {
+		return 0, fmt.Errorf("error clearing customer lifetime values %v", err)
+	}
+
+	customerCodes, err := queries.ListOrderCustomerCodes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error listing customers %v", err)
+	}
+
+	for _, customerCode := range customerCodes {
+		if err := refreshCustomerStats(ctx, queries, int(customerCode)); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := notifyOrderChange(ctx, queries, domain.OrderChange{All: true}); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("error committing transaction %v", err)
+	}
+
+	return len(customerCodes), nil
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database"
+	database "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrConcurrentModification is returned when the order changed between being read and updated.
+// The message is requeued and applied again against the new version.
+var ErrConcurrentModification = errors.New("order was modified concurrently")
+
+// CancelOrder marks an order as cancelled, bumping its version and recording a revision.
+// Cancelling an already cancelled order is a no-op so redeliveries are harmless.
+func (s *OrderProcessingService) CancelOrder(ctx context.Context, cancellation *domain.OrderCancellation) error {
+	if cancellation == nil {
+		return s.rejectOnValidation(ctx, 0, cancellation, emptyPayloadError(0))
+	}
+
+	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("error opening transaction %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+
+	dbOrder, order, err := loadOrder(ctx, queries, cancellation.OrderCode)
+	if err != nil {
+		return s.rejectOnValidation(ctx, cancellation.CustomerCode, cancellation, err)
+	}
+
+	if order.Status == domain.OrderStatusCancelled {
+		return nil
+	}
+
+	if reasons := order.CheckVersion(cancellation.ExpectedVersion); len(reasons) > 0 {
+		return s.rejectOnValidation(ctx, order.CustomerCode, cancellation, &domain.OrderValidationError{
+			OrderCode: order.OrderCode,
+			Reasons:   reasons,
+		})
+	}
+
+	rows, err := queries.UpdateOrderStatus(ctx, database.UpdateOrderStatusParams{
+		ID:      dbOrder.ID,
+		Status:  domain.OrderStatusCancelled,
+		Version: dbOrder.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("error cancelling order %v", err)
+	}
+	if rows == 0 {
+		return ErrConcurrentModification
+	}
+
+	order.Status = domain.OrderStatusCancelled
+	order.Version++
+	if err := writeRevision(ctx, queries, dbOrder.ID, domain.EventOrderCancelled, order); err != nil {
+		return err
+	}
+
+	if err := refreshCustomerStats(ctx, queries, order.CustomerCode); err != nil {
+		return err
+	}
+
+	if err := notifyOrderChange(ctx, queries, domain.OrderChange{OrderCode: order.OrderCode, CustomerCode: order.CustomerCode}); err != nil {
+		return err
+	}
+
+	err = enqueueEvent(ctx, queries, domain.EventOrderCancelled, domain.OrderCancelledEvent{
+		OrderCode:    order.OrderCode,
+		CustomerCode: order.CustomerCode,
+		Version:      order.Version,
+		Reason:       cancellation.Reason,
+		CancelledAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction %v", err)
+	}
+
+	return nil
+}
+
+// AmendOrder adds, removes or changes items of an order and stores the recomputed total.
+// The amended order must still pass the validation rules applied to new orders.
+func (s *OrderProcessingService) AmendOrder(ctx context.Context, amendment *domain.OrderAmendment) error {
+	if amendment == nil {
+		return s.rejectOnValidation(ctx, 0, amendment, emptyPayloadError(0))
+	}
+
+	if err := s.amendOrder(ctx, amendment); err != nil {
+		return s.rejectOnOverflow(ctx, amendment.CustomerCode, amendment, amendment.OrderCode, err)
+	}
+
+	return nil
+}
+
+// amendOrder applies an amendment and stores the amended order in one transaction
+func (s *OrderProcessingService) amendOrder(ctx context.Context, amendment *domain.OrderAmendment) error {
+	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("error opening transaction %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+
+	dbOrder, order, err := loadOrder(ctx, queries, amendment.OrderCode)
+	if err != nil {
+		return s.rejectOnValidation(ctx, amendment.CustomerCode, amendment, err)
+	}
+
+	reasons := order.CheckVersion(amendment.ExpectedVersion)
+	if order.Status == domain.OrderStatusCancelled {
+		reasons = append(reasons, domain.RejectionReason{
+			Field:   "orderCode",
+			Code:    domain.ReasonOrderCancelled,
+			Message: "cancelled orders cannot be amended",
+		})
+	}
+	if len(reasons) == 0 {
+		// Items given only by SKU are matched against the order by their catalog name
+		catalog, err := loadCatalog(ctx, queries, amendment.SKUs())
+		if err != nil {
+			return err
+		}
+		reasons = amendment.ApplyCatalog(catalog, s.rules.PriceTolerance)
+	}
+	if len(reasons) == 0 {
+		reasons = order.ApplyAmendment(amendment)
+	}
+	if len(reasons) == 0 {
+		reasons, err = s.applyCatalog(ctx, queries, order)
+		if err != nil {
+			return err
+		}
+	}
+	if len(reasons) == 0 {
+		reasons = order.CheckReturnedQuantities()
+	}
+	if len(reasons) == 0 {
+		reasons = order.Validate(s.rules)
+	}
+	if len(reasons) > 0 {
+		return s.rejectOnValidation(ctx, order.CustomerCode, amendment, &domain.OrderValidationError{
+			OrderCode: order.OrderCode,
+			Reasons:   reasons,
+		})
+	}
+
+	breakdown := order.CalculateBreakdown().Round(totalScale)
+	total := breakdown.Total
+
+	rows, err := queries.UpdateOrderContents(ctx, database.UpdateOrderContentsParams{
+		ID:            dbOrder.ID,
+		Total:         db.NumericFromMoney(total),
+		ItemCount:     int32(len(order.Items)),
+		Subtotal:      db.NumericFromMoney(breakdown.Subtotal),
+		DiscountTotal: db.NumericFromMoney(breakdown.Discounts),
+		Version:       dbOrder.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("error amending order: %w", err)
+	}
+	if rows == 0 {
+		return ErrConcurrentModification
+	}
+
+	if err := queries.DeleteOrderItems(ctx, dbOrder.ID); err != nil {
+		return fmt.Errorf("error removing order items %v", err)
+	}
+
+	if err := s.insertItems(ctx, queries, dbOrder.ID, order.Items); err != nil {
+		return err
+	}
+
+	order.Version++
+	if err := writeRevision(ctx, queries, dbOrder.ID, domain.EventOrderAmended, order); err != nil {
+		return err
+	}
+
+	if err := refreshCustomerStats(ctx, queries, order.CustomerCode); err != nil {
+		return err
+	}
+
+	if err := notifyOrderChange(ctx, queries, domain.OrderChange{OrderCode: order.OrderCode, CustomerCode: order.CustomerCode}); err != nil {
+		return err
+	}
+
+	err = enqueueEvent(ctx, queries, domain.EventOrderAmended, domain.OrderAmendedEvent{
+		OrderCode:    order.OrderCode,
+		CustomerCode: order.CustomerCode,
+		Version:      order.Version,
+		Total:        total.StringFixed(totalScale),
+		Currency:     order.Currency,
+		ItemCount:    len(order.Items),
+		FlaggedSKUs:  order.FlaggedSKUs(),
+		AmendedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction %v", err)
+	}
+
+	return nil
+}
+
+// loadOrder reads an order with its items and returned quantities inside the caller's transaction.
+// A missing order is reported as an *domain.OrderValidationError so the message is rejected.
+func loadOrder(ctx context.Context, queries *database.Queries, orderCode int64) (database.Order, *domain.Order, error) {
+	dbOrder, err := queries.GetOrderByCode(ctx, int32(orderCode))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return dbOrder, nil, &domain.OrderValidationError{
+			OrderCode: orderCode,
+			Reasons: []domain.RejectionReason{{
+				Field:   "orderCode",
+				Code:    domain.ReasonOrderNotFound,
+				Message: fmt.Sprintf("order %d does not exist", orderCode),
+			}},
+		}
+	}
+	if err != nil {
+		return dbOrder, nil, fmt.Errorf("error loading order %v", err)
+	}
+
+	dbItems, err := queries.GetOrderItems(ctx, dbOrder.ID)
+	if err != nil {
+		return dbOrder, nil, fmt.Errorf("error loading order items %v", err)
+	}
+
+	items, err := itemsFromDB(dbItems)
+	if err != nil {
+		return dbOrder, nil, err
+	}
+
+	dbReturned, err := queries.GetReturnedQuantities(ctx, dbOrder.ID)
+	if err != nil {
+		return dbOrder, nil, fmt.Errorf("error loading returned quantities %v", err)
+	}
+
+	var returned map[string]int
+	if len(dbReturned) > 0 {
+		returned = make(map[string]int, len(dbReturned))
+		for _, row := range dbReturned {
+			returned[row.Product] = int(row.Quantity)
+		}
+	}
+
+	order := &domain.Order{
+		CustomerCode: int(dbOrder.CustomerCode),
+		OrderCode:    int64(dbOrder.Code),
+		Items:        items,
+		Currency:     dbOrder.Currency,
+		Status:       dbOrder.Status,
+		Version:      int(dbOrder.Version),
+		Returned:     returned,
+		CreatedAt:    dbOrder.CreatedAt.Time,
+	}
+	if err := applyPricing(order, dbOrder); err != nil {
+		return dbOrder, nil, err
+	}
+
+	return dbOrder, order, nil
+}
+
+// emptyPayloadError reports a message whose body decoded to null
+func emptyPayloadError(orderCode int64) *domain.OrderValidationError {
+	return &domain.OrderValidationError{
+		OrderCode: orderCode,
+		Reasons: []domain.RejectionReason{{
+			Field:   "order",
+			Code:    domain.ReasonEmptyPayload,
+			Message: "order payload is empty",
+		}},
+	}
+}
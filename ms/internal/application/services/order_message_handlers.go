@@ -0,0 +1,214 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/ports"
+)
+
+var (
+	_ ports.MessageProcessor = (*OrderCreatedHandler)(nil)
+	_ ports.MessageProcessor = (*OrderCancelledHandler)(nil)
+	_ ports.MessageProcessor = (*OrderAmendedHandler)(nil)
+	_ ports.MessageProcessor = (*OrderReturnedHandler)(nil)
+	_ ports.MessageProcessor = (*CustomerUpdatedHandler)(nil)
+)
+
+// OrderCreatedHandler handles order.created messages
+type OrderCreatedHandler struct {
+	service *OrderProcessingService
+}
+
+// NewOrderCreatedHandler creates a new OrderCreatedHandler with dependency injection
+func NewOrderCreatedHandler(service *OrderProcessingService) *OrderCreatedHandler {
+	return &OrderCreatedHandler{service: service}
+}
+
+// ProcessMessage decodes the order and stores it through the OrderProcessingService
+func (h *OrderCreatedHandler) ProcessMessage(ctx context.Context, messageData []byte) error {
+	var order *domain.Order
+	if err := json.Unmarshal(messageData, &order); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrMalformedMessage, err)
+	}
+
+	if order != nil {
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(
+			attribute.Int64("order.code", order.OrderCode),
+			attribute.Int("order.customer_code", order.CustomerCode),
+			attribute.Int("order.items_count", len(order.Items)),
+		)
+
+		logger.Info("Processing order",
+			zap.Int64("order_code", order.OrderCode),
+			zap.Int("customer_code", order.CustomerCode),
+			zap.String("items: ", fmt.Sprintf("%v", order.Items)),
+			zap.String("created_at", order.CreatedAt.String()),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+	}
+
+	return h.service.ProcessOrder(ctx, order)
+}
+
+// OrderCancelledHandler handles order.cancelled messages
+type OrderCancelledHandler struct {
+	service *OrderProcessingService
+}
+
+// NewOrderCancelledHandler creates a new OrderCancelledHandler with dependency injection
+func NewOrderCancelledHandler(service *OrderProcessingService) *OrderCancelledHandler {
+	return &OrderCancelledHandler{service: service}
+}
+
+// ProcessMessage decodes the cancellation and applies it through the OrderProcessingService
+func (h *OrderCancelledHandler) ProcessMessage(ctx context.Context, messageData []byte) error {
+	var cancellation *domain.OrderCancellation
+	if err := json.Unmarshal(messageData, &cancellation); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrMalformedMessage, err)
+	}
+
+	if cancellation != nil {
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(
+			attribute.Int64("order.code", cancellation.OrderCode),
+			attribute.Int("order.customer_code", cancellation.CustomerCode),
+		)
+
+		logger.Info("Cancelling order",
+			zap.Int64("order_code", cancellation.OrderCode),
+			zap.Int("customer_code", cancellation.CustomerCode),
+			zap.Int("expected_version", cancellation.ExpectedVersion),
+			zap.String("reason", cancellation.Reason),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+	}
+
+	return h.service.CancelOrder(ctx, cancellation)
+}
+
+// OrderAmendedHandler handles order.amended messages
+type OrderAmendedHandler struct {
+	service *OrderProcessingService
+}
+
+// NewOrderAmendedHandler creates a new OrderAmendedHandler with dependency injection
+func NewOrderAmendedHandler(service *OrderProcessingService) *OrderAmendedHandler {
+	return &OrderAmendedHandler{service: service}
+}
+
+// ProcessMessage decodes the amendment and applies it through the OrderProcessingService
+func (h *OrderAmendedHandler) ProcessMessage(ctx context.Context, messageData []byte) error {
+	var amendment *domain.OrderAmendment
+	if err := json.Unmarshal(messageData, &amendment); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrMalformedMessage, err)
+	}
+
+	if amendment != nil {
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(
+			attribute.Int64("order.code", amendment.OrderCode),
+			attribute.Int("order.customer_code", amendment.CustomerCode),
+		)
+
+		logger.Info("Amending order",
+			zap.Int64("order_code", amendment.OrderCode),
+			zap.Int("customer_code", amendment.CustomerCode),
+			zap.Int("expected_version", amendment.ExpectedVersion),
+			zap.Int("add_items", len(amendment.AddItems)),
+			zap.Int("remove_products", len(amendment.RemoveProducts)),
+			zap.Int("change_items", len(amendment.ChangeItems)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+	}
+
+	return h.service.AmendOrder(ctx, amendment)
+}
+
+// OrderReturnedHandler handles order.returned messages
+type OrderReturnedHandler struct {
+	service *OrderProcessingService
+}
+
+// NewOrderReturnedHandler creates a new OrderReturnedHandler with dependency injection
+func NewOrderReturnedHandler(service *OrderProcessingService) *OrderReturnedHandler {
+	return &OrderReturnedHandler{service: service}
+}
+
+// ProcessMessage decodes the return and stores it through the OrderProcessingService
+func (h *OrderReturnedHandler) ProcessMessage(ctx context.Context, messageData []byte) error {
+	var orderReturn *domain.OrderReturn
+	if err := json.Unmarshal(messageData, &orderReturn); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrMalformedMessage, err)
+	}
+
+	if orderReturn != nil {
+		span := trace.SpanFromContext(ctx)
+		span.SetAttributes(
+			attribute.String("return.id", orderReturn.ReturnID),
+			attribute.Int64("order.code", orderReturn.OrderCode),
+			attribute.Int("order.customer_code", orderReturn.CustomerCode),
+			attribute.Int("return.items_count", len(orderReturn.Items)),
+		)
+
+		logger.Info("Returning order items",
+			zap.String("return_id", orderReturn.ReturnID),
+			zap.Int64("order_code", orderReturn.OrderCode),
+			zap.Int("customer_code", orderReturn.CustomerCode),
+			zap.String("items", fmt.Sprintf("%v", orderReturn.Items)),
+			zap.String("trace_id", span.SpanContext().TraceID().String()),
+			zap.String("span_id", span.SpanContext().SpanID().String()),
+		)
+	}
+
+	return h.service.ReturnOrder(ctx, orderReturn)
+}
+
+// customerStatsRefresher recomputes the read models derived from a customer's orders
+type customerStatsRefresher interface {
+	RefreshCustomerStats(ctx context.Context, customerCode int) error
+}
+
+// CustomerUpdatedHandler handles customer.updated messages
+type CustomerUpdatedHandler struct {
+	service customerStatsRefresher
+}
+
+// NewCustomerUpdatedHandler creates a new CustomerUpdatedHandler with dependency injection
+func NewCustomerUpdatedHandler(service *OrderProcessingService) *CustomerUpdatedHandler {
+	return &CustomerUpdatedHandler{service: service}
+}
+
+// ProcessMessage decodes the update and refreshes the customer's stats through the OrderProcessingService
+func (h *CustomerUpdatedHandler) ProcessMessage(ctx context.Context, messageData []byte) error {
+	var update domain.CustomerUpdate
+	if err := json.Unmarshal(messageData, &update); err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrMalformedMessage, err)
+	}
+
+	if update.CustomerCode <= 0 {
+		return fmt.Errorf("%w: customer code must be positive", domain.ErrMalformedMessage)
+	}
+
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int("customer.code", update.CustomerCode))
+
+	logger.Info("Refreshing customer stats",
+		zap.Int("customer_code", update.CustomerCode),
+		zap.String("trace_id", span.SpanContext().TraceID().String()),
+		zap.String("span_id", span.SpanContext().SpanID().String()),
+	)
+
+	return h.service.RefreshCustomerStats(ctx, update.CustomerCode)
+}
@@ -0,0 +1,47 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+)
+
+// stubCustomerStatsRefresher records the customers it is asked to refresh
+type stubCustomerStatsRefresher struct {
+	refreshed []int
+}
+
+func (s *stubCustomerStatsRefresher) RefreshCustomerStats(ctx context.Context, customerCode int) error {
+	s.refreshed = append(s.refreshed, customerCode)
+	return nil
+}
+
+func TestCustomerUpdatedHandler(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		want    []int
+		wantErr error
+	}{
+		{name: "refreshes the customer", body: `{"customerCode":7,"updatedAt":"2026-01-02T10:00:00Z"}`, want: []int{7}},
+		{name: "invalid json", body: `{`, wantErr: domain.ErrMalformedMessage},
+		{name: "missing customer code", body: `{"updatedAt":"2026-01-02T10:00:00Z"}`, wantErr: domain.ErrMalformedMessage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refresher := &stubCustomerStatsRefresher{}
+			handler := &CustomerUpdatedHandler{service: refresher}
+
+			err := handler.ProcessMessage(context.Background(), []byte(tt.body))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if len(refresher.refreshed) != len(tt.want) || (len(tt.want) > 0 && refresher.refreshed[0] != tt.want[0]) {
+				t.Errorf("expected refreshed customers %v, got %v", tt.want, refresher.refreshed)
+			}
+		})
+	}
+}
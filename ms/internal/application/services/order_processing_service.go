@@ -2,71 +2,532 @@ package services
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	db "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database"
 	database "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/application/ports"
 	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+var _ ports.OrderProcessingService = (*OrderProcessingService)(nil)
+
+// totalScale is the number of decimal places of stored totals and refunds
+const totalScale = 2
+
+// numericValueOutOfRange is the Postgres error raised when a value does not fit its column
+const numericValueOutOfRange = "22003"
+
 // OrderProcessingService handles business logic for processing orders from RabbitMQ
 type OrderProcessingService struct {
 	queries *db.Store
+	rules   domain.ValidationRules
 }
 
 // NewOrderProcessingService creates a new OrderProcessingService with dependency injection
-func NewOrderProcessingService(queries *db.Store) *OrderProcessingService {
+func NewOrderProcessingService(queries *db.Store, rules domain.ValidationRules) *OrderProcessingService {
 	return &OrderProcessingService{
 		queries: queries,
+		rules:   rules,
 	}
 }
 
-// ProcessOrder processes an order message from RabbitMQ and saves to database
+// ProcessOrder processes an order message from RabbitMQ and saves to database.
+// Orders failing validation are stored as rejected and an *domain.OrderValidationError is returned.
 func (s *OrderProcessingService) ProcessOrder(ctx context.Context, order *domain.Order) error {
+	if err := s.ValidateOrder(ctx, order); err != nil {
+		var customerCode int
+		if order != nil {
+			customerCode = order.CustomerCode
+		}
+		return s.rejectOnValidation(ctx, customerCode, order, err)
+	}
+
+	stored, err := s.createdOrder(ctx, int32(order.OrderCode))
+	if err != nil {
+		return err
+	}
+	if stored != nil {
+		if reasons := order.CheckRedelivery(stored); len(reasons) > 0 {
+			return s.rejectOnValidation(ctx, order.CustomerCode, order, &domain.OrderValidationError{
+				OrderCode: order.OrderCode,
+				Reasons:   reasons,
+			})
+		}
+		// Redelivered message for an order we already stored
+		return nil
+	}
+
+	if err := s.storeOrder(ctx, order); err != nil {
+		return s.rejectOnOverflow(ctx, order.CustomerCode, order, order.OrderCode, err)
+	}
+
+	return nil
+}
+
+// storeOrder saves a validated order with its items, revision and processed event in one transaction
+func (s *OrderProcessingService) storeOrder(ctx context.Context, order *domain.Order) error {
 	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("error opening transaction %v", err)
 	}
-	defer tx.Commit(ctx)
+	defer tx.Rollback(ctx)
 
 	queries := s.queries.WithTx(tx)
 
+	breakdown := order.CalculateBreakdown().Round(totalScale)
+	total := breakdown.Total
+
+	if order.Currency == "" {
+		order.Currency = domain.DefaultCurrency
+	}
+
+	var couponCode string
+	if order.Coupon != nil {
+		couponCode = order.Coupon.Code
+	}
+
 	args := database.CreateOrderParams{
-		Code:         int32(order.OrderCode),
-		CustomerCode: int32(order.CustomerCode),
+		Code:           int32(order.OrderCode),
+		CustomerCode:   int32(order.CustomerCode),
+		Total:          db.NumericFromMoney(total),
+		ItemCount:      int32(len(order.Items)),
+		Currency:       order.Currency,
+		Subtotal:       db.NumericFromMoney(breakdown.Subtotal),
+		DiscountTotal:  db.NumericFromMoney(breakdown.Discounts),
+		CouponCode:     couponCode,
+		CouponDiscount: db.NumericFromMoney(breakdown.CouponDiscount),
+		Shipping:       db.NumericFromMoney(breakdown.Shipping),
+		Tax:            db.NumericFromMoney(breakdown.Tax),
 	}
 
 	orderCreated, err := queries.CreateOrder(ctx, args)
 	if err != nil {
-		tx.Rollback(ctx)
-		return fmt.Errorf("error creating order %v", err)
+		return fmt.Errorf("error creating order: %w", err)
+	}
+
+	if err := s.insertItems(ctx, queries, orderCreated.ID, order.Items); err != nil {
+		return err
+	}
+
+	order.Status = orderCreated.Status
+	order.Version = int(orderCreated.Version)
+	if err := writeRevision(ctx, queries, orderCreated.ID, domain.EventOrderCreated, order); err != nil {
+		return err
+	}
+
+	if err := refreshCustomerStats(ctx, queries, order.CustomerCode); err != nil {
+		return err
+	}
+
+	if err := notifyOrderChange(ctx, queries, domain.OrderChange{OrderCode: order.OrderCode, CustomerCode: order.CustomerCode}); err != nil {
+		return err
+	}
+
+	err = enqueueEvent(ctx, queries, domain.EventOrderProcessed, domain.OrderProcessedEvent{
+		OrderCode:    order.OrderCode,
+		CustomerCode: order.CustomerCode,
+		Total:        total.StringFixed(totalScale),
+		Currency:     order.Currency,
+		ItemCount:    len(order.Items),
+		FlaggedSKUs:  order.FlaggedSKUs(),
+		ProcessedAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction %v", err)
+	}
+
+	return nil
+}
+
+// ValidateOrder resolves catalog SKUs and validates order data before processing
+func (s *OrderProcessingService) ValidateOrder(ctx context.Context, order *domain.Order) error {
+	if order == nil {
+		return emptyPayloadError(0)
+	}
+
+	reasons, err := s.applyCatalog(ctx, s.queries.Queries, order)
+	if err != nil {
+		return err
+	}
+
+	reasons = append(reasons, order.Validate(s.rules)...)
+	if len(reasons) > 0 {
+		return &domain.OrderValidationError{
+			OrderCode: order.OrderCode,
+			Reasons:   reasons,
+		}
+	}
+
+	return nil
+}
+
+// CalculateOrderTotal calculates the exact grand total of an order
+func (s *OrderProcessingService) CalculateOrderTotal(order *domain.Order) domain.Money {
+	return order.CalculateTotal()
+}
+
+// OrderExists checks if an order already exists by code
+func (s *OrderProcessingService) OrderExists(ctx context.Context, orderCode int32) (bool, error) {
+	_, err := s.queries.GetOrderByCode(ctx, orderCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("error checking order existence %v", err)
+	}
+	return true, nil
+}
+
+// createdOrder returns the order as it was first stored under orderCode, or nil when the
+// code is unused. Orders stored before revisions were kept are returned as they are now.
+func (s *OrderProcessingService) createdOrder(ctx context.Context, orderCode int32) (*domain.Order, error) {
+	dbOrder, err := s.queries.GetOrderByCode(ctx, orderCode)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error checking order existence: %w", err)
 	}
 
-	orderId := int64(orderCreated.Code)
+	revision, err := s.queries.GetFirstOrderRevision(ctx, database.GetFirstOrderRevisionParams{
+		OrderID:   dbOrder.ID,
+		EventType: domain.EventOrderCreated,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s.GetOrderByCode(ctx, orderCode)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error loading order revision: %w", err)
+	}
+
+	var order domain.Order
+	if err := json.Unmarshal(revision.Payload, &order); err != nil {
+		return nil, fmt.Errorf("error decoding order revision: %w", err)
+	}
+	return &order, nil
+}
 
-	for _, item := range order.Items {
-		var p pgtype.Numeric
+// GetOrderByCode retrieves an order by its code
+func (s *OrderProcessingService) GetOrderByCode(ctx context.Context, orderCode int32) (*domain.Order, error) {
+	dbOrder, err := s.queries.GetOrderByCode(ctx, orderCode)
+	if err != nil {
+		return nil, err
+	}
+
+	dbItems, err := s.queries.GetOrderItems(ctx, dbOrder.ID)
+	if err != nil {
+		return nil, err
+	}
 
-		err := p.Scan(item.Price)
+	items, err := itemsFromDB(dbItems)
+	if err != nil {
+		return nil, err
+	}
+
+	order := &domain.Order{
+		CustomerCode: int(dbOrder.CustomerCode),
+		OrderCode:    int64(dbOrder.Code),
+		Items:        items,
+		Currency:     dbOrder.Currency,
+		Status:       dbOrder.Status,
+		Version:      int(dbOrder.Version),
+		CreatedAt:    dbOrder.CreatedAt.Time,
+	}
+	if err := applyPricing(order, dbOrder); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// BackfillOrderTotals recomputes the stored total and item count of existing orders
+// from their items, walking the table in id ranges of batchSize rows.
+func (s *OrderProcessingService) BackfillOrderTotals(ctx context.Context, batchSize int64) (int64, error) {
+	maxID, err := s.queries.GetMaxOrderID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error reading max order id %v", err)
+	}
+
+	var updated int64
+	for minID := int64(1); minID <= maxID; minID += batchSize {
+		rows, err := s.queries.BackfillOrderTotals(ctx, database.BackfillOrderTotalsParams{
+			MinID: minID,
+			MaxID: minID + batchSize - 1,
+		})
 		if err != nil {
-			tx.Rollback(ctx)
-			return err
+			return updated, fmt.Errorf("error backfilling orders %d-%d %v", minID, minID+batchSize-1, err)
 		}
+		updated += rows
+	}
 
+	if updated > 0 {
+		if err := notifyOrderChange(ctx, s.queries.Queries, domain.OrderChange{All: true}); err != nil {
+			return updated, err
+		}
+	}
+
+	return updated, nil
+}
+
+// insertItems stores the order items. Prices were validated against the configured precision.
+func (s *OrderProcessingService) insertItems(ctx context.Context, queries *database.Queries, orderID int64, items []domain.OrderItem) error {
+	for _, item := range items {
 		args := database.CreateOrderItemParams{
-			OrderID:  orderId,
-			Product:  item.Product,
-			Price:    p,
-			Quantity: int32(item.Quantity),
+			OrderID:      orderID,
+			Product:      item.Product,
+			Price:        db.NumericFromMoney(item.Price),
+			Discount:     db.NumericFromMoney(item.Discount),
+			Quantity:     int32(item.Quantity),
+			Sku:          item.SKU,
+			PriceFlagged: item.PriceFlagged,
+		}
+
+		_, err := queries.CreateOrderItem(ctx, args)
+		if err != nil {
+			return fmt.Errorf("error creating order item %v: %w", item, err)
+		}
+	}
+
+	return nil
+}
+
+// applyCatalog loads the products referenced by the order's SKUs and resolves the items against them
+func (s *OrderProcessingService) applyCatalog(ctx context.Context, queries *database.Queries, order *domain.Order) ([]domain.RejectionReason, error) {
+	catalog, err := loadCatalog(ctx, queries, order.SKUs())
+	if err != nil {
+		return nil, err
+	}
+	return order.ApplyCatalog(catalog, s.rules.PriceTolerance), nil
+}
+
+// loadCatalog reads the given SKUs from the product catalog, keyed by SKU
+func loadCatalog(ctx context.Context, queries *database.Queries, skus []string) (map[string]domain.Product, error) {
+	if len(skus) == 0 {
+		return nil, nil
+	}
+
+	dbProducts, err := queries.GetProductsBySKUs(ctx, skus)
+	if err != nil {
+		return nil, fmt.Errorf("error loading catalog products %v", err)
+	}
+
+	catalog := make(map[string]domain.Product, len(dbProducts))
+	for _, dbProduct := range dbProducts {
+		listPrice, err := db.MoneyFromNumeric(dbProduct.ListPrice)
+		if err != nil {
+			return nil, err
 		}
 
-		_, err = queries.CreateOrderItem(ctx, args)
+		catalog[dbProduct.Sku] = domain.Product{
+			SKU:       dbProduct.Sku,
+			Name:      dbProduct.Name,
+			Unit:      dbProduct.Unit,
+			ListPrice: listPrice,
+			Active:    dbProduct.Active,
+		}
+	}
+
+	return catalog, nil
+}
+
+// itemsFromDB maps stored order items, with their prices and discounts, to the domain
+func itemsFromDB(dbItems []database.OrderItem) ([]domain.OrderItem, error) {
+	items := make([]domain.OrderItem, 0, len(dbItems))
+	for _, dbItem := range dbItems {
+		price, err := db.MoneyFromNumeric(dbItem.Price)
+		if err != nil {
+			return nil, err
+		}
+
+		discount, err := db.MoneyFromNumeric(dbItem.Discount)
 		if err != nil {
-			tx.Rollback(ctx)
-			return fmt.Errorf("error creating order item %v : %v", item, err)
+			return nil, err
 		}
+
+		items = append(items, domain.OrderItem{
+			SKU:          dbItem.Sku,
+			Product:      dbItem.Product,
+			Quantity:     int(dbItem.Quantity),
+			Price:        price,
+			Discount:     discount,
+			PriceFlagged: dbItem.PriceFlagged,
+		})
+	}
+	return items, nil
+}
+
+// applyPricing sets the coupon, shipping and tax stored with the order
+func applyPricing(order *domain.Order, dbOrder database.Order) error {
+	shipping, err := db.MoneyFromNumeric(dbOrder.Shipping)
+	if err != nil {
+		return err
+	}
+
+	tax, err := db.MoneyFromNumeric(dbOrder.Tax)
+	if err != nil {
+		return err
+	}
+
+	order.Shipping, order.Tax = shipping, tax
+
+	if dbOrder.CouponCode == "" {
+		return nil
+	}
+
+	couponDiscount, err := db.MoneyFromNumeric(dbOrder.CouponDiscount)
+	if err != nil {
+		return err
+	}
+
+	order.Coupon = &domain.Coupon{Code: dbOrder.CouponCode, Discount: couponDiscount}
+	return nil
+}
+
+// rejectOnValidation stores the message as rejected when err is an *domain.OrderValidationError.
+// The original error is returned either way so the consumer can decide how to settle the message.
+func (s *OrderProcessingService) rejectOnValidation(ctx context.Context, customerCode int, payload any, err error) error {
+	var validationErr *domain.OrderValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+
+	if rejectErr := s.reject(ctx, customerCode, payload, validationErr); rejectErr != nil {
+		return rejectErr
+	}
+	return err
+}
+
+// rejectOnOverflow rejects the message when storing it failed because an amount does not
+// fit its column; redelivering it would fail the same way. Other errors are returned as is.
+func (s *OrderProcessingService) rejectOnOverflow(ctx context.Context, customerCode int, payload any, orderCode int64, err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != numericValueOutOfRange {
+		return err
+	}
+
+	return s.rejectOnValidation(ctx, customerCode, payload, &domain.OrderValidationError{
+		OrderCode: orderCode,
+		Reasons: []domain.RejectionReason{{
+			Field:   "order",
+			Code:    domain.ReasonAmountTooLarge,
+			Message: "an amount of the order is too large to be stored",
+		}},
+	})
+}
+
+// reject stores a message that failed validation together with its reasons
+// and enqueues the matching order.rejected event in the same transaction
+func (s *OrderProcessingService) reject(ctx context.Context, customerCode int, payload any, validationErr *domain.OrderValidationError) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("error marshalling rejected order %v", err)
+	}
+
+	reasons, err := json.Marshal(validationErr.Reasons)
+	if err != nil {
+		return fmt.Errorf("error marshalling rejection reasons %v", err)
+	}
+
+	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("error opening transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+
+	args := database.CreateRejectedOrderParams{
+		OrderCode:    validationErr.OrderCode,
+		CustomerCode: int64(customerCode),
+		Payload:      body,
+		Reasons:      reasons,
+	}
+
+	if _, err := queries.CreateRejectedOrder(ctx, args); err != nil {
+		return fmt.Errorf("error storing rejected order %v", err)
+	}
+
+	err = enqueueEvent(ctx, queries, domain.EventOrderRejected, domain.OrderRejectedEvent{
+		OrderCode:    validationErr.OrderCode,
+		CustomerCode: customerCode,
+		Reasons:      validationErr.Reasons,
+		RejectedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// writeRevision appends an immutable snapshot of the order at its current version
+func writeRevision(ctx context.Context, queries *database.Queries, orderID int64, eventType string, order *domain.Order) error {
+	payload, err := json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("error marshalling order revision %v", err)
+	}
+
+	_, err = queries.CreateOrderRevision(ctx, database.CreateOrderRevisionParams{
+		OrderID:   orderID,
+		Version:   int32(order.Version),
+		EventType: eventType,
+		Payload:   payload,
+	})
+	if err != nil {
+		return fmt.Errorf("error writing order revision %v", err)
+	}
+
+	return nil
+}
+
+// notifyOrderChange announces an order change on domain.OrdersChannel using the caller's
+// transaction. Postgres delivers the notification only if the transaction commits.
+func notifyOrderChange(ctx context.Context, queries *database.Queries, change domain.OrderChange) error {
+	payload, err := json.Marshal(change)
+	if err != nil {
+		return fmt.Errorf("error marshalling order change %v", err)
+	}
+
+	err = queries.NotifyOrderChange(ctx, database.NotifyOrderChangeParams{
+		Channel: domain.OrdersChannel,
+		Payload: string(payload),
+	})
+	if err != nil {
+		return fmt.Errorf("error notifying order change %v", err)
+	}
+
+	return nil
+}
+
+// enqueueEvent writes an event to the outbox using the caller's transaction.
+// The outbox relay publishes it once the transaction commits.
+func enqueueEvent(ctx context.Context, queries *database.Queries, eventType string, event any) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error marshalling %s event: %w", eventType, err)
+	}
+
+	_, err = queries.CreateOutboxEvent(ctx, database.CreateOutboxEventParams{
+		EventType:  eventType,
+		RoutingKey: eventType,
+		Payload:    payload,
+	})
+	if err != nil {
+		return fmt.Errorf("error enqueueing %s event: %w", eventType, err)
 	}
 
 	return nil
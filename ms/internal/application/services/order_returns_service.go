@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database"
+	database "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database/sqlc"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ReturnOrder stores a partial return of an order's items and adds its refund to the order.
+// A return whose ReturnID was already stored is a no-op so redeliveries are harmless.
+func (s *OrderProcessingService) ReturnOrder(ctx context.Context, orderReturn *domain.OrderReturn) error {
+	if orderReturn == nil {
+		return s.rejectOnValidation(ctx, 0, orderReturn, emptyPayloadError(0))
+	}
+
+	var returnID pgtype.UUID
+	if err := returnID.Scan(orderReturn.ReturnID); err != nil || !returnID.Valid {
+		return s.rejectOnValidation(ctx, orderReturn.CustomerCode, orderReturn, &domain.OrderValidationError{
+			OrderCode: orderReturn.OrderCode,
+			Reasons: []domain.RejectionReason{{
+				Field:   "returnId",
+				Code:    domain.ReasonInvalidReturnID,
+				Message: "return id must be a UUID",
+			}},
+		})
+	}
+
+	tx, err := s.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("error opening transaction %v", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := s.queries.WithTx(tx)
+
+	exists, err := queries.ReturnExists(ctx, returnID)
+	if err != nil {
+		return fmt.Errorf("error checking return existence %v", err)
+	}
+	if exists {
+		// Redelivered message for a return we already stored
+		return nil
+	}
+
+	dbOrder, order, err := loadOrder(ctx, queries, orderReturn.OrderCode)
+	if err != nil {
+		return s.rejectOnValidation(ctx, orderReturn.CustomerCode, orderReturn, err)
+	}
+
+	if reasons := order.ValidateReturn(orderReturn); len(reasons) > 0 {
+		return s.rejectOnValidation(ctx, order.CustomerCode, orderReturn, &domain.OrderValidationError{
+			OrderCode: order.OrderCode,
+			Reasons:   reasons,
+		})
+	}
+
+	refund := order.CalculateRefund(orderReturn).Round(totalScale)
+	refundTotal := db.NumericFromMoney(refund)
+
+	rows, err := queries.AddOrderRefund(ctx, database.AddOrderRefundParams{
+		ID:            dbOrder.ID,
+		RefundedTotal: refundTotal,
+		Version:       dbOrder.Version,
+	})
+	if err != nil {
+		return fmt.Errorf("error adding order refund %v", err)
+	}
+	if rows == 0 {
+		return ErrConcurrentModification
+	}
+
+	dbReturn, err := queries.CreateReturn(ctx, database.CreateReturnParams{
+		ReturnID:    returnID,
+		OrderID:     dbOrder.ID,
+		Reason:      orderReturn.Reason,
+		RefundTotal: refundTotal,
+	})
+	if err != nil {
+		return fmt.Errorf("error creating return %v", err)
+	}
+
+	if order.Returned == nil {
+		order.Returned = make(map[string]int, len(orderReturn.Items))
+	}
+
+	for _, line := range orderReturn.Items {
+		item, _ := order.ItemFor(line.Product)
+
+		_, err := queries.CreateReturnItem(ctx, database.CreateReturnItemParams{
+			ReturnID: dbReturn.ID,
+			Product:  line.Product,
+			Quantity: int32(line.Quantity),
+			Price:    db.NumericFromMoney(item.Price),
+		})
+		if err != nil {
+			return fmt.Errorf("error creating return item %v : %v", line, err)
+		}
+
+		order.Returned[line.Product] += line.Quantity
+	}
+
+	order.Version++
+	if err := writeRevision(ctx, queries, dbOrder.ID, domain.EventOrderReturned, order); err != nil {
+		return err
+	}
+
+	gross, err := db.MoneyFromNumeric(dbOrder.Total)
+	if err != nil {
+		return err
+	}
+	refunded, err := db.MoneyFromNumeric(dbOrder.RefundedTotal)
+	if err != nil {
+		return err
+	}
+
+	if err := refreshCustomerStats(ctx, queries, order.CustomerCode); err != nil {
+		return err
+	}
+
+	if err := notifyOrderChange(ctx, queries, domain.OrderChange{OrderCode: order.OrderCode, CustomerCode: order.CustomerCode}); err != nil {
+		return err
+	}
+
+	err = enqueueEvent(ctx, queries, domain.EventOrderReturned, domain.OrderReturnedEvent{
+		ReturnID:     orderReturn.ReturnID,
+		OrderCode:    order.OrderCode,
+		CustomerCode: order.CustomerCode,
+		Version:      order.Version,
+		Items:        orderReturn.Items,
+		Refund:       refund.StringFixed(totalScale),
+		NetTotal:     gross.Sub(refunded).Sub(refund).StringFixed(totalScale),
+		Currency:     order.Currency,
+		ReturnedAt:   time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("error committing transaction %v", err)
+	}
+
+	return nil
+}
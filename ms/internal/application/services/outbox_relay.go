@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+
+	db "github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/adapters/outbound/database"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/ports"
+	"github.com/jackc/pgx/v5"
+)
+
+// OutboxRelay publishes events written to the outbox table by the order transactions
+type OutboxRelay struct {
+	queries      *db.Store
+	publisher    ports.EventPublisher
+	pollInterval time.Duration
+	batchSize    int32
+}
+
+// NewOutboxRelay creates a new OutboxRelay with dependency injection
+func NewOutboxRelay(queries *db.Store, publisher ports.EventPublisher, pollInterval time.Duration, batchSize int32) *OutboxRelay {
+	return &OutboxRelay{
+		queries:      queries,
+		publisher:    publisher,
+		pollInterval: pollInterval,
+		batchSize:    batchSize,
+	}
+}
+
+// Start polls the outbox until the context is cancelled
+func (r *OutboxRelay) Start(ctx context.Context) {
+	ticker := time.NewTicker(r.pollInterval)
+	defer ticker.Stop()
+
+	logger.Info("Outbox relay started",
+		zap.Duration("poll_interval", r.pollInterval),
+		zap.Int32("batch_size", r.batchSize),
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Info("Outbox relay stopped", zap.String("reason", "shutdown"))
+			return
+		case <-ticker.C:
+			published, err := r.PublishPending(ctx)
+			if err != nil {
+				logger.Error("Failed to publish outbox events",
+					zap.Error(err),
+					zap.Int("published", published),
+				)
+				continue
+			}
+			if published > 0 {
+				logger.Debug("Outbox events published", zap.Int("published", published))
+			}
+		}
+	}
+}
+
+// PublishPending publishes one batch of unpublished events in id order.
+// Rows are locked with SKIP LOCKED so several consumer replicas can relay concurrently;
+// a failed publish stops the batch so later events are not sent ahead of it.
+func (r *OutboxRelay) PublishPending(ctx context.Context) (int, error) {
+	tx, err := r.queries.Pool.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("error opening transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	queries := r.queries.WithTx(tx)
+
+	events, err := queries.GetPendingOutboxEvents(ctx, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error reading outbox: %w", err)
+	}
+
+	published := 0
+	var publishErr error
+	for _, event := range events {
+		if err := r.publisher.Publish(ctx, event.EventType, event.RoutingKey, event.Payload); err != nil {
+			publishErr = fmt.Errorf("error publishing outbox event %d: %w", event.ID, err)
+			break
+		}
+
+		if err := queries.MarkOutboxEventPublished(ctx, event.ID); err != nil {
+			publishErr = fmt.Errorf("error marking outbox event %d: %w", event.ID, err)
+			break
+		}
+		published++
+	}
+
+	// A failed mark aborts the transaction, so the commit fails too; both errors are kept
+	// and the batch's events stay pending, to be published again
+	if err := tx.Commit(ctx); err != nil {
+		return 0, errors.Join(publishErr, fmt.Errorf("error committing transaction: %w", err))
+	}
+
+	return published, publishErr
+}
@@ -4,15 +4,19 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	App      AppConfig
-	RabbitMQ RabbitMQConfig
-	Database DatabaseConfig
-	OTel     OTelConfig
+	App        AppConfig
+	RabbitMQ   RabbitMQConfig
+	Database   DatabaseConfig
+	OTel       OTelConfig
+	Validation ValidationConfig
+	Outbox     OutboxConfig
+	Debug      DebugConfig
 }
 
 type AppConfig struct {
@@ -23,11 +27,14 @@ type AppConfig struct {
 }
 
 type RabbitMQConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Queue    string
+	Host            string
+	Port            string
+	User            string
+	Password        string
+	Queue           string
+	Partitions      int
+	QuarantineQueue string
+	EventsExchange  string
 }
 
 type DatabaseConfig struct {
@@ -44,11 +51,40 @@ type OTelConfig struct {
 	Endpoint string
 }
 
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+}
+
+// DebugConfig enables the pprof and diagnostics listener. Every request must
+// send Token as a bearer token, so it is required when enabled.
+type DebugConfig struct {
+	Enabled bool
+	Addr    string
+	Token   string
+}
+
+type ValidationConfig struct {
+	MaxItems       int
+	MaxQuantity    int
+	PricePrecision int
+	PriceTolerance int
+}
+
 func Load() (*Config, error) {
 	if err := godotenv.Load(); err != nil {
 		fmt.Println("Warning: .env file not found, using environment variables")
 	}
 
+	debug := DebugConfig{
+		Enabled: getEnvBool("DEBUG_ENABLED", false),
+		Addr:    getEnv("DEBUG_ADDR", "localhost:6061"),
+		Token:   getEnv("DEBUG_TOKEN", ""),
+	}
+	if debug.Enabled && debug.Token == "" {
+		return nil, fmt.Errorf("error loading config: DEBUG_TOKEN is required when DEBUG_ENABLED is set")
+	}
+
 	config := &Config{
 		App: AppConfig{
 			Name:     getEnv("APP_NAME", "btg-consumer-ms"),
@@ -57,11 +93,14 @@ func Load() (*Config, error) {
 			LogLevel: getEnv("LOG_LEVEL", "info"),
 		},
 		RabbitMQ: RabbitMQConfig{
-			Host:     getEnv("RABBITMQ_HOST", "localhost"),
-			Port:     getEnv("RABBITMQ_PORT", "5672"),
-			User:     getEnv("RABBITMQ_USER", "guest"),
-			Password: getEnv("RABBITMQ_PASSWORD", "guest"),
-			Queue:    getEnv("RABBITMQ_QUEUE", "orders"),
+			Host:            getEnv("RABBITMQ_HOST", "localhost"),
+			Port:            getEnv("RABBITMQ_PORT", "5672"),
+			User:            getEnv("RABBITMQ_USER", "guest"),
+			Password:        getEnv("RABBITMQ_PASSWORD", "guest"),
+			Queue:           getEnv("RABBITMQ_QUEUE", "orders"),
+			Partitions:      getEnvInt("RABBITMQ_PARTITIONS", 1),
+			QuarantineQueue: getEnv("RABBITMQ_QUARANTINE_QUEUE", "orders.quarantine"),
+			EventsExchange:  getEnv("RABBITMQ_EVENTS_EXCHANGE", "order_events"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -75,6 +114,17 @@ func Load() (*Config, error) {
 			Enabled:  getEnvBool("OTEL_ENABLED", true),
 			Endpoint: getEnv("OTEL_EXPORTER_OTLP_ENDPOINT", "http://localhost:4318"),
 		},
+		Validation: ValidationConfig{
+			MaxItems:       getEnvInt("ORDER_MAX_ITEMS", 100),
+			MaxQuantity:    getEnvInt("ORDER_MAX_QUANTITY", 10000),
+			PricePrecision: getEnvInt("ORDER_PRICE_PRECISION", 2),
+			PriceTolerance: getEnvInt("ORDER_PRICE_TOLERANCE_PERCENT", 20),
+		},
+		Outbox: OutboxConfig{
+			PollInterval: getEnvDuration("OUTBOX_POLL_INTERVAL", time.Second),
+			BatchSize:    getEnvInt("OUTBOX_BATCH_SIZE", 100),
+		},
+		Debug: debug,
 	}
 
 	return config, nil
@@ -105,3 +155,21 @@ func getEnvBool(key string, defaultValue bool) bool {
 	}
 	return defaultValue
 }
+
+func getEnvInt(key string, defaultValue int) int {
+	if value := os.Getenv(key); value != "" {
+		if i, err := strconv.Atoi(value); err == nil {
+			return i
+		}
+	}
+	return defaultValue
+}
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := os.Getenv(key); value != "" {
+		if d, err := time.ParseDuration(value); err == nil {
+			return d
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,101 @@
+package domain
+
+import (
+	"errors"
+	"time"
+)
+
+// Message types consumed from the order queues.
+// Messages without a type are treated as EventOrderCreated.
+const (
+	EventOrderCreated   = "order.created"
+	EventOrderCancelled = "order.cancelled"
+	EventOrderAmended   = "order.amended"
+	EventOrderReturned  = "order.returned"
+
+	EventCustomerUpdated = "customer.updated"
+)
+
+// Event types published to the order events exchange.
+// They double as routing keys so consumers can bind with patterns like "order.*".
+// EventOrderCancelled, EventOrderAmended and EventOrderReturned are also published once applied.
+const (
+	EventOrderProcessed = "order.processed"
+	EventOrderRejected  = "order.rejected"
+)
+
+// OrdersChannel is the Postgres NOTIFY channel announcing committed order changes.
+// The core API listens on it to invalidate its read cache.
+const OrdersChannel = "orders_changed"
+
+// OrderChange is the payload notified on OrdersChannel. All is set when every order
+// may have changed, for example after a bulk rebuild.
+type OrderChange struct {
+	OrderCode    int64 `json:"orderCode,omitempty"`
+	CustomerCode int   `json:"customerCode,omitempty"`
+	All          bool  `json:"all,omitempty"`
+}
+
+// CustomerUpdate is the body of a customer.updated message, sent when a customer's
+// registry data changes so the read models derived from its orders are refreshed
+type CustomerUpdate struct {
+	CustomerCode int       `json:"customerCode"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// ErrMalformedMessage is returned by message handlers when a body cannot be decoded.
+// Such messages are dropped instead of requeued.
+var ErrMalformedMessage = errors.New("malformed message")
+
+// OrderProcessedEvent is emitted once an order and its items are stored
+type OrderProcessedEvent struct {
+	OrderCode    int64     `json:"orderCode"`
+	CustomerCode int       `json:"customerCode"`
+	Total        string    `json:"total"`
+	Currency     string    `json:"currency"`
+	ItemCount    int       `json:"itemCount"`
+	FlaggedSKUs  []string  `json:"flaggedSkus,omitempty"`
+	ProcessedAt  time.Time `json:"processedAt"`
+}
+
+// OrderRejectedEvent is emitted when an order fails business validation
+type OrderRejectedEvent struct {
+	OrderCode    int64             `json:"orderCode"`
+	CustomerCode int               `json:"customerCode"`
+	Reasons      []RejectionReason `json:"reasons"`
+	RejectedAt   time.Time         `json:"rejectedAt"`
+}
+
+// OrderCancelledEvent is emitted once a cancellation is applied
+type OrderCancelledEvent struct {
+	OrderCode    int64     `json:"orderCode"`
+	CustomerCode int       `json:"customerCode"`
+	Version      int       `json:"version"`
+	Reason       string    `json:"reason,omitempty"`
+	CancelledAt  time.Time `json:"cancelledAt"`
+}
+
+// OrderAmendedEvent is emitted once an amendment is applied, with the new stored total
+type OrderAmendedEvent struct {
+	OrderCode    int64     `json:"orderCode"`
+	CustomerCode int       `json:"customerCode"`
+	Version      int       `json:"version"`
+	Total        string    `json:"total"`
+	Currency     string    `json:"currency"`
+	ItemCount    int       `json:"itemCount"`
+	FlaggedSKUs  []string  `json:"flaggedSkus,omitempty"`
+	AmendedAt    time.Time `json:"amendedAt"`
+}
+
+// OrderReturnedEvent is emitted once a return is stored, with the refund and the new net total
+type OrderReturnedEvent struct {
+	ReturnID     string       `json:"returnId"`
+	OrderCode    int64        `json:"orderCode"`
+	CustomerCode int          `json:"customerCode"`
+	Version      int          `json:"version"`
+	Items        []ReturnItem `json:"items"`
+	Refund       string       `json:"refund"`
+	NetTotal     string       `json:"netTotal"`
+	Currency     string       `json:"currency"`
+	ReturnedAt   time.Time    `json:"returnedAt"`
+}
@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMoneyJSON(t *testing.T) {
+	var item OrderItem
+	if err := json.Unmarshal([]byte(`{"product":"lápis","quantity":3,"price":0.10}`), &item); err != nil {
+		t.Fatalf("unmarshal number: %v", err)
+	}
+	if item.Price.String() != "0.10" {
+		t.Errorf("expected 0.10, got %s", item.Price)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":"1.005"}`), &item); err != nil {
+		t.Fatalf("unmarshal string: %v", err)
+	}
+	if item.Price.String() != "1.005" {
+		t.Errorf("expected 1.005, got %s", item.Price)
+	}
+
+	if err := json.Unmarshal([]byte(`{"price":"abc"}`), &item); err == nil {
+		t.Error("expected an error for a non-decimal price")
+	}
+
+	for _, price := range []string{`"1e100000000"`, `1E3`, `"2.5e-1"`, `"1000000000000"`, `-1000000000000.00`} {
+		if err := json.Unmarshal([]byte(`{"price":`+price+`}`), &item); err == nil {
+			t.Errorf("expected an error for price %s", price)
+		}
+	}
+	if err := json.Unmarshal([]byte(`{"price":"999999999999.99"}`), &item); err != nil {
+		t.Errorf("unmarshal the largest amount: %v", err)
+	}
+
+	body, err := json.Marshal(OrderItem{Product: "a", Quantity: 1, Price: MustParseMoney("2.50")})
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	if string(body) != `{"product":"a","quantity":1,"price":"2.50"}` {
+		t.Errorf("unexpected JSON %s", body)
+	}
+}
+
+func TestMoneyArithmetic(t *testing.T) {
+	order := Order{Items: []OrderItem{
+		{Quantity: 1, Price: MustParseMoney("0.1")},
+		{Quantity: 1, Price: MustParseMoney("0.2")},
+	}}
+	if total := order.CalculateTotal(); total.Cmp(MustParseMoney("0.3")) != 0 {
+		t.Errorf("expected exactly 0.3, got %s", total)
+	}
+
+	rounding := map[string]string{
+		"0.125":  "0.12",
+		"0.135":  "0.14",
+		"2.675":  "2.68",
+		"-1.005": "-1.00",
+	}
+	for in, want := range rounding {
+		if got := MustParseMoney(in).StringFixed(2); got != want {
+			t.Errorf("StringFixed(%s): expected %s, got %s", in, want, got)
+		}
+	}
+
+	decimals := map[string]int32{"10": 0, "1.10": 1, "1.005": 3, "0.000": 0}
+	for in, want := range decimals {
+		if got := MustParseMoney(in).Decimals(); got != want {
+			t.Errorf("Decimals(%s): expected %d, got %d", in, want, got)
+		}
+	}
+}
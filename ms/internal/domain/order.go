@@ -2,23 +2,45 @@ package domain
 
 import "time"
 
+// DefaultCurrency is assumed for orders that do not state an ISO 4217 currency code
+const DefaultCurrency = "BRL"
+
+// Order statuses stored in orders.status
+const (
+	OrderStatusCreated   = "created"
+	OrderStatusCancelled = "cancelled"
+)
+
 type Order struct {
 	CustomerCode int         `json:"customerCode"`
 	OrderCode    int64       `json:"orderCode"`
 	Items        []OrderItem `json:"items"`
+	Currency     string      `json:"currency,omitempty"`
+	Coupon       *Coupon     `json:"coupon,omitempty"`
+	Shipping     Money       `json:"shipping,omitzero"`
+	Tax          Money       `json:"tax,omitzero"`
 	CreatedAt    time.Time   `json:"createdAt"`
+	Status       string      `json:"status,omitempty"`
+	Version      int         `json:"version,omitempty"`
+
+	// Returned holds the quantity already returned per product
+	Returned map[string]int `json:"returned,omitempty"`
 }
 
 type OrderItem struct {
-	Product  string  `json:"product"`
-	Quantity int     `json:"quantity"`
-	Price    float64 `json:"price"`
+	SKU      string `json:"sku,omitempty"`
+	Product  string `json:"product"`
+	Quantity int    `json:"quantity"`
+	Price    Money  `json:"price"`
+
+	// Discount is taken off the whole line, not off each unit
+	Discount Money `json:"discount,omitzero"`
+
+	// PriceFlagged marks a price outside the tolerance around the catalog list price
+	PriceFlagged bool `json:"priceFlagged,omitempty"`
 }
 
-func (o *Order) CalculateTotal() float64 {
-	var total float64
-	for _, item := range o.Items {
-		total += float64(item.Quantity) * item.Price
-	}
-	return total
+// CalculateTotal returns the grand total of the order, exactly
+func (o *Order) CalculateTotal() Money {
+	return o.CalculateBreakdown().Total
 }
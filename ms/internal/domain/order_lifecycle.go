@@ -0,0 +1,103 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// OrderCancellation is the body of an order.cancelled message
+type OrderCancellation struct {
+	OrderCode       int64     `json:"orderCode"`
+	CustomerCode    int       `json:"customerCode"`
+	Reason          string    `json:"reason,omitempty"`
+	ExpectedVersion int       `json:"expectedVersion,omitempty"`
+	RequestedAt     time.Time `json:"requestedAt"`
+}
+
+// OrderAmendment is the body of an order.amended message.
+// Items are matched by product name.
+type OrderAmendment struct {
+	OrderCode       int64       `json:"orderCode"`
+	CustomerCode    int         `json:"customerCode"`
+	ExpectedVersion int         `json:"expectedVersion,omitempty"`
+	AddItems        []OrderItem `json:"addItems,omitempty"`
+	RemoveProducts  []string    `json:"removeProducts,omitempty"`
+	ChangeItems     []OrderItem `json:"changeItems,omitempty"`
+	RequestedAt     time.Time   `json:"requestedAt"`
+}
+
+// CheckVersion reports a conflict when the caller expected a different version.
+// An expected version of zero skips the check.
+func (o *Order) CheckVersion(expected int) []RejectionReason {
+	if expected == 0 || expected == o.Version {
+		return nil
+	}
+	return []RejectionReason{{
+		Field:   "expectedVersion",
+		Code:    ReasonVersionConflict,
+		Message: fmt.Sprintf("expected version %d, current version is %d", expected, o.Version),
+	}}
+}
+
+// ApplyAmendment changes the order items in place: removals first, then changes, then additions.
+// It returns the reasons the amendment cannot be applied; the items must not be used in that case.
+func (o *Order) ApplyAmendment(a *OrderAmendment) []RejectionReason {
+	if len(a.AddItems) == 0 && len(a.RemoveProducts) == 0 && len(a.ChangeItems) == 0 {
+		return []RejectionReason{{
+			Field:   "amendment",
+			Code:    ReasonEmptyAmendment,
+			Message: "amendment must add, remove or change at least one item",
+		}}
+	}
+
+	var reasons []RejectionReason
+
+	index := func(product string) int {
+		for i, item := range o.Items {
+			if item.Product == product {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for i, product := range a.RemoveProducts {
+		pos := index(product)
+		if pos < 0 {
+			reasons = append(reasons, RejectionReason{
+				Field:   fmt.Sprintf("removeProducts[%d]", i),
+				Code:    ReasonUnknownProduct,
+				Message: fmt.Sprintf("product %q is not in the order", product),
+			})
+			continue
+		}
+		o.Items = append(o.Items[:pos], o.Items[pos+1:]...)
+	}
+
+	for i, change := range a.ChangeItems {
+		pos := index(change.Product)
+		if pos < 0 {
+			reasons = append(reasons, RejectionReason{
+				Field:   fmt.Sprintf("changeItems[%d].product", i),
+				Code:    ReasonUnknownProduct,
+				Message: fmt.Sprintf("product %q is not in the order", change.Product),
+			})
+			continue
+		}
+		o.Items[pos] = change
+	}
+
+	for i, item := range a.AddItems {
+		if index(item.Product) >= 0 {
+			reasons = append(reasons, RejectionReason{
+				Field:   fmt.Sprintf("addItems[%d].product", i),
+				Code:    ReasonDuplicateProduct,
+				Message: fmt.Sprintf("product %q is already in the order", item.Product),
+			})
+			continue
+		}
+		o.Items = append(o.Items, item)
+	}
+
+	return reasons
+}
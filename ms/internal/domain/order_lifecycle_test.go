@@ -0,0 +1,82 @@
+package domain
+
+import "testing"
+
+func TestOrderApplyAmendment(t *testing.T) {
+	base := func() Order {
+		return Order{OrderCode: 1, CustomerCode: 1, Version: 2, Items: []OrderItem{
+			{Product: "lápis", Quantity: 10, Price: MustParseMoney("1.10")},
+			{Product: "caderno", Quantity: 1, Price: MustParseMoney("10")},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		amendment OrderAmendment
+		codes     []string
+		products  []string
+	}{
+		{
+			name:  "empty amendment",
+			codes: []string{ReasonEmptyAmendment},
+		},
+		{
+			name: "remove, change and add",
+			amendment: OrderAmendment{
+				RemoveProducts: []string{"caderno"},
+				ChangeItems:    []OrderItem{{Product: "lápis", Quantity: 5, Price: MustParseMoney("1.10")}},
+				AddItems:       []OrderItem{{Product: "borracha", Quantity: 2, Price: MustParseMoney("0.50")}},
+			},
+			products: []string{"lápis", "borracha"},
+		},
+		{
+			name: "unknown and duplicate products",
+			amendment: OrderAmendment{
+				RemoveProducts: []string{"caneta"},
+				ChangeItems:    []OrderItem{{Product: "régua", Quantity: 1, Price: MustParseMoney("1")}},
+				AddItems:       []OrderItem{{Product: "lápis", Quantity: 1, Price: MustParseMoney("1")}},
+			},
+			codes: []string{ReasonUnknownProduct, ReasonUnknownProduct, ReasonDuplicateProduct},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := base()
+			reasons := order.ApplyAmendment(&tt.amendment)
+			if len(reasons) != len(tt.codes) {
+				t.Fatalf("expected %d reasons, got %d: %+v", len(tt.codes), len(reasons), reasons)
+			}
+			for i, code := range tt.codes {
+				if reasons[i].Code != code {
+					t.Errorf("reason %d: expected %s, got %s", i, code, reasons[i].Code)
+				}
+			}
+			if tt.products == nil {
+				return
+			}
+			if len(order.Items) != len(tt.products) {
+				t.Fatalf("expected %d items, got %+v", len(tt.products), order.Items)
+			}
+			for i, product := range tt.products {
+				if order.Items[i].Product != product {
+					t.Errorf("item %d: expected %s, got %s", i, product, order.Items[i].Product)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderCheckVersion(t *testing.T) {
+	order := Order{Version: 3}
+
+	if reasons := order.CheckVersion(0); len(reasons) != 0 {
+		t.Errorf("expected no conflict without an expected version, got %+v", reasons)
+	}
+	if reasons := order.CheckVersion(3); len(reasons) != 0 {
+		t.Errorf("expected no conflict for the current version, got %+v", reasons)
+	}
+	if reasons := order.CheckVersion(2); len(reasons) != 1 || reasons[0].Code != ReasonVersionConflict {
+		t.Errorf("expected a version conflict, got %+v", reasons)
+	}
+}
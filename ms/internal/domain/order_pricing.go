@@ -0,0 +1,175 @@
+package domain
+
+import "fmt"
+
+// amountScale is the number of decimal places the money columns keep
+const amountScale = 2
+
+// Largest amounts the money columns hold: item prices and discounts are NUMERIC(10, 2),
+// order amounts NUMERIC(14, 2). Anything larger makes the insert fail.
+var (
+	MaxItemAmount  = MustParseMoney("99999999.99")
+	MaxOrderAmount = MustParseMoney("999999999999.99")
+)
+
+// Coupon is an order-level discount, taken off after the item discounts
+type Coupon struct {
+	Code     string `json:"code"`
+	Discount Money  `json:"discount"`
+}
+
+// PriceBreakdown is how the grand total of an order is composed:
+// Total = Subtotal - Discounts + Shipping + Tax
+type PriceBreakdown struct {
+	Subtotal       Money
+	ItemDiscounts  Money
+	CouponDiscount Money
+	Discounts      Money
+	Shipping       Money
+	Tax            Money
+	Total          Money
+}
+
+// CalculateBreakdown prices the order exactly. Subtotal sums quantity * price,
+// discounts add up the item discounts and the coupon.
+func (o *Order) CalculateBreakdown() PriceBreakdown {
+	var b PriceBreakdown
+	for _, item := range o.Items {
+		b.Subtotal = b.Subtotal.Add(item.Price.Mul(item.Quantity))
+		b.ItemDiscounts = b.ItemDiscounts.Add(item.Discount)
+	}
+	if o.Coupon != nil {
+		b.CouponDiscount = o.Coupon.Discount
+	}
+
+	b.Discounts = b.ItemDiscounts.Add(b.CouponDiscount)
+	b.Shipping = o.Shipping
+	b.Tax = o.Tax
+	b.Total = b.Subtotal.Sub(b.Discounts).Add(b.Shipping).Add(b.Tax)
+	return b
+}
+
+// Round rounds every component half-even to places
+func (b PriceBreakdown) Round(places int32) PriceBreakdown {
+	return PriceBreakdown{
+		Subtotal:       b.Subtotal.Round(places),
+		ItemDiscounts:  b.ItemDiscounts.Round(places),
+		CouponDiscount: b.CouponDiscount.Round(places),
+		Discounts:      b.Discounts.Round(places),
+		Shipping:       b.Shipping.Round(places),
+		Tax:            b.Tax.Round(places),
+		Total:          b.Total.Round(places),
+	}
+}
+
+// validatePricing checks discounts, shipping, tax and the totals they add up to. Discounts
+// may not exceed what they are taken off: an item discount its line, the coupon the subtotal
+// left after item discounts. No amount may exceed the column it is stored in.
+func (o *Order) validatePricing(precision int) []RejectionReason {
+	var reasons []RejectionReason
+
+	var discounted Money
+	for i, item := range o.Items {
+		field := fmt.Sprintf("items[%d].discount", i)
+		line := item.Price.Mul(item.Quantity)
+
+		switch {
+		case item.Discount.IsNegative():
+			reasons = append(reasons, RejectionReason{
+				Field:   field,
+				Code:    ReasonInvalidDiscount,
+				Message: "discount must not be negative",
+			})
+		case line.IsPositive() && item.Discount.Cmp(line) > 0:
+			reasons = append(reasons, RejectionReason{
+				Field:   field,
+				Code:    ReasonInvalidDiscount,
+				Message: fmt.Sprintf("discount %s exceeds the line amount %s", item.Discount, line),
+			})
+		default:
+			reasons = append(reasons, checkPrecision(field, item.Discount, precision)...)
+			reasons = append(reasons, checkMax(field, item.Discount, MaxItemAmount)...)
+		}
+
+		discounted = discounted.Add(line.Sub(item.Discount))
+	}
+
+	if o.Coupon != nil {
+		switch {
+		case o.Coupon.Code == "":
+			reasons = append(reasons, RejectionReason{
+				Field:   "coupon.code",
+				Code:    ReasonInvalidDiscount,
+				Message: "coupon code must not be empty",
+			})
+		case o.Coupon.Discount.IsNegative():
+			reasons = append(reasons, RejectionReason{
+				Field:   "coupon.discount",
+				Code:    ReasonInvalidDiscount,
+				Message: "coupon discount must not be negative",
+			})
+		case o.Coupon.Discount.Cmp(discounted) > 0:
+			reasons = append(reasons, RejectionReason{
+				Field:   "coupon.discount",
+				Code:    ReasonInvalidDiscount,
+				Message: fmt.Sprintf("coupon discount %s exceeds the discounted subtotal %s", o.Coupon.Discount, discounted),
+			})
+		default:
+			reasons = append(reasons, checkPrecision("coupon.discount", o.Coupon.Discount, precision)...)
+			reasons = append(reasons, checkMax("coupon.discount", o.Coupon.Discount, MaxOrderAmount)...)
+		}
+	}
+
+	if o.Shipping.IsNegative() {
+		reasons = append(reasons, RejectionReason{
+			Field:   "shipping",
+			Code:    ReasonInvalidShipping,
+			Message: "shipping must not be negative",
+		})
+	} else {
+		reasons = append(reasons, checkPrecision("shipping", o.Shipping, precision)...)
+		reasons = append(reasons, checkMax("shipping", o.Shipping, MaxOrderAmount)...)
+	}
+
+	if o.Tax.IsNegative() {
+		reasons = append(reasons, RejectionReason{
+			Field:   "tax",
+			Code:    ReasonInvalidTax,
+			Message: "tax must not be negative",
+		})
+	} else {
+		reasons = append(reasons, checkPrecision("tax", o.Tax, precision)...)
+		reasons = append(reasons, checkMax("tax", o.Tax, MaxOrderAmount)...)
+	}
+
+	breakdown := o.CalculateBreakdown().Round(amountScale)
+	reasons = append(reasons, checkMax("subtotal", breakdown.Subtotal, MaxOrderAmount)...)
+	reasons = append(reasons, checkMax("discountTotal", breakdown.Discounts, MaxOrderAmount)...)
+	reasons = append(reasons, checkMax("total", breakdown.Total, MaxOrderAmount)...)
+
+	return reasons
+}
+
+// checkPrecision reports an amount with more decimal places than precision
+func checkPrecision(field string, amount Money, precision int) []RejectionReason {
+	if amount.Decimals() <= int32(precision) {
+		return nil
+	}
+	return []RejectionReason{{
+		Field:   field,
+		Code:    ReasonPricePrecision,
+		Message: fmt.Sprintf("%s must have at most %d decimal places", field, precision),
+	}}
+}
+
+// checkMax reports an amount that, rounded to the places kept, is larger than limit
+func checkMax(field string, amount, limit Money) []RejectionReason {
+	if amount.Round(amountScale).Cmp(limit) <= 0 {
+		return nil
+	}
+	return []RejectionReason{{
+		Field:   field,
+		Code:    ReasonAmountTooLarge,
+		Message: fmt.Sprintf("%s must be at most %s", field, limit),
+	}}
+}
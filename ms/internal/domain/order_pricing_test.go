@@ -0,0 +1,100 @@
+package domain
+
+import "testing"
+
+func TestOrderCalculateBreakdown(t *testing.T) {
+	order := Order{
+		Items: []OrderItem{
+			{Product: "lápis", Quantity: 10, Price: MustParseMoney("1.10"), Discount: MustParseMoney("1.00")},
+			{Product: "caderno", Quantity: 2, Price: MustParseMoney("10")},
+		},
+		Coupon:   &Coupon{Code: "BEMVINDO", Discount: MustParseMoney("5")},
+		Shipping: MustParseMoney("12.90"),
+		Tax:      MustParseMoney("3.15"),
+	}
+
+	b := order.CalculateBreakdown()
+
+	expected := map[string]string{
+		"subtotal":  "31.00",
+		"discounts": "6.00",
+		"shipping":  "12.90",
+		"tax":       "3.15",
+		"total":     "41.05",
+	}
+	got := map[string]Money{
+		"subtotal":  b.Subtotal,
+		"discounts": b.Discounts,
+		"shipping":  b.Shipping,
+		"tax":       b.Tax,
+		"total":     b.Total,
+	}
+	for name, want := range expected {
+		if got[name].StringFixed(2) != want {
+			t.Errorf("%s: expected %s, got %s", name, want, got[name].StringFixed(2))
+		}
+	}
+}
+
+func TestOrderValidatePricing(t *testing.T) {
+	tests := []struct {
+		name  string
+		order Order
+		codes []string
+	}{
+		{
+			name: "valid discounts, shipping and tax",
+			order: Order{
+				Items:    []OrderItem{{Product: "lápis", Quantity: 2, Price: MustParseMoney("5"), Discount: MustParseMoney("10")}},
+				Shipping: MustParseMoney("10"),
+				Tax:      MustParseMoney("0.99"),
+			},
+		},
+		{
+			name: "item discount above the line amount",
+			order: Order{
+				Items: []OrderItem{{Product: "lápis", Quantity: 2, Price: MustParseMoney("5"), Discount: MustParseMoney("10.01")}},
+			},
+			codes: []string{ReasonInvalidDiscount},
+		},
+		{
+			name: "coupon above the discounted subtotal",
+			order: Order{
+				Items:  []OrderItem{{Product: "lápis", Quantity: 2, Price: MustParseMoney("5"), Discount: MustParseMoney("4")}},
+				Coupon: &Coupon{Code: "BEMVINDO", Discount: MustParseMoney("6.01")},
+			},
+			codes: []string{ReasonInvalidDiscount},
+		},
+		{
+			name: "negative shipping and tax with too many decimals",
+			order: Order{
+				Items:    []OrderItem{{Product: "lápis", Quantity: 1, Price: MustParseMoney("5")}},
+				Shipping: MustParseMoney("-1"),
+				Tax:      MustParseMoney("0.001"),
+			},
+			codes: []string{ReasonInvalidShipping, ReasonPricePrecision},
+		},
+		{
+			name: "amounts wider than their columns",
+			order: Order{
+				Items:    []OrderItem{{Product: "lápis", Quantity: 10001, Price: MustParseMoney("99999999.99"), Discount: MustParseMoney("100000000")}},
+				Shipping: MustParseMoney("999999999999.995"),
+			},
+			codes: []string{ReasonAmountTooLarge, ReasonPricePrecision, ReasonAmountTooLarge, ReasonAmountTooLarge, ReasonAmountTooLarge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := tt.order.validatePricing(2)
+			if len(reasons) != len(tt.codes) {
+				t.Fatalf("expected %d reasons, got %d: %+v", len(tt.codes), len(reasons), reasons)
+			}
+			for i, code := range tt.codes {
+				if reasons[i].Code != code {
+					t.Errorf("reason %d: expected %s, got %s", i, code, reasons[i].Code)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// OrderReturn is the body of an order.returned message.
+// ReturnID is assigned by the API and makes redeliveries idempotent.
+type OrderReturn struct {
+	ReturnID     string       `json:"returnId"`
+	OrderCode    int64        `json:"orderCode"`
+	CustomerCode int          `json:"customerCode"`
+	Reason       string       `json:"reason,omitempty"`
+	Items        []ReturnItem `json:"items"`
+	RequestedAt  time.Time    `json:"requestedAt"`
+}
+
+// ReturnItem is a returned quantity of one of the order's products
+type ReturnItem struct {
+	Product  string `json:"product"`
+	Quantity int    `json:"quantity"`
+}
+
+// ValidateReturn checks every line is a product of the order and that, together with
+// earlier returns in o.Returned, no more is returned than was bought.
+func (o *Order) ValidateReturn(r *OrderReturn) []RejectionReason {
+	var reasons []RejectionReason
+
+	if o.Status == OrderStatusCancelled {
+		reasons = append(reasons, RejectionReason{
+			Field:   "orderCode",
+			Code:    ReasonOrderCancelled,
+			Message: "cancelled orders cannot be returned",
+		})
+	}
+
+	if len(r.Items) == 0 {
+		reasons = append(reasons, RejectionReason{
+			Field:   "items",
+			Code:    ReasonNoItems,
+			Message: "return must contain at least one item",
+		})
+	}
+
+	seen := make(map[string]bool, len(r.Items))
+	for i, line := range r.Items {
+		field := fmt.Sprintf("items[%d]", i)
+
+		if seen[line.Product] {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".product",
+				Code:    ReasonDuplicateProduct,
+				Message: fmt.Sprintf("product %q appears more than once", line.Product),
+			})
+			continue
+		}
+		seen[line.Product] = true
+
+		item, ok := o.ItemFor(line.Product)
+		if !ok {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".product",
+				Code:    ReasonUnknownProduct,
+				Message: fmt.Sprintf("product %q is not in the order", line.Product),
+			})
+			continue
+		}
+
+		if line.Quantity <= 0 {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".quantity",
+				Code:    ReasonInvalidQuantity,
+				Message: "quantity must be greater than zero",
+			})
+			continue
+		}
+
+		if returnable := item.Quantity - o.Returned[line.Product]; line.Quantity > returnable {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".quantity",
+				Code:    ReasonReturnExceeded,
+				Message: fmt.Sprintf("returning %d of %q, only %d returnable", line.Quantity, line.Product, returnable),
+			})
+		}
+	}
+
+	return reasons
+}
+
+// CalculateRefund prices the returned lines at the price they were bought for,
+// less the share of the item discount that falls on the returned quantity.
+// The coupon, shipping and tax are not refunded.
+func (o *Order) CalculateRefund(r *OrderReturn) Money {
+	var refund Money
+	for _, line := range r.Items {
+		if item, ok := o.ItemFor(line.Product); ok {
+			refund = refund.Add(item.Price.Mul(line.Quantity))
+			refund = refund.Sub(item.Discount.Prorate(line.Quantity, item.Quantity))
+		}
+	}
+	return refund
+}
+
+// CheckReturnedQuantities reports items whose quantity dropped below what was already returned,
+// including returned products that are no longer in the order.
+func (o *Order) CheckReturnedQuantities() []RejectionReason {
+	products := make([]string, 0, len(o.Returned))
+	for product := range o.Returned {
+		products = append(products, product)
+	}
+	sort.Strings(products)
+
+	var reasons []RejectionReason
+
+	for _, product := range products {
+		returned := o.Returned[product]
+		item, ok := o.ItemFor(product)
+		if ok && item.Quantity >= returned {
+			continue
+		}
+		reasons = append(reasons, RejectionReason{
+			Field:   "items",
+			Code:    ReasonBelowReturned,
+			Message: fmt.Sprintf("%d of %q were already returned", returned, product),
+		})
+	}
+
+	return reasons
+}
+
+// ItemFor returns the order item for a product
+func (o *Order) ItemFor(product string) (OrderItem, bool) {
+	for _, item := range o.Items {
+		if item.Product == product {
+			return item, true
+		}
+	}
+	return OrderItem{}, false
+}
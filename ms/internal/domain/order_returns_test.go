@@ -0,0 +1,78 @@
+package domain
+
+import "testing"
+
+func TestOrderValidateReturn(t *testing.T) {
+	order := Order{OrderCode: 1, CustomerCode: 1, Items: []OrderItem{
+		{Product: "lápis", Quantity: 10, Price: MustParseMoney("1.10")},
+		{Product: "caderno", Quantity: 2, Price: MustParseMoney("10")},
+	}, Returned: map[string]int{"lápis": 8}}
+
+	tests := []struct {
+		name  string
+		items []ReturnItem
+		codes []string
+	}{
+		{
+			name:  "within returnable quantities",
+			items: []ReturnItem{{Product: "lápis", Quantity: 2}, {Product: "caderno", Quantity: 2}},
+		},
+		{
+			name:  "no items",
+			codes: []string{ReasonNoItems},
+		},
+		{
+			name:  "more than was bought",
+			items: []ReturnItem{{Product: "lápis", Quantity: 3}, {Product: "caderno", Quantity: 3}},
+			codes: []string{ReasonReturnExceeded, ReasonReturnExceeded},
+		},
+		{
+			name:  "unknown, duplicate and invalid lines",
+			items: []ReturnItem{{Product: "régua", Quantity: 1}, {Product: "caderno", Quantity: 0}, {Product: "caderno", Quantity: 1}},
+			codes: []string{ReasonUnknownProduct, ReasonInvalidQuantity, ReasonDuplicateProduct},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := order.ValidateReturn(&OrderReturn{Items: tt.items})
+			if len(reasons) != len(tt.codes) {
+				t.Fatalf("expected %d reasons, got %d: %+v", len(tt.codes), len(reasons), reasons)
+			}
+			for i, code := range tt.codes {
+				if reasons[i].Code != code {
+					t.Errorf("reason %d: expected %s, got %s", i, code, reasons[i].Code)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderCalculateRefund(t *testing.T) {
+	order := Order{Items: []OrderItem{
+		{Product: "lápis", Quantity: 10, Price: MustParseMoney("1.10")},
+		{Product: "caderno", Quantity: 2, Price: MustParseMoney("10")},
+	}}
+
+	refund := order.CalculateRefund(&OrderReturn{Items: []ReturnItem{
+		{Product: "lápis", Quantity: 5},
+		{Product: "caderno", Quantity: 1},
+	}})
+	if refund.String() != "15.50" {
+		t.Errorf("expected refund of 15.50, got %s", refund)
+	}
+}
+
+func TestOrderCalculateRefundProratesItemDiscount(t *testing.T) {
+	order := Order{
+		Items: []OrderItem{
+			{Product: "lápis", Quantity: 3, Price: MustParseMoney("1.00"), Discount: MustParseMoney("1.00")},
+		},
+		Coupon: &Coupon{Code: "BEMVINDO", Discount: MustParseMoney("0.50")},
+	}
+
+	refund := order.CalculateRefund(&OrderReturn{Items: []ReturnItem{{Product: "lápis", Quantity: 1}}})
+	if refund.String() != "0.67" {
+		t.Errorf("expected refund of 0.67, got %s", refund)
+	}
+}
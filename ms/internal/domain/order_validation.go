@@ -0,0 +1,216 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// currencyPattern matches the shape of an ISO 4217 alphabetic code
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
+// Rejection reason codes attached to orders that fail business validation
+const (
+	ReasonEmptyPayload        = "EMPTY_PAYLOAD"
+	ReasonInvalidOrderCode    = "INVALID_ORDER_CODE"
+	ReasonDuplicateOrderCode  = "DUPLICATE_ORDER_CODE"
+	ReasonInvalidCustomerCode = "INVALID_CUSTOMER_CODE"
+	ReasonNoItems             = "NO_ITEMS"
+	ReasonTooManyItems        = "TOO_MANY_ITEMS"
+	ReasonEmptyProduct        = "EMPTY_PRODUCT"
+	ReasonInvalidQuantity     = "INVALID_QUANTITY"
+	ReasonQuantityTooLarge    = "QUANTITY_TOO_LARGE"
+	ReasonInvalidPrice        = "INVALID_PRICE"
+	ReasonPricePrecision      = "PRICE_PRECISION_EXCEEDED"
+	ReasonAmountTooLarge      = "AMOUNT_TOO_LARGE"
+	ReasonOrderNotFound       = "ORDER_NOT_FOUND"
+	ReasonOrderCancelled      = "ORDER_CANCELLED"
+	ReasonVersionConflict     = "VERSION_CONFLICT"
+	ReasonUnknownProduct      = "UNKNOWN_PRODUCT"
+	ReasonDuplicateProduct    = "DUPLICATE_PRODUCT"
+	ReasonEmptyAmendment      = "EMPTY_AMENDMENT"
+	ReasonInvalidReturnID     = "INVALID_RETURN_ID"
+	ReasonReturnExceeded      = "RETURN_QUANTITY_EXCEEDED"
+	ReasonBelowReturned       = "BELOW_RETURNED_QUANTITY"
+	ReasonInvalidCurrency     = "INVALID_CURRENCY"
+	ReasonInvalidDiscount     = "INVALID_DISCOUNT"
+	ReasonInvalidShipping     = "INVALID_SHIPPING"
+	ReasonInvalidTax          = "INVALID_TAX"
+	ReasonUnknownSKU          = "UNKNOWN_SKU"
+	ReasonInactiveSKU         = "INACTIVE_SKU"
+)
+
+// ValidationRules holds the configurable limits applied to incoming orders
+type ValidationRules struct {
+	MaxItems       int
+	MaxQuantity    int
+	PricePrecision int
+
+	// PriceTolerance is how far, in percent of the list price, a catalog item's price may be
+	// before it is flagged. Zero disables the check.
+	PriceTolerance int
+}
+
+// RejectionReason describes a single rule an order failed
+type RejectionReason struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// OrderValidationError is returned when an order breaks one or more business rules.
+// It carries every reason found so the rejection can be stored in full.
+type OrderValidationError struct {
+	OrderCode int64
+	Reasons   []RejectionReason
+}
+
+func (e *OrderValidationError) Error() string {
+	codes := make([]string, len(e.Reasons))
+	for i, reason := range e.Reasons {
+		codes[i] = reason.Code
+	}
+	return fmt.Sprintf("order %d rejected: %s", e.OrderCode, strings.Join(codes, ", "))
+}
+
+// Validate checks the order against the given rules and returns every violation found
+func (o *Order) Validate(rules ValidationRules) []RejectionReason {
+	var reasons []RejectionReason
+
+	if o.OrderCode <= 0 || o.OrderCode > math.MaxInt32 {
+		reasons = append(reasons, RejectionReason{
+			Field:   "orderCode",
+			Code:    ReasonInvalidOrderCode,
+			Message: "order code must be a positive 32-bit integer",
+		})
+	}
+
+	if o.CustomerCode <= 0 || o.CustomerCode > math.MaxInt32 {
+		reasons = append(reasons, RejectionReason{
+			Field:   "customerCode",
+			Code:    ReasonInvalidCustomerCode,
+			Message: "customer code must be a positive 32-bit integer",
+		})
+	}
+
+	if o.Currency != "" && !currencyPattern.MatchString(o.Currency) {
+		reasons = append(reasons, RejectionReason{
+			Field:   "currency",
+			Code:    ReasonInvalidCurrency,
+			Message: "currency must be an ISO 4217 code such as BRL or USD",
+		})
+	}
+
+	if len(o.Items) == 0 {
+		reasons = append(reasons, RejectionReason{
+			Field:   "items",
+			Code:    ReasonNoItems,
+			Message: "order must contain at least one item",
+		})
+	}
+
+	if rules.MaxItems > 0 && len(o.Items) > rules.MaxItems {
+		reasons = append(reasons, RejectionReason{
+			Field:   "items",
+			Code:    ReasonTooManyItems,
+			Message: fmt.Sprintf("order has %d items, maximum is %d", len(o.Items), rules.MaxItems),
+		})
+	}
+
+	for i, item := range o.Items {
+		field := fmt.Sprintf("items[%d]", i)
+
+		if strings.TrimSpace(item.Product) == "" && item.SKU == "" {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".product",
+				Code:    ReasonEmptyProduct,
+				Message: "product must not be empty",
+			})
+		}
+
+		if item.Quantity <= 0 {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".quantity",
+				Code:    ReasonInvalidQuantity,
+				Message: "quantity must be greater than zero",
+			})
+		} else if rules.MaxQuantity > 0 && item.Quantity > rules.MaxQuantity {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".quantity",
+				Code:    ReasonQuantityTooLarge,
+				Message: fmt.Sprintf("quantity %d exceeds maximum of %d", item.Quantity, rules.MaxQuantity),
+			})
+		}
+
+		if !item.Price.IsPositive() {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".price",
+				Code:    ReasonInvalidPrice,
+				Message: "price must be greater than zero",
+			})
+		} else if item.Price.Decimals() > int32(rules.PricePrecision) {
+			reasons = append(reasons, RejectionReason{
+				Field:   field + ".price",
+				Code:    ReasonPricePrecision,
+				Message: fmt.Sprintf("price must have at most %d decimal places", rules.PricePrecision),
+			})
+		} else {
+			reasons = append(reasons, checkMax(field+".price", item.Price, MaxItemAmount)...)
+		}
+	}
+
+	reasons = append(reasons, o.validatePricing(rules.PricePrecision)...)
+
+	return reasons
+}
+
+// CheckRedelivery compares the order with the one first stored under its code. A redelivered
+// message places the same order again; another customer or payload reusing the code is
+// reported as a duplicate order code.
+func (o *Order) CheckRedelivery(stored *Order) []RejectionReason {
+	if o.samePlacement(stored) {
+		return nil
+	}
+	return []RejectionReason{{
+		Field:   "orderCode",
+		Code:    ReasonDuplicateOrderCode,
+		Message: fmt.Sprintf("order code %d is already used by a different order", o.OrderCode),
+	}}
+}
+
+// samePlacement reports whether both orders have the same customer, currency, items and pricing
+func (o *Order) samePlacement(other *Order) bool {
+	if o.CustomerCode != other.CustomerCode ||
+		currencyOrDefault(o.Currency) != currencyOrDefault(other.Currency) ||
+		o.Shipping.Cmp(other.Shipping) != 0 ||
+		o.Tax.Cmp(other.Tax) != 0 ||
+		len(o.Items) != len(other.Items) ||
+		(o.Coupon == nil) != (other.Coupon == nil) {
+		return false
+	}
+	if o.Coupon != nil && (o.Coupon.Code != other.Coupon.Code || o.Coupon.Discount.Cmp(other.Coupon.Discount) != 0) {
+		return false
+	}
+
+	for i, item := range o.Items {
+		stored := other.Items[i]
+		if item.SKU != stored.SKU || item.Quantity != stored.Quantity ||
+			item.Price.Cmp(stored.Price) != 0 || item.Discount.Cmp(stored.Discount) != 0 {
+			return false
+		}
+		// Catalog items are named by the catalog, which may have renamed them since
+		if item.SKU == "" && item.Product != stored.Product {
+			return false
+		}
+	}
+	return true
+}
+
+// currencyOrDefault returns the currency an order is stored in
+func currencyOrDefault(currency string) string {
+	if currency == "" {
+		return DefaultCurrency
+	}
+	return currency
+}
@@ -0,0 +1,112 @@
+package domain
+
+import "testing"
+
+func TestOrderValidate(t *testing.T) {
+	rules := ValidationRules{MaxItems: 2, MaxQuantity: 100, PricePrecision: 2}
+
+	tests := []struct {
+		name  string
+		order Order
+		codes []string
+	}{
+		{
+			name: "valid order",
+			order: Order{OrderCode: 1001, CustomerCode: 1, Items: []OrderItem{
+				{Product: "lápis", Quantity: 100, Price: MustParseMoney("1.10")},
+			}},
+		},
+		{
+			name:  "no items and zero codes",
+			order: Order{},
+			codes: []string{ReasonInvalidOrderCode, ReasonInvalidCustomerCode, ReasonNoItems},
+		},
+		{
+			name: "too many items",
+			order: Order{OrderCode: 1, CustomerCode: 1, Items: []OrderItem{
+				{Product: "a", Quantity: 1, Price: MustParseMoney("1")},
+				{Product: "b", Quantity: 1, Price: MustParseMoney("1")},
+				{Product: "c", Quantity: 1, Price: MustParseMoney("1")},
+			}},
+			codes: []string{ReasonTooManyItems},
+		},
+		{
+			name: "invalid item fields",
+			order: Order{OrderCode: 1, CustomerCode: 1, Items: []OrderItem{
+				{Product: "  ", Quantity: -1, Price: MustParseMoney("1.005")},
+				{Product: "b", Quantity: 101, Price: MustParseMoney("0")},
+			}},
+			codes: []string{ReasonEmptyProduct, ReasonInvalidQuantity, ReasonPricePrecision, ReasonQuantityTooLarge, ReasonInvalidPrice},
+		},
+		{
+			name: "invalid currency",
+			order: Order{OrderCode: 1, CustomerCode: 1, Currency: "usd", Items: []OrderItem{
+				{Product: "a", Quantity: 1, Price: MustParseMoney("1")},
+			}},
+			codes: []string{ReasonInvalidCurrency},
+		},
+		{
+			name: "price wider than the item column",
+			order: Order{OrderCode: 1, CustomerCode: 1, Items: []OrderItem{
+				{Product: "a", Quantity: 1, Price: MustParseMoney("99999999.99")},
+				{Product: "b", Quantity: 1, Price: MustParseMoney("100000000")},
+			}},
+			codes: []string{ReasonAmountTooLarge},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reasons := tt.order.Validate(rules)
+			if len(reasons) != len(tt.codes) {
+				t.Fatalf("expected %d reasons, got %d: %+v", len(tt.codes), len(reasons), reasons)
+			}
+			for i, code := range tt.codes {
+				if reasons[i].Code != code {
+					t.Errorf("reason %d: expected %s, got %s", i, code, reasons[i].Code)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderCheckRedelivery(t *testing.T) {
+	stored := Order{OrderCode: 1001, CustomerCode: 1, Currency: DefaultCurrency, Shipping: MustParseMoney("12.90"), Items: []OrderItem{
+		{SKU: "LAP-001", Product: "lápis", Quantity: 100, Price: MustParseMoney("1.10")},
+		{Product: "caderno", Quantity: 2, Price: MustParseMoney("10"), Discount: MustParseMoney("1")},
+	}}
+
+	tests := []struct {
+		name   string
+		change func(o *Order)
+		codes  []string
+	}{
+		{name: "redelivery", change: func(o *Order) {}},
+		{name: "redelivery without the default currency", change: func(o *Order) { o.Currency = "" }},
+		{name: "catalog item renamed since", change: func(o *Order) { o.Items[0].Product = "lápis preto" }},
+		{name: "another customer", change: func(o *Order) { o.CustomerCode = 2 }, codes: []string{ReasonDuplicateOrderCode}},
+		{name: "another quantity", change: func(o *Order) { o.Items[1].Quantity = 3 }, codes: []string{ReasonDuplicateOrderCode}},
+		{name: "another discount", change: func(o *Order) { o.Items[1].Discount = Money{} }, codes: []string{ReasonDuplicateOrderCode}},
+		{name: "another product", change: func(o *Order) { o.Items[1].Product = "borracha" }, codes: []string{ReasonDuplicateOrderCode}},
+		{name: "a coupon added", change: func(o *Order) { o.Coupon = &Coupon{Code: "BEMVINDO", Discount: MustParseMoney("5")} }, codes: []string{ReasonDuplicateOrderCode}},
+		{name: "fewer items", change: func(o *Order) { o.Items = o.Items[:1] }, codes: []string{ReasonDuplicateOrderCode}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := stored
+			order.Items = append([]OrderItem(nil), stored.Items...)
+			tt.change(&order)
+
+			reasons := order.CheckRedelivery(&stored)
+			if len(reasons) != len(tt.codes) {
+				t.Fatalf("expected %d reasons, got %d: %+v", len(tt.codes), len(reasons), reasons)
+			}
+			for i, code := range tt.codes {
+				if reasons[i].Code != code {
+					t.Errorf("reason %d: expected %s, got %s", i, code, reasons[i].Code)
+				}
+			}
+		})
+	}
+}
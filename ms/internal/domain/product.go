@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Product is a catalog entry that order items reference through its SKU
+type Product struct {
+	SKU       string
+	Name      string
+	Unit      string
+	ListPrice Money
+	Active    bool
+}
+
+// NormalizeSKU trims and upper-cases a SKU so lookups do not depend on how it was typed
+func NormalizeSKU(sku string) string {
+	return strings.ToUpper(strings.TrimSpace(sku))
+}
+
+// SKUs returns the distinct SKUs referenced by the items, normalized
+func (o *Order) SKUs() []string {
+	return distinctSKUs(o.Items)
+}
+
+// SKUs returns the distinct SKUs referenced by the added and changed items, normalized
+func (a *OrderAmendment) SKUs() []string {
+	return distinctSKUs(append(append([]OrderItem(nil), a.AddItems...), a.ChangeItems...))
+}
+
+// ApplyCatalog resolves the items that reference a SKU against the catalog, keyed by SKU.
+// Their product name is replaced by the catalog name so reports group them together.
+// Unknown and inactive SKUs are returned as reasons; items priced further than tolerance
+// percent from the list price are flagged, not rejected.
+func (o *Order) ApplyCatalog(catalog map[string]Product, tolerance int) []RejectionReason {
+	return applyCatalog(o.Items, "items", catalog, tolerance)
+}
+
+// ApplyCatalog resolves the added and changed items like Order.ApplyCatalog,
+// so that items given only by SKU are matched by their catalog name.
+func (a *OrderAmendment) ApplyCatalog(catalog map[string]Product, tolerance int) []RejectionReason {
+	reasons := applyCatalog(a.AddItems, "addItems", catalog, tolerance)
+	return append(reasons, applyCatalog(a.ChangeItems, "changeItems", catalog, tolerance)...)
+}
+
+// FlaggedSKUs returns the SKUs of the items whose price was flagged
+func (o *Order) FlaggedSKUs() []string {
+	var skus []string
+	for _, item := range o.Items {
+		if item.PriceFlagged {
+			skus = append(skus, item.SKU)
+		}
+	}
+	return skus
+}
+
+func distinctSKUs(items []OrderItem) []string {
+	var skus []string
+	seen := make(map[string]bool, len(items))
+	for _, item := range items {
+		sku := NormalizeSKU(item.SKU)
+		if sku == "" || seen[sku] {
+			continue
+		}
+		seen[sku] = true
+		skus = append(skus, sku)
+	}
+	return skus
+}
+
+func applyCatalog(items []OrderItem, field string, catalog map[string]Product, tolerance int) []RejectionReason {
+	var reasons []RejectionReason
+
+	for i := range items {
+		item := &items[i]
+		if item.SKU == "" {
+			continue
+		}
+		item.SKU = NormalizeSKU(item.SKU)
+		item.PriceFlagged = false
+
+		skuField := fmt.Sprintf("%s[%d].sku", field, i)
+
+		product, ok := catalog[item.SKU]
+		if !ok {
+			reasons = append(reasons, RejectionReason{
+				Field:   skuField,
+				Code:    ReasonUnknownSKU,
+				Message: fmt.Sprintf("sku %q is not in the catalog", item.SKU),
+			})
+			continue
+		}
+		if !product.Active {
+			reasons = append(reasons, RejectionReason{
+				Field:   skuField,
+				Code:    ReasonInactiveSKU,
+				Message: fmt.Sprintf("sku %q is no longer sold", item.SKU),
+			})
+			continue
+		}
+
+		item.Product = product.Name
+
+		if tolerance > 0 && item.Price.Sub(product.ListPrice).Abs().Cmp(product.ListPrice.Percent(tolerance)) > 0 {
+			item.PriceFlagged = true
+		}
+	}
+
+	return reasons
+}
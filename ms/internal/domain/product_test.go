@@ -0,0 +1,43 @@
+package domain
+
+import "testing"
+
+func TestOrderApplyCatalog(t *testing.T) {
+	catalog := map[string]Product{
+		"LAP-001": {SKU: "LAP-001", Name: "lápis", ListPrice: MustParseMoney("1.00"), Active: true},
+		"CAD-001": {SKU: "CAD-001", Name: "caderno", ListPrice: MustParseMoney("10.00"), Active: false},
+	}
+
+	order := Order{Items: []OrderItem{
+		{SKU: " lap-001", Product: "Lapis ", Quantity: 1, Price: MustParseMoney("1.10")},
+		{SKU: "LAP-001", Quantity: 1, Price: MustParseMoney("1.11")},
+		{SKU: "CAD-001", Quantity: 1, Price: MustParseMoney("10")},
+		{SKU: "REG-001", Quantity: 1, Price: MustParseMoney("2")},
+		{Product: "borracha", Quantity: 1, Price: MustParseMoney("0.50")},
+	}}
+
+	reasons := order.ApplyCatalog(catalog, 10)
+
+	codes := []string{ReasonInactiveSKU, ReasonUnknownSKU}
+	if len(reasons) != len(codes) {
+		t.Fatalf("expected %d reasons, got %+v", len(codes), reasons)
+	}
+	for i, code := range codes {
+		if reasons[i].Code != code {
+			t.Errorf("reason %d: expected %s, got %s", i, code, reasons[i].Code)
+		}
+	}
+
+	if order.Items[0].Product != "lápis" || order.Items[0].SKU != "LAP-001" {
+		t.Errorf("expected the catalog name and a normalized sku, got %+v", order.Items[0])
+	}
+	if order.Items[0].PriceFlagged {
+		t.Error("a price 10% above the list price is within the tolerance")
+	}
+	if flagged := order.FlaggedSKUs(); len(flagged) != 1 || !order.Items[1].PriceFlagged {
+		t.Errorf("expected only the 1.11 item to be flagged, got %v", flagged)
+	}
+	if order.Items[4].Product != "borracha" {
+		t.Errorf("items without sku keep their product, got %+v", order.Items[4])
+	}
+}
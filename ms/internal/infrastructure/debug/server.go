@@ -0,0 +1,220 @@
+package debug
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
+)
+
+// StateFunc describes the current state of a dependency, rendered as JSON
+type StateFunc func() any
+
+// StateReporter is implemented by adapters that can describe their connections
+type StateReporter interface {
+	DebugState() map[string]any
+}
+
+// Server is an opt-in listener, apart from the API, serving pprof profiles and
+// runtime diagnostics to callers presenting the debug token as a bearer token
+type Server struct {
+	server  *http.Server
+	started time.Time
+}
+
+// NewServer creates the debug listener on addr. Each state is served at
+// /debug/state/<name>, and all of them together at /debug/state.
+func NewServer(addr, token string, states map[string]StateFunc) *Server {
+	s := &Server{started: time.Now()}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /debug/pprof/", pprof.Index)
+	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("POST /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+	mux.HandleFunc("GET /debug/goroutines", goroutineDump)
+	mux.HandleFunc("GET /debug/runtime", s.runtimeStats)
+	mux.HandleFunc("GET /debug/log-level", getLogLevel)
+	mux.HandleFunc("PUT /debug/log-level", setLogLevel)
+
+	for name, state := range states {
+		mux.HandleFunc("GET /debug/state/"+name, func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, state())
+		})
+	}
+	mux.HandleFunc("GET /debug/state", func(w http.ResponseWriter, r *http.Request) {
+		all := make(map[string]any, len(states))
+		for name, state := range states {
+			all[name] = state()
+		}
+		writeJSON(w, all)
+	})
+
+	// No write timeout: CPU profiles and traces stream for as long as the caller asks
+	s.server = &http.Server{
+		Addr:              addr,
+		Handler:           requireToken(token, mux),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+	return s
+}
+
+// Start serves the debug endpoints in the background
+func (s *Server) Start() {
+	logger.Warn("Debug listener enabled, profiles and runtime state are exposed",
+		zap.String("address", s.server.Addr),
+	)
+
+	go func() {
+		if err := s.server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Debug listener stopped", zap.Error(err))
+		}
+	}()
+}
+
+// Shutdown stops the debug listener, waiting for requests in flight until ctx ends
+func (s *Server) Shutdown(ctx context.Context) error {
+	return s.server.Shutdown(ctx)
+}
+
+// PoolStats reports the pgx pool's connection counts and acquire statistics
+func PoolStats(pool *pgxpool.Pool) StateFunc {
+	return func() any {
+		stat := pool.Stat()
+		return map[string]any{
+			"max_conns":                  stat.MaxConns(),
+			"total_conns":                stat.TotalConns(),
+			"acquired_conns":             stat.AcquiredConns(),
+			"idle_conns":                 stat.IdleConns(),
+			"constructing_conns":         stat.ConstructingConns(),
+			"acquire_count":              stat.AcquireCount(),
+			"acquire_duration_ms":        stat.AcquireDuration().Milliseconds(),
+			"empty_acquire_count":        stat.EmptyAcquireCount(),
+			"canceled_acquire_count":     stat.CanceledAcquireCount(),
+			"new_conns_count":            stat.NewConnsCount(),
+			"max_lifetime_destroy_count": stat.MaxLifetimeDestroyCount(),
+			"max_idle_destroy_count":     stat.MaxIdleDestroyCount(),
+		}
+	}
+}
+
+// requireToken rejects requests without the debug token. The comparison takes the
+// same time whatever the token sent, so it cannot be guessed byte by byte.
+func requireToken(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sent, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="debug"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// goroutineDump writes every goroutine's stack, as a panic would
+func goroutineDump(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	runtimepprof.Lookup("goroutine").WriteTo(w, 2)
+}
+
+// runtimeStats reports the scheduler and memory figures worth a first look
+func (s *Server) runtimeStats(w http.ResponseWriter, r *http.Request) {
+	var mem runtime.MemStats
+	runtime.ReadMemStats(&mem)
+
+	writeJSON(w, map[string]any{
+		"go_version":     runtime.Version(),
+		"uptime_seconds": int64(time.Since(s.started).Seconds()),
+		"goroutines":     runtime.NumGoroutine(),
+		"gomaxprocs":     runtime.GOMAXPROCS(0),
+		"num_cpu":        runtime.NumCPU(),
+		"heap_alloc":     mem.HeapAlloc,
+		"heap_inuse":     mem.HeapInuse,
+		"heap_objects":   mem.HeapObjects,
+		"sys":            mem.Sys,
+		"num_gc":         mem.NumGC,
+		"gc_pause_total": time.Duration(mem.PauseTotalNs).String(),
+		"next_gc":        mem.NextGC,
+		"last_gc":        time.Unix(0, int64(mem.LastGC)).UTC().Format(time.RFC3339),
+	})
+}
+
+// logLevelRequest changes the consumer's log level, for revert_after when given
+type logLevelRequest struct {
+	Level       string `json:"level"`
+	RevertAfter string `json:"revert_after"`
+}
+
+// getLogLevel reports the log level in force
+func getLogLevel(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, logLevelFields(logger.CurrentLevel()))
+}
+
+// setLogLevel changes the log level, the consumer having no API of its own to do it
+func setLogLevel(w http.ResponseWriter, r *http.Request) {
+	var req logLevelRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<10)).Decode(&req); err != nil {
+		http.Error(w, "invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	level, err := zapcore.ParseLevel(req.Level)
+	if err != nil || level < zapcore.DebugLevel || level > zapcore.ErrorLevel {
+		http.Error(w, "unknown level "+req.Level, http.StatusBadRequest)
+		return
+	}
+
+	var revertAfter time.Duration
+	if req.RevertAfter != "" {
+		revertAfter, err = time.ParseDuration(req.RevertAfter)
+		if err != nil || revertAfter <= 0 {
+			http.Error(w, "invalid revert delay "+req.RevertAfter, http.StatusBadRequest)
+			return
+		}
+	}
+
+	setting, err := logger.SetLevel(level, revertAfter)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Logged at warn, which every level but error lets through
+	logger.Warn("Log level changed",
+		zap.String("level", level.String()),
+		zap.Duration("revert_after", revertAfter),
+	)
+
+	writeJSON(w, logLevelFields(setting))
+}
+
+// logLevelFields renders the level in force
+func logLevelFields(setting logger.LevelSetting) map[string]any {
+	fields := map[string]any{"level": setting.Level.String()}
+	if !setting.RevertAt.IsZero() {
+		fields["revert_at"] = setting.RevertAt.UTC().Format(time.RFC3339)
+	}
+	return fields
+}
+
+func writeJSON(w http.ResponseWriter, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,122 @@
+package debug
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap/zapcore"
+
+	"github.com/IgorGrieder/Desafio-BTG/tree/main/ms/internal/logger"
+)
+
+func TestServerRequiresToken(t *testing.T) {
+	s := NewServer("localhost:0", "s3cret", map[string]StateFunc{
+		"amqp_events": func() any { return map[string]any{"channel_closed": false} },
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+	}{
+		{"no token", "", http.StatusUnauthorized},
+		{"wrong token", "Bearer guess", http.StatusUnauthorized},
+		{"token without scheme", "s3cret", http.StatusUnauthorized},
+		{"debug token", "Bearer s3cret", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/debug/state/amqp_events", nil)
+			if tt.authorization != "" {
+				r.Header.Set("Authorization", tt.authorization)
+			}
+			w := httptest.NewRecorder()
+			s.server.Handler.ServeHTTP(w, r)
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, w.Code)
+			}
+		})
+	}
+}
+
+func TestServerEndpoints(t *testing.T) {
+	s := NewServer("localhost:0", "s3cret", map[string]StateFunc{
+		"amqp_events": func() any { return map[string]any{"channel_closed": true} },
+	})
+
+	get := func(path string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, path, nil)
+		r.Header.Set("Authorization", "Bearer s3cret")
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, r)
+		if w.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", path, w.Code)
+		}
+		return w
+	}
+
+	var state map[string]map[string]bool
+	if err := json.Unmarshal(get("/debug/state").Body.Bytes(), &state); err != nil {
+		t.Fatal(err)
+	}
+	if !state["amqp_events"]["channel_closed"] {
+		t.Errorf("unexpected state %v", state)
+	}
+
+	if body := get("/debug/goroutines").Body.String(); !strings.Contains(body, "goroutine ") {
+		t.Errorf("expected a goroutine dump, got %q", body)
+	}
+
+	var stats map[string]any
+	if err := json.Unmarshal(get("/debug/runtime").Body.Bytes(), &stats); err != nil {
+		t.Fatal(err)
+	}
+	if stats["goroutines"] == nil {
+		t.Errorf("expected the goroutine count, got %v", stats)
+	}
+
+	get("/debug/pprof/")
+}
+
+func TestServerLogLevel(t *testing.T) {
+	s := NewServer("localhost:0", "s3cret", nil)
+	t.Cleanup(func() { logger.SetLevel(zapcore.InfoLevel, 0) })
+	logger.SetLevel(zapcore.InfoLevel, 0)
+
+	do := func(method, body string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/debug/log-level", strings.NewReader(body))
+		r.Header.Set("Authorization", "Bearer s3cret")
+		w := httptest.NewRecorder()
+		s.server.Handler.ServeHTTP(w, r)
+		return w
+	}
+
+	for _, body := range []string{`{"level":"loud"}`, `{"level":"fatal"}`, `{"level":"debug","revert_after":"soon"}`, `{"level":"debug","revert_after":"-1m"}`, `not json`} {
+		if w := do(http.MethodPut, body); w.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", body, w.Code)
+		}
+	}
+	if logger.Level.Level() != zapcore.InfoLevel {
+		t.Fatalf("level changed by a rejected request to %s", logger.Level.Level())
+	}
+
+	if w := do(http.MethodPut, `{"level":"debug","revert_after":"15m"}`); w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body)
+	}
+	if logger.Level.Level() != zapcore.DebugLevel {
+		t.Errorf("expected the debug level, got %s", logger.Level.Level())
+	}
+
+	var current map[string]string
+	if err := json.Unmarshal(do(http.MethodGet, "").Body.Bytes(), &current); err != nil {
+		t.Fatal(err)
+	}
+	if current["level"] != "debug" || current["revert_at"] == "" {
+		t.Errorf("unexpected level %v", current)
+	}
+}
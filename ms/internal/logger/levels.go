@@ -0,0 +1,77 @@
+package logger
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Level is the level of the global logger, changed at runtime through SetLevel
+var Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+
+// LevelSetting describes the level in force and when it reverts, if it was changed temporarily
+type LevelSetting struct {
+	Level    zapcore.Level
+	RevertAt time.Time
+}
+
+var levels struct {
+	sync.Mutex
+	revert   *time.Timer
+	base     zapcore.Level
+	revertAt time.Time
+}
+
+// SetLevel changes the level of the global logger. With revertAfter > 0 the change
+// is undone once it elapses, returning to the level in force before it.
+func SetLevel(level zapcore.Level, revertAfter time.Duration) (LevelSetting, error) {
+	if revertAfter < 0 {
+		return LevelSetting{}, errors.New("revert delay must not be negative")
+	}
+
+	levels.Lock()
+	defer levels.Unlock()
+
+	var revertAt time.Time
+	if revertAfter > 0 {
+		revertAt = time.Now().Add(revertAfter)
+	}
+
+	// A pending revert still returns to the level in force before the first temporary change
+	previous := Level.Level()
+	if levels.revert != nil {
+		previous = levels.base
+		levels.revert.Stop()
+		levels.revert = nil
+	}
+	Level.SetLevel(level)
+	levels.revertAt = revertAt
+	if revertAfter > 0 {
+		levels.base = previous
+		// A timer that fired while a newer change held the lock finds itself replaced
+		var timer *time.Timer
+		timer = time.AfterFunc(revertAfter, func() {
+			levels.Lock()
+			defer levels.Unlock()
+			if levels.revert != timer {
+				return
+			}
+			Level.SetLevel(previous)
+			levels.revert = nil
+			levels.revertAt = time.Time{}
+		})
+		levels.revert = timer
+	}
+	return LevelSetting{Level: level, RevertAt: revertAt}, nil
+}
+
+// CurrentLevel returns the level in force and when it reverts
+func CurrentLevel() LevelSetting {
+	levels.Lock()
+	defer levels.Unlock()
+
+	return LevelSetting{Level: Level.Level(), RevertAt: levels.revertAt}
+}
@@ -0,0 +1,39 @@
+package logger
+
+import (
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+func TestSetLevelReverts(t *testing.T) {
+	t.Cleanup(func() { SetLevel(zap.InfoLevel, 0) })
+	SetLevel(zap.InfoLevel, 0)
+
+	if _, err := SetLevel(zap.DebugLevel, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	setting, err := SetLevel(zap.WarnLevel, 20*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if setting.RevertAt.IsZero() || Level.Level() != zap.WarnLevel {
+		t.Fatalf("unexpected setting %+v at level %s", setting, Level.Level())
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for Level.Level() != zap.InfoLevel && time.Now().Before(deadline) {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if Level.Level() != zap.InfoLevel {
+		t.Errorf("expected the level to revert to info, got %s", Level.Level())
+	}
+	if current := CurrentLevel(); !current.RevertAt.IsZero() {
+		t.Errorf("expected no pending revert, got %+v", current)
+	}
+
+	if _, err := SetLevel(zap.DebugLevel, -time.Second); err == nil {
+		t.Error("expected a negative revert delay to be refused")
+	}
+}
@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -8,10 +10,17 @@ import (
 // Log is the global logger instance
 var Log *zap.Logger
 
-// Init initializes the Zap logger with JSON output
-func Init(env string) error {
+// Init initializes the Zap logger with JSON output at the given level, such as "debug" or "info".
+// The level can be changed later through SetLevel.
+func Init(env, level string) error {
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("error parsing log level: %v", err)
+	}
+	Level.SetLevel(parsed)
+
 	config := zap.Config{
-		Level:       zap.NewAtomicLevelAt(zap.InfoLevel),
+		Level:       Level,
 		Development: env == "development",
 		Encoding:    "json",
 		EncoderConfig: zapcore.EncoderConfig{
@@ -32,7 +41,6 @@ func Init(env string) error {
 		ErrorOutputPaths: []string{"stderr"},
 	}
 
-	var err error
 	Log, err = config.Build()
 	if err != nil {
 		return err
@@ -3,7 +3,8 @@ package ports
 import "context"
 
 // MessageProcessor defines the inbound port (driving side)
-// This is what the RabbitMQ consumer adapter will call
+// This is what the RabbitMQ consumer adapter will call.
+// One processor is registered per message type in the consumer's dispatcher.
 type MessageProcessor interface {
 	ProcessMessage(ctx context.Context, messageData []byte) error
 }
@@ -8,3 +8,13 @@ type ProcessRepository interface {
 	SaveProcessedMessage(ctx context.Context, messageID string, data any) error
 	GetProcessedMessage(ctx context.Context, messageID string) (any, error)
 }
+
+// EventPublisher defines the outbound port (driven side) for domain events
+// This is what the outbox relay needs from the message broker adapter
+type EventPublisher interface {
+	// Publish sends an event body with the given type and waits for the broker to confirm it
+	Publish(ctx context.Context, eventType, routingKey string, body []byte) error
+
+	// Close closes the broker connection
+	Close() error
+}